@@ -3,6 +3,7 @@ package config
 import (
 	"github.com/lucas-tremaroli/pace/internal/output"
 	"github.com/lucas-tremaroli/pace/internal/storage"
+	"github.com/lucas-tremaroli/pace/internal/task"
 	"github.com/spf13/cobra"
 )
 
@@ -21,7 +22,18 @@ var setCmd = &cobra.Command{
 		key := args[0]
 		value := args[1]
 
-		if err := db.SetConfig(key, value); err != nil {
+		// Refuse a workflow that every task command would then reject
+		err = db.WithTx(func(tx *storage.DB) error {
+			if err := tx.SetConfig(key, value); err != nil {
+				return err
+			}
+			if key == task.ConfigKeyStatuses || key == task.ConfigKeyTerminalStatuses {
+				_, err := task.LoadWorkflow(tx)
+				return err
+			}
+			return nil
+		})
+		if err != nil {
 			output.Error(err)
 		}
 
@@ -0,0 +1,16 @@
+package db
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var DBCmd = &cobra.Command{
+	Use:   "db",
+	Short: "Manage the task database",
+	Long:  `Inspect and maintain the task database for the current pace storage.`,
+}
+
+func init() {
+	DBCmd.GroupID = "configuration"
+	DBCmd.AddCommand(migrateCmd)
+}
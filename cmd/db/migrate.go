@@ -0,0 +1,60 @@
+package db
+
+import (
+	"github.com/lucas-tremaroli/pace/internal/output"
+	"github.com/lucas-tremaroli/pace/internal/storage"
+	"github.com/spf13/cobra"
+)
+
+var migrateStatus bool
+
+var migrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Apply pending schema migrations",
+	Long: `Applies pending schema migrations to the task database and outputs the result in JSON format.
+
+Migrations are also applied automatically whenever pace opens the database.
+Use --status to report the schema version without changing anything:
+  pace db migrate --status`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		db, err := storage.OpenDB()
+		if err != nil {
+			output.Error(err)
+		}
+		defer db.Close()
+
+		if migrateStatus {
+			status, err := db.MigrationStatus()
+			if err != nil {
+				output.Error(err)
+			}
+			output.Success("schema status", status)
+			return nil
+		}
+
+		applied, err := db.Migrate()
+		if err != nil {
+			output.Error(err)
+		}
+
+		version, err := db.SchemaVersion()
+		if err != nil {
+			output.Error(err)
+		}
+
+		message := "schema up to date"
+		if len(applied) > 0 {
+			message = "migrations applied"
+		}
+		output.Success(message, map[string]any{
+			"version": version,
+			"applied": applied,
+		})
+		return nil
+	},
+}
+
+func init() {
+	migrateCmd.Flags().BoolVar(&migrateStatus, "status", false, "Show applied and pending migrations without applying them")
+}
@@ -1,6 +1,8 @@
 package cmd
 
 import (
+	"strings"
+
 	"github.com/lucas-tremaroli/pace/internal/note"
 	"github.com/lucas-tremaroli/pace/internal/output"
 	"github.com/lucas-tremaroli/pace/internal/storage"
@@ -35,21 +37,26 @@ var infoCmd = &cobra.Command{
 			output.Error(err)
 		}
 
-		// Count tasks by status
+		workflow, err := taskSvc.Workflow()
+		if err != nil {
+			output.Error(err)
+		}
+
+		// Count tasks by workflow status, keyed like "in_progress"
+		statusKey := func(s task.Status) string { return strings.ReplaceAll(s.String(), "-", "_") }
 		taskStats := map[string]int{
-			"todo":        0,
-			"in_progress": 0,
-			"done":        0,
+			"total":    len(tasks),
+			"archived": 0,
+		}
+		for _, s := range workflow.Statuses() {
+			taskStats[statusKey(s)] = 0
 		}
 		for _, t := range tasks {
-			switch t.Status() {
-			case task.Todo:
-				taskStats["todo"]++
-			case task.InProgress:
-				taskStats["in_progress"]++
-			case task.Done:
-				taskStats["done"]++
+			if t.IsArchived() {
+				taskStats["archived"]++
+				continue
 			}
+			taskStats[statusKey(t.Status())]++
 		}
 
 		// Get note count
@@ -80,12 +87,7 @@ var infoCmd = &cobra.Command{
 				"path": resolved.Path,
 				"type": resolved.Type,
 			},
-			"tasks": map[string]any{
-				"total":       len(tasks),
-				"todo":        taskStats["todo"],
-				"in_progress": taskStats["in_progress"],
-				"done":        taskStats["done"],
-			},
+			"tasks": taskStats,
 			"notes": map[string]any{
 				"total": len(notes),
 			},
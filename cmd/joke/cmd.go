@@ -3,25 +3,28 @@ package joke
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/charmbracelet/x/term"
 	"github.com/lucas-tremaroli/pace/internal/joke"
+	"github.com/lucas-tremaroli/pace/internal/output"
 	"github.com/spf13/cobra"
 )
 
 var JokeCmd = &cobra.Command{
-	Use:   "joke",
-	Short: "Displays a random dad joke",
-	Long:  `Fetches a random dad joke from icanhazdadjoke.com just 4 fun.`,
-	Args:  cobra.NoArgs,
+	Use:         "joke",
+	Short:       "Displays a random dad joke",
+	Long:        `Fetches a random dad joke from icanhazdadjoke.com just 4 fun.`,
+	Args:        cobra.NoArgs,
+	Annotations: map[string]string{output.FormatAnnotation: output.FormatPretty},
 	RunE: func(cmd *cobra.Command, args []string) error {
 		svc := joke.NewService()
 
 		jokeText, err := svc.FetchJoke(context.Background())
 		if err != nil {
-			return err
+			output.Error(err)
 		}
 
 		// Get terminal width, default to 80 if unavailable
@@ -43,8 +46,9 @@ var JokeCmd = &cobra.Command{
 			MarginTop(1).
 			Width(maxWidth)
 
-		fmt.Println(jokeStyle.Render(jokeText))
-
+		output.Print(output.WithPretty(map[string]string{"joke": jokeText}, func(out io.Writer) {
+			fmt.Fprintln(out, jokeStyle.Render(jokeText))
+		}))
 		return nil
 	},
 }
@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"time"
+
+	"github.com/lucas-tremaroli/pace/internal/output"
+	"github.com/lucas-tremaroli/pace/internal/task"
+	"github.com/spf13/cobra"
+)
+
+var (
+	logSince string
+	logLimit int
+)
+
+var logCmd = &cobra.Command{
+	Use:   "log",
+	Short: "Show recent changes across all tasks",
+	Long: `Outputs the task change history for the whole store in JSON format, oldest first.
+
+Examples:
+  pace log --since 24h
+  pace log --since 2026-01-15 --limit 0`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var since time.Time
+		if logSince != "" {
+			parsed, err := task.ParseTimeBound(logSince)
+			if err != nil {
+				output.Error(err)
+			}
+			since = parsed
+		}
+
+		svc, err := task.NewService()
+		if err != nil {
+			output.Error(err)
+		}
+		defer svc.Close()
+
+		events, err := svc.EventsSince(since, logLimit)
+		if err != nil {
+			output.Error(err)
+		}
+
+		output.Print(map[string]any{
+			"events": events,
+			"count":  len(events),
+		})
+		return nil
+	},
+}
+
+func init() {
+	logCmd.GroupID = "core"
+	logCmd.Flags().StringVar(&logSince, "since", "", "Only changes at or after this time (YYYY-MM-DD, RFC3339, or 24h/7d/2w ago)")
+	logCmd.Flags().IntVar(&logLimit, "limit", 100, "Maximum number of most recent events to show (0 for all)")
+	rootCmd.AddCommand(logCmd)
+}
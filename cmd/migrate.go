@@ -3,11 +3,14 @@ package cmd
 import (
 	"fmt"
 	"io"
+	"maps"
 	"os"
 	"path/filepath"
+	"slices"
 
 	"github.com/lucas-tremaroli/pace/internal/output"
 	"github.com/lucas-tremaroli/pace/internal/storage"
+	"github.com/lucas-tremaroli/pace/internal/task"
 	"github.com/spf13/cobra"
 )
 
@@ -168,8 +171,35 @@ func migrateTasks(sourceDir, destDir string, dryRun bool) (map[string]any, error
 		destIDs[t.ID] = true
 	}
 
+	// Read dependencies and relations before migrated tasks are removed
+	// from the source along with them
+	blockedByMap, _, err := sourceDB.GetAllDependencies()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dependencies: %w", err)
+	}
+	relations, err := sourceDB.GetAllRelations()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get relations: %w", err)
+	}
+	migratedIDs := make(map[string]bool)
+	for _, t := range sourceTasks {
+		if !destIDs[t.ID] {
+			migratedIDs[t.ID] = true
+		}
+	}
+
+	// Custom types are numbered per store, so a task's type code is
+	// carried over by the name of its type
+	typeCodes := map[int]int{}
+	if !dryRun {
+		if typeCodes, err = migrateTaskTypes(sourceDB, destDB); err != nil {
+			return nil, err
+		}
+	}
+
 	var migrated, skipped int
 	var conflicts []string
+	var cycles [][]string
 
 	for _, task := range sourceTasks {
 		if destIDs[task.ID] {
@@ -181,7 +211,10 @@ func migrateTasks(sourceDir, destDir string, dryRun bool) (map[string]any, error
 
 		if !dryRun {
 			// Create task in destination
-			if err := destDB.CreateTask(task.ID, task.Title, task.Description, task.Status, task.TaskType, task.Priority, task.Link); err != nil {
+			if task.TaskType >= storage.FirstCustomTypeCode {
+				task.TaskType = typeCodes[task.TaskType]
+			}
+			if err := destDB.CreateTask(task); err != nil {
 				return nil, fmt.Errorf("failed to migrate task %s: %w", task.ID, err)
 			}
 
@@ -196,10 +229,66 @@ func migrateTasks(sourceDir, destDir string, dryRun bool) (map[string]any, error
 				}
 			}
 
+			// Copy history for this task
+			events, err := sourceDB.GetEvents(task.ID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get history for task %s: %w", task.ID, err)
+			}
+			for _, event := range events {
+				if err := destDB.AddEvent(event); err != nil {
+					return nil, fmt.Errorf("failed to migrate history for task %s: %w", task.ID, err)
+				}
+			}
+
+			// Copy comments for this task, renumbered in the destination
+			comments, err := sourceDB.GetComments(task.ID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get comments for task %s: %w", task.ID, err)
+			}
+			for _, comment := range comments {
+				comment.ID = 0
+				if _, err := destDB.AddComment(comment); err != nil {
+					return nil, fmt.Errorf("failed to migrate comment for task %s: %w", task.ID, err)
+				}
+			}
+
+			// Copy custom fields and links for this task
+			fields, err := sourceDB.GetTaskFields(task.ID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get fields for task %s: %w", task.ID, err)
+			}
+			for _, field := range fields {
+				if err := destDB.SetTaskField(task.ID, field); err != nil {
+					return nil, fmt.Errorf("failed to migrate field for task %s: %w", task.ID, err)
+				}
+			}
+			links, err := sourceDB.GetLinks(task.ID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get links for task %s: %w", task.ID, err)
+			}
+			for _, link := range links {
+				link.ID = 0
+				if _, err := destDB.AddLink(link); err != nil {
+					return nil, fmt.Errorf("failed to migrate link for task %s: %w", task.ID, err)
+				}
+			}
+
 			// Delete from source
+			if err := sourceDB.RemoveAllTaskFields(task.ID); err != nil {
+				return nil, fmt.Errorf("failed to remove fields from source task %s: %w", task.ID, err)
+			}
+			if err := sourceDB.RemoveAllLinks(task.ID); err != nil {
+				return nil, fmt.Errorf("failed to remove links from source task %s: %w", task.ID, err)
+			}
+			if err := sourceDB.RemoveAllComments(task.ID); err != nil {
+				return nil, fmt.Errorf("failed to remove comments from source task %s: %w", task.ID, err)
+			}
 			if err := sourceDB.RemoveAllLabels(task.ID); err != nil {
 				return nil, fmt.Errorf("failed to remove labels from source task %s: %w", task.ID, err)
 			}
+			if err := sourceDB.RemoveAllRelations(task.ID); err != nil {
+				return nil, fmt.Errorf("failed to remove relations from source task %s: %w", task.ID, err)
+			}
 			if err := sourceDB.RemoveAllDependencies(task.ID); err != nil {
 				return nil, fmt.Errorf("failed to remove dependencies from source task %s: %w", task.ID, err)
 			}
@@ -212,37 +301,79 @@ func migrateTasks(sourceDir, destDir string, dryRun bool) (map[string]any, error
 
 	// Migrate dependencies (only for tasks that were migrated)
 	if !dryRun && migrated > 0 {
-		blockedByMap, _, err := sourceDB.GetAllDependencies()
-		if err != nil {
-			return nil, fmt.Errorf("failed to get dependencies: %w", err)
-		}
-
-		migratedIDs := make(map[string]bool)
-		for _, task := range sourceTasks {
-			if !destIDs[task.ID] {
-				migratedIDs[task.ID] = true
-			}
-		}
-
-		for blockedID, blockerIDs := range blockedByMap {
+		// Migrated tasks are new to the destination, so only dependencies
+		// between them can form a cycle; the ones that would are skipped
+		blocks := make(map[string][]string)
+		for _, blockedID := range slices.Sorted(maps.Keys(blockedByMap)) {
 			if !migratedIDs[blockedID] {
 				continue
 			}
-			for _, blockerID := range blockerIDs {
-				if migratedIDs[blockerID] {
-					if err := destDB.AddDependency(blockerID, blockedID); err != nil {
-						return nil, fmt.Errorf("failed to migrate dependency: %w", err)
-					}
+			for _, blockerID := range blockedByMap[blockedID] {
+				if !migratedIDs[blockerID] {
+					continue
+				}
+				if cycle := task.DependencyCycle(blocks, blockerID, blockedID); cycle != nil {
+					cycles = append(cycles, cycle)
+					continue
 				}
+				if err := destDB.AddDependency(blockerID, blockedID); err != nil {
+					return nil, fmt.Errorf("failed to migrate dependency: %w", err)
+				}
+				blocks[blockerID] = append(blocks[blockerID], blockedID)
 			}
 		}
 	}
 
-	return map[string]any{
+	// Migrate relations between migrated tasks
+	if !dryRun && migrated > 0 {
+		for _, r := range relations {
+			if migratedIDs[r.TaskID] && migratedIDs[r.RelatedID] {
+				if err := destDB.AddRelation(r); err != nil {
+					return nil, fmt.Errorf("failed to migrate relation: %w", err)
+				}
+			}
+		}
+	}
+
+	result := map[string]any{
 		"migrated":  migrated,
 		"skipped":   skipped,
 		"conflicts": conflicts,
-	}, nil
+	}
+	if len(cycles) > 0 {
+		result["skipped_dependency_cycles"] = cycles
+	}
+	return result, nil
+}
+
+// migrateTaskTypes adds the source's custom types to the destination,
+// reusing a destination type of the same name, and returns each source
+// code mapped to its destination code
+func migrateTaskTypes(sourceDB, destDB *storage.DB) (map[int]int, error) {
+	sourceTypes, err := sourceDB.GetTaskTypes()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get source task types: %w", err)
+	}
+	destTypes, err := destDB.GetTaskTypes()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get destination task types: %w", err)
+	}
+	destCodes := make(map[string]int)
+	for _, t := range destTypes {
+		destCodes[t.Name] = t.Code
+	}
+
+	codes := make(map[int]int)
+	for _, t := range sourceTypes {
+		code, ok := destCodes[t.Name]
+		if !ok {
+			if code, err = destDB.AddTaskType(t); err != nil {
+				return nil, fmt.Errorf("failed to migrate task type %s: %w", t.Name, err)
+			}
+		}
+		codes[t.Code] = code
+	}
+	return codes, nil
 }
 
 func migrateNotes(sourceDir, destDir string, dryRun bool) (map[string]any, error) {
@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/lucas-tremaroli/pace/internal/storage"
+)
+
+// newMigrateDB creates a tasks database in a new directory and returns both
+func newMigrateDB(t *testing.T) (string, *storage.DB) {
+	t.Helper()
+	dir := t.TempDir()
+	db, err := storage.NewDBWithPath(filepath.Join(dir, "tasks.db"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return dir, db
+}
+
+func TestMigrateTasks_Dependencies(t *testing.T) {
+	sourceDir, source := newMigrateDB(t)
+	destDir, dest := newMigrateDB(t)
+
+	for _, id := range []string{"t-a", "t-b", "t-c"} {
+		if err := source.CreateTask(storage.TaskRecord{ID: id, Title: id, Status: "todo", Priority: 3}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	// An older store can hold a cycle: a blocks b, b blocks c, c blocks a
+	for _, dep := range [][2]string{{"t-a", "t-b"}, {"t-b", "t-c"}, {"t-c", "t-a"}} {
+		if err := source.AddDependency(dep[0], dep[1]); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	result, err := migrateTasks(sourceDir, destDir, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result["migrated"] != 3 {
+		t.Errorf("expected 3 migrated tasks, got %v", result["migrated"])
+	}
+
+	// Dependencies are added in order of the blocked task, so b blocking c
+	// is the one that would close the cycle
+	blockedBy, _, err := dest.GetAllDependencies()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !slices.Equal(blockedBy["t-a"], []string{"t-c"}) || !slices.Equal(blockedBy["t-b"], []string{"t-a"}) || len(blockedBy["t-c"]) != 0 {
+		t.Errorf("unexpected destination dependencies: %v", blockedBy)
+	}
+	cycles, _ := result["skipped_dependency_cycles"].([][]string)
+	if len(cycles) != 1 {
+		t.Errorf("expected 1 skipped cycle, got %v", result["skipped_dependency_cycles"])
+	}
+
+	left, _, err := source.GetAllDependencies()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(left) != 0 {
+		t.Errorf("expected no dependencies left in the source, got %v", left)
+	}
+}
+
+func TestMigrateTasks_TaskTypes(t *testing.T) {
+	sourceDir, source := newMigrateDB(t)
+	destDir, dest := newMigrateDB(t)
+
+	// The destination already numbers its own types, so codes differ
+	if _, err := dest.AddTaskType(storage.TypeRecord{Name: "chore", Symbol: "c", Color: "8"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := dest.AddTaskType(storage.TypeRecord{Name: "spike", Symbol: "s", Color: "5"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	spike, err := source.AddTaskType(storage.TypeRecord{Name: "spike", Symbol: "s", Color: "5"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	epic, err := source.AddTaskType(storage.TypeRecord{Name: "epic", Symbol: "e", Color: "4"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for id, code := range map[string]int{"t-spike": spike, "t-epic": epic, "t-bug": 1} {
+		if err := source.CreateTask(storage.TaskRecord{ID: id, Title: id, Status: "todo", Priority: 3, TaskType: code}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if _, err := migrateTasks(sourceDir, destDir, false); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	types, err := dest.GetTaskTypes()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	names := make(map[string]int)
+	for _, typ := range types {
+		names[typ.Name] = typ.Code
+	}
+	if len(types) != 3 {
+		t.Fatalf("expected 3 types in the destination, got %v", types)
+	}
+
+	want := map[string]int{"t-spike": names["spike"], "t-epic": names["epic"], "t-bug": 1}
+	for id, code := range want {
+		task, err := dest.GetTaskByID(id)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if task.TaskType != code {
+			t.Errorf("expected %s to have type code %d, got %d", id, code, task.TaskType)
+		}
+	}
+}
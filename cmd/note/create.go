@@ -14,24 +14,16 @@ import (
 
 var content string
 var editor string
-var createOutput string
 
 var createCmd = &cobra.Command{
-	Use:   "create [filename]",
-	Short: "Create a new note",
-	Long:  `Creates a new markdown note with the specified filename and content. Use --json for JSON output.`,
+	Use:         "create [filename]",
+	Short:       "Create a new note",
+	Long:        `Creates a new markdown note with the specified filename and content. Use --json (--format json) for JSON output.`,
+	Annotations: map[string]string{output.FormatAnnotation: output.FormatPretty},
 	RunE: func(cmd *cobra.Command, args []string) error {
 		svc, err := note.NewService()
-
-		if cmd.Flags().Changed("json") {
-			createOutput = "json"
-		}
-
 		if err != nil {
-			if createOutput == "json" {
-				output.Error(err)
-			}
-			return err
+			output.Error(err)
 		}
 
 		var filename string
@@ -46,10 +38,7 @@ var createCmd = &cobra.Command{
 			if (stat.Mode() & os.ModeCharDevice) == 0 {
 				stdinBytes, err := io.ReadAll(os.Stdin)
 				if err != nil {
-					if createOutput == "json" {
-						output.Error(err)
-					}
-					return err
+					output.Error(err)
 				}
 				content = string(stdinBytes)
 			}
@@ -57,10 +46,7 @@ var createCmd = &cobra.Command{
 
 		if content != "" {
 			if err := svc.WriteNote(filename, content); err != nil {
-				if createOutput == "json" {
-					output.Error(err)
-				}
-				return err
+				output.Error(err)
 			}
 			if cmd.Flags().Changed("editor") {
 				return svc.OpenInEditor(filename, editor)
@@ -68,21 +54,22 @@ var createCmd = &cobra.Command{
 
 			path := svc.GetNotePath(filename)
 
-			if createOutput == "json" {
-				output.Success("note created", map[string]string{
+			output.Print(output.WithPretty(output.Response{
+				Success: true,
+				Message: "note created",
+				Data: map[string]string{
 					"filename": filepath.Base(path),
 					"path":     path,
-				})
-				return nil
-			}
-
-			successStyle := lipgloss.NewStyle().
-				Bold(true).
-				Foreground(lipgloss.Color("10"))
-			pathStyle := lipgloss.NewStyle().
-				Foreground(lipgloss.Color("12")).
-				Underline(true)
-			fmt.Println(successStyle.Render("✓ Note created: ") + pathStyle.Render(path))
+				},
+			}, func(out io.Writer) {
+				successStyle := lipgloss.NewStyle().
+					Bold(true).
+					Foreground(lipgloss.Color("10"))
+				pathStyle := lipgloss.NewStyle().
+					Foreground(lipgloss.Color("12")).
+					Underline(true)
+				fmt.Fprintln(out, successStyle.Render("✓ Note created: ")+pathStyle.Render(path))
+			}))
 			return nil
 		}
 		return svc.OpenInEditor(filename, editor)
@@ -92,5 +79,5 @@ var createCmd = &cobra.Command{
 func init() {
 	createCmd.Flags().StringVarP(&content, "content", "c", "", "Write content directly to the note without opening the editor")
 	createCmd.Flags().StringVarP(&editor, "editor", "e", "nvim", "Editor to use for writing the note")
-	createCmd.Flags().Bool("json", false, "Output result in JSON format")
+	createCmd.Flags().Bool("json", false, "Shorthand for --format json")
 }
@@ -34,7 +34,7 @@ var listCmd = &cobra.Command{
 		}
 
 		sortNotes(notes, listSort)
-		output.JSON(noteListResponse{
+		output.Print(noteListResponse{
 			Notes: notes,
 			Count: len(notes),
 		})
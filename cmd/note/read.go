@@ -2,6 +2,7 @@ package note
 
 import (
 	"fmt"
+	"io"
 	"path/filepath"
 
 	"github.com/lucas-tremaroli/pace/internal/note"
@@ -9,53 +10,39 @@ import (
 	"github.com/spf13/cobra"
 )
 
-var readOutput string
-
 var readCmd = &cobra.Command{
-	Use:     "read <filename>",
-	Aliases: []string{"cat"},
-	Short:   "Read a note's content (alias: cat)",
-	Long:    `Reads and outputs a note's content. Use --json for JSON format. Alias: 'pace note cat'`,
-	Args:    cobra.ExactArgs(1),
+	Use:         "read <filename>",
+	Aliases:     []string{"cat"},
+	Short:       "Read a note's content (alias: cat)",
+	Long:        `Reads and outputs a note's content. Use --json (--format json) for JSON format. Alias: 'pace note cat'`,
+	Args:        cobra.ExactArgs(1),
+	Annotations: map[string]string{output.FormatAnnotation: output.FormatPretty},
 	RunE: func(cmd *cobra.Command, args []string) error {
 		filename := args[0]
 
-		if cmd.Flags().Changed("json") {
-			readOutput = "json"
-		}
-
 		svc, err := note.NewService()
 		if err != nil {
-			if readOutput == "json" {
-				output.Error(err)
-			}
-			return err
+			output.Error(err)
 		}
 
 		content, err := svc.ReadNote(filename)
 		if err != nil {
-			if readOutput == "json" {
-				output.Error(err)
-			}
-			return fmt.Errorf("failed to read note: %w", err)
-		}
-
-		if readOutput == "json" {
-			path := svc.GetNotePath(filename)
-			output.JSON(map[string]any{
-				"filename": filepath.Base(path),
-				"path":     path,
-				"content":  content,
-			})
-			return nil
+			output.Error(fmt.Errorf("failed to read note: %w", err))
 		}
 
-		// Raw content output
-		fmt.Print(content)
+		path := svc.GetNotePath(filename)
+		output.Print(output.WithPretty(map[string]any{
+			"filename": filepath.Base(path),
+			"path":     path,
+			"content":  content,
+		}, func(out io.Writer) {
+			// Raw content output
+			fmt.Fprint(out, content)
+		}))
 		return nil
 	},
 }
 
 func init() {
-	readCmd.Flags().Bool("json", false, "Output in JSON format")
+	readCmd.Flags().Bool("json", false, "Shorthand for --format json")
 }
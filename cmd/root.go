@@ -7,10 +7,13 @@ import (
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/lucas-tremaroli/pace/cmd/config"
+	"github.com/lucas-tremaroli/pace/cmd/db"
 	"github.com/lucas-tremaroli/pace/cmd/joke"
 	"github.com/lucas-tremaroli/pace/cmd/note"
 	"github.com/lucas-tremaroli/pace/cmd/task"
 	"github.com/lucas-tremaroli/pace/cmd/tick"
+	"github.com/lucas-tremaroli/pace/cmd/view"
+	"github.com/lucas-tremaroli/pace/internal/output"
 	"github.com/spf13/cobra"
 )
 
@@ -20,14 +23,42 @@ var (
 	dimStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
 )
 
+var outputFormat string
+
 var rootCmd = &cobra.Command{
 	Use:  "pace",
 	Long: `A simple CLI productivity tool for both humans and machines.`,
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		if err := selectFormat(cmd); err != nil {
+			output.Error(err)
+		}
+	},
 	Run: func(cmd *cobra.Command, args []string) {
 		cmd.Help()
 	},
 }
 
+// selectFormat selects the output format from --format, the --pretty and
+// --json shorthands some commands have, or the command's default format
+func selectFormat(cmd *cobra.Command) error {
+	format := cmd.Annotations[output.FormatAnnotation]
+	for flag, shorthand := range map[string]string{"pretty": output.FormatPretty, "json": output.FormatJSON} {
+		if f := cmd.Flags().Lookup(flag); f != nil && f.Value.String() == "true" {
+			if cmd.Flags().Changed("format") && outputFormat != shorthand {
+				return fmt.Errorf("--%s cannot be used with --format %s", flag, outputFormat)
+			}
+			format = shorthand
+		}
+	}
+	if cmd.Flags().Changed("format") {
+		format = outputFormat
+	}
+	if format == "" {
+		return nil
+	}
+	return output.SetFormat(format)
+}
+
 func SetVersionInfo(version, commit, date string) {
 	rootCmd.Version = fmt.Sprintf("%s (commit: %s, built: %s)", version, commit, date)
 }
@@ -92,6 +123,12 @@ func styledHelp(cmd *cobra.Command, _ []string) {
 		b.WriteString("\n")
 	}
 
+	if cmd.HasAvailableInheritedFlags() {
+		b.WriteString(headerStyle.Render("Global Flags") + "\n")
+		b.WriteString(cmd.InheritedFlags().FlagUsages())
+		b.WriteString("\n")
+	}
+
 	// Footer
 	b.WriteString(dimStyle.Render(fmt.Sprintf("Use \"%s [command] --help\" for more information about a command.", cmd.CommandPath())))
 	b.WriteString("\n")
@@ -105,10 +142,17 @@ func init() {
 	rootCmd.AddGroup(&cobra.Group{ID: "recharge", Title: "Recharge"})
 
 	rootCmd.AddCommand(task.TaskCmd)
+	rootCmd.AddCommand(view.ViewCmd)
 	rootCmd.AddCommand(note.NoteCmd)
 	rootCmd.AddCommand(tick.TickCmd)
 	rootCmd.AddCommand(joke.JokeCmd)
 	rootCmd.AddCommand(config.ConfigCmd)
+	rootCmd.AddCommand(db.DBCmd)
+
+	rootCmd.PersistentFlags().StringVar(&outputFormat, "format", "", "Output format: "+strings.Join(output.Formats(), ", ")+" (default json for most commands)")
+	rootCmd.RegisterFlagCompletionFunc("format", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return output.Formats(), cobra.ShellCompDirectiveNoFileComp
+	})
 
 	rootCmd.SetHelpFunc(styledHelp)
 }
@@ -0,0 +1,148 @@
+package task
+
+import (
+	"github.com/lucas-tremaroli/pace/internal/output"
+	"github.com/lucas-tremaroli/pace/internal/task"
+	"github.com/spf13/cobra"
+)
+
+var (
+	archiveFilters []string
+	archiveDryRun  bool
+	archiveAtomic  bool
+)
+
+var archiveCmd = &cobra.Command{
+	Use:   "archive [id] [id2] [id3] ...",
+	Short: "Archive one or more tasks by ID or filter",
+	Long: `Archives tasks and outputs the result in JSON format.
+
+Archived tasks are hidden from list, ready, search and the TUI unless
+--include-archived is given. They keep their labels and dependencies, so
+'pace task restore' brings them back unchanged.
+
+Archive by ID:
+  pace task archive pace-001
+  pace task archive pace-001 pace-002 pace-003
+
+Archive by filter:
+  pace task archive --filter status=done
+  pace task archive --filter label=sprint-1 --dry-run
+
+Use --atomic to archive all tasks or none if any fails.`,
+	Args: cobra.ArbitraryArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		// Check for conflicting options
+		if len(archiveFilters) > 0 && len(args) > 0 {
+			output.ErrorMsg("cannot use both task IDs and --filter (use one or the other)")
+		}
+
+		// Check if filter-based archive
+		if len(archiveFilters) > 0 {
+			return handleFilterArchive()
+		}
+
+		if len(args) == 0 {
+			output.ErrorMsg("task ID required (or use --filter for filter-based archiving)")
+		}
+
+		svc, err := task.NewService()
+		if err != nil {
+			output.Error(err)
+		}
+		defer svc.Close()
+		svc.BeginOperation("task archive")
+
+		if len(args) == 1 {
+			taskID := resolveID(svc, args[0])
+			if err := svc.ArchiveTask(taskID); err != nil {
+				output.Error(err)
+			}
+			output.Success("task archived", map[string]string{
+				"id": taskID,
+			})
+			return nil
+		}
+
+		result := runBatch(svc, archiveAtomic, len(args), func(i int) (output.BulkItem, error) {
+			taskID, err := svc.ResolveID(args[i])
+			if err != nil {
+				return output.BulkItem{ID: args[i]}, err
+			}
+			return output.BulkItem{ID: taskID}, svc.ArchiveTask(taskID)
+		})
+
+		output.BulkSuccess("tasks archived", result)
+		return nil
+	},
+}
+
+func handleFilterArchive() error {
+	mergedFilter, err := task.ParseFilters(archiveFilters)
+	if err != nil {
+		output.Error(err)
+	}
+
+	svc, err := task.NewService()
+	if err != nil {
+		output.Error(err)
+	}
+	defer svc.Close()
+	svc.BeginOperation("task archive --filter")
+
+	if err := svc.ValidateFilter(mergedFilter); err != nil {
+		output.Error(err)
+	}
+
+	tasks, err := svc.LoadTasks(false)
+	if err != nil {
+		output.Error(err)
+	}
+
+	// Filter tasks
+	var matchingTasks []task.Task
+	for _, t := range tasks {
+		if mergedFilter.Matches(t) {
+			matchingTasks = append(matchingTasks, t)
+		}
+	}
+
+	if len(matchingTasks) == 0 {
+		output.Success("no tasks matched filter", map[string]any{
+			"matched": 0,
+		})
+		return nil
+	}
+
+	// Dry run mode
+	if archiveDryRun {
+		var preview []map[string]any
+		for _, t := range matchingTasks {
+			preview = append(preview, map[string]any{
+				"id":     t.ID(),
+				"title":  t.Title(),
+				"status": t.Status().String(),
+				"type":   t.Type().String(),
+			})
+		}
+		output.Success("dry run - no tasks archived", map[string]any{
+			"matched": len(matchingTasks),
+			"preview": preview,
+		})
+		return nil
+	}
+
+	result := runBatch(svc, archiveAtomic, len(matchingTasks), func(i int) (output.BulkItem, error) {
+		t := matchingTasks[i]
+		return output.BulkItem{ID: t.ID(), Title: t.Title()}, svc.ArchiveTask(t.ID())
+	})
+
+	output.BulkSuccess("tasks archived", result)
+	return nil
+}
+
+func init() {
+	archiveCmd.Flags().StringArrayVar(&archiveFilters, "filter", nil, filterHelp(" selecting the tasks to archive"))
+	archiveCmd.Flags().BoolVar(&archiveDryRun, "dry-run", false, "Preview without archiving")
+	archiveCmd.Flags().BoolVar(&archiveAtomic, "atomic", false, "Archive all tasks or none")
+}
@@ -0,0 +1,63 @@
+package task
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/lucas-tremaroli/pace/internal/output"
+	"github.com/lucas-tremaroli/pace/internal/task"
+)
+
+// runBatch applies fn to items 0..n-1 and collects the outcome.
+//
+// Without atomic, each item runs in its own transaction, and a failed item
+// is reported while the remaining items still run. With atomic, all items
+// run in one transaction: the first failure (or warning) rolls back every
+// change, and the result is marked as rolled back.
+func runBatch(svc *task.Service, atomic bool, n int, fn func(i int) (output.BulkItem, error)) output.BulkResult {
+	result := output.BulkResult{Total: n}
+
+	if !atomic {
+		for i := 0; i < n; i++ {
+			var item output.BulkItem
+			err := svc.Atomic(func() error {
+				var err error
+				item, err = fn(i)
+				return err
+			})
+			if err != nil {
+				item.Error = err.Error()
+				item.Warnings = nil
+				result.Failed = append(result.Failed, item)
+				continue
+			}
+			result.Succeeded = append(result.Succeeded, item)
+		}
+		return result
+	}
+
+	err := svc.Atomic(func() error {
+		for i := 0; i < n; i++ {
+			item, err := fn(i)
+			if err == nil && len(item.Warnings) > 0 {
+				err = errors.New(strings.Join(item.Warnings, "; "))
+			}
+			if err != nil {
+				item.Error = err.Error()
+				item.Warnings = nil
+				result.Failed = append(result.Failed, item)
+				return err
+			}
+			result.Succeeded = append(result.Succeeded, item)
+		}
+		return nil
+	})
+	if err != nil {
+		if len(result.Failed) == 0 {
+			result.Failed = append(result.Failed, output.BulkItem{Error: err.Error()})
+		}
+		result.Succeeded = nil
+		result.RolledBack = true
+	}
+	return result
+}
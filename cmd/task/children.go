@@ -0,0 +1,51 @@
+package task
+
+import (
+	"github.com/lucas-tremaroli/pace/internal/output"
+	"github.com/lucas-tremaroli/pace/internal/task"
+	"github.com/spf13/cobra"
+)
+
+var childrenIncludeArchived bool
+
+var childrenCmd = &cobra.Command{
+	Use:   "children <id>",
+	Short: "List the subtasks of a task",
+	Long: `Outputs the direct subtasks of a task in JSON format, with the parent's
+progress (the share of its subtasks that are done).`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		svc, err := task.NewService()
+		if err != nil {
+			output.Error(err)
+		}
+		defer svc.Close()
+
+		parent, err := svc.GetTaskByID(resolveID(svc, args[0]))
+		if err != nil {
+			output.Error(err)
+		}
+
+		children, err := svc.Children(parent.ID(), childrenIncludeArchived)
+		if err != nil {
+			output.Error(err)
+		}
+
+		childJSONs := make([]task.TaskJSON, len(children))
+		for i, t := range children {
+			childJSONs[i] = t.ToJSON()
+		}
+
+		output.Print(map[string]any{
+			"task_id":  parent.ID(),
+			"progress": parent.Progress(),
+			"children": childJSONs,
+			"count":    len(childJSONs),
+		})
+		return nil
+	},
+}
+
+func init() {
+	childrenCmd.Flags().BoolVar(&childrenIncludeArchived, "include-archived", false, "Include archived subtasks")
+}
@@ -1,6 +1,8 @@
 package task
 
 import (
+	"github.com/lucas-tremaroli/pace/internal/output"
+	"github.com/lucas-tremaroli/pace/internal/task"
 	"github.com/spf13/cobra"
 )
 
@@ -19,7 +21,49 @@ func init() {
 	TaskCmd.AddCommand(createCmd)
 	TaskCmd.AddCommand(updateCmd)
 	TaskCmd.AddCommand(deleteCmd)
+	TaskCmd.AddCommand(archiveCmd)
+	TaskCmd.AddCommand(restoreCmd)
 	TaskCmd.AddCommand(depCmd)
+	TaskCmd.AddCommand(relCmd)
+	TaskCmd.AddCommand(childrenCmd)
+	TaskCmd.AddCommand(statusesCmd)
+	TaskCmd.AddCommand(typeCmd)
 	TaskCmd.AddCommand(readyCmd)
+	TaskCmd.AddCommand(overdueCmd)
+	TaskCmd.AddCommand(planCmd)
+	TaskCmd.AddCommand(mineCmd)
 	TaskCmd.AddCommand(searchCmd)
+	TaskCmd.AddCommand(historyCmd)
+	TaskCmd.AddCommand(commentCmd)
+	TaskCmd.AddCommand(linkCmd)
+}
+
+// workflow returns the store's statuses, exiting with an error if they
+// are misconfigured
+func workflow(svc *task.Service) task.Workflow {
+	w, err := svc.Workflow()
+	if err != nil {
+		output.Error(err)
+	}
+	return w
+}
+
+// taskTypes returns the store's task types, exiting with an error if they
+// cannot be loaded
+func taskTypes(svc *task.Service) task.TypeRegistry {
+	types, err := svc.Types()
+	if err != nil {
+		output.Error(err)
+	}
+	return types
+}
+
+// resolveID expands a short task ID, exiting with an error unless it
+// matches exactly one task
+func resolveID(svc *task.Service, id string) string {
+	resolved, err := svc.ResolveID(id)
+	if err != nil {
+		output.Error(err)
+	}
+	return resolved
 }
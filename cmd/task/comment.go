@@ -0,0 +1,123 @@
+package task
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/lucas-tremaroli/pace/internal/output"
+	"github.com/lucas-tremaroli/pace/internal/task"
+	"github.com/spf13/cobra"
+)
+
+var commentCmd = &cobra.Command{
+	Use:   "comment",
+	Short: "Manage task comments",
+	Long: `Manage the comment log on a task.
+
+Comments are separate, timestamped entries with an author (from $PACE_ACTOR,
+or the OS username), so several people or agents can record progress on the
+same task without overwriting each other's notes in the description.`,
+}
+
+var commentAddCmd = &cobra.Command{
+	Use:   "add <task-id> <body>",
+	Short: "Add a comment to a task",
+	Long: `Adds a markdown comment to a task. Use - as the body to read it from stdin.
+
+Examples:
+  pace task comment add pace-a1b "Tried X, failed because Y"
+  git diff --stat | pace task comment add pace-a1b -`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		body := args[1]
+		if body == "-" {
+			data, err := io.ReadAll(os.Stdin)
+			if err != nil {
+				output.Error(fmt.Errorf("failed to read stdin: %w", err))
+			}
+			body = string(data)
+		}
+
+		svc, err := task.NewService()
+		if err != nil {
+			output.Error(err)
+		}
+		defer svc.Close()
+		svc.BeginOperation("task comment add")
+
+		taskID := resolveID(svc, args[0])
+		comment, err := svc.AddComment(taskID, body)
+		if err != nil {
+			output.Error(err)
+		}
+
+		output.Success("comment added", comment)
+		return nil
+	},
+}
+
+var commentListCmd = &cobra.Command{
+	Use:   "list <task-id>",
+	Short: "List the comments on a task",
+	Long:  `Outputs the comments on a task in JSON format, oldest first.`,
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		svc, err := task.NewService()
+		if err != nil {
+			output.Error(err)
+		}
+		defer svc.Close()
+
+		taskID := resolveID(svc, args[0])
+		comments, err := svc.ListComments(taskID)
+		if err != nil {
+			output.Error(err)
+		}
+
+		output.Print(map[string]any{
+			"task_id":  taskID,
+			"comments": comments,
+			"count":    len(comments),
+		})
+		return nil
+	},
+}
+
+var commentDeleteCmd = &cobra.Command{
+	Use:   "delete <comment-id>",
+	Short: "Delete a comment",
+	Long:  `Deletes a comment by the numeric ID shown in 'pace task comment list'.`,
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		commentID, err := strconv.ParseInt(args[0], 10, 64)
+		if err != nil {
+			output.ErrorMsg("invalid comment ID: " + args[0])
+		}
+
+		svc, err := task.NewService()
+		if err != nil {
+			output.Error(err)
+		}
+		defer svc.Close()
+		svc.BeginOperation("task comment delete")
+
+		comment, err := svc.DeleteComment(commentID)
+		if err != nil {
+			output.Error(err)
+		}
+
+		output.Success("comment deleted", map[string]any{
+			"id":      comment.ID,
+			"task_id": comment.TaskID,
+		})
+		return nil
+	},
+}
+
+func init() {
+	commentCmd.AddCommand(commentAddCmd)
+	commentCmd.AddCommand(commentListCmd)
+	commentCmd.AddCommand(commentDeleteCmd)
+}
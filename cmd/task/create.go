@@ -2,6 +2,7 @@ package task
 
 import (
 	"encoding/json"
+	"errors"
 	"io"
 	"os"
 
@@ -18,7 +19,13 @@ var (
 	createPriority    int
 	createLabels      []string
 	createLink        string
+	createParent      string
+	createDue         string
+	createEstimate    float64
+	createAssignee    string
+	createFields      []string
 	createBulk        string
+	createAtomic      bool
 )
 
 var createCmd = &cobra.Command{
@@ -28,7 +35,27 @@ var createCmd = &cobra.Command{
 
 For bulk creation, use --bulk with a JSON array or '-' for stdin:
   pace task create --bulk '[{"title":"Task 1"},{"title":"Task 2"}]'
-  cat tasks.json | pace task create --bulk -`,
+  cat tasks.json | pace task create --bulk -
+
+Use --parent (or "parent" in bulk input) to create a subtask of an epic:
+  pace task create --title "Login form" --parent pace-a1b
+
+Use --due (or "due" in bulk input) to set a due date:
+  pace task create --title "Release notes" --due friday
+
+Use --estimate (or "estimate" in bulk input) to size a task for 'pace task plan':
+  pace task create --title "Login form" --estimate 3
+
+Use --assignee (or "assignee" in bulk input) to assign a task; "me" is you
+($PACE_ACTOR, or your username), and agents are named agent:<name>:
+  pace task create --title "Write tests" --assignee agent:claude
+
+Use --field (or "fields" in bulk input) to set custom fields; the type is
+inferred from the value, or given as key:type=value:
+  pace task create --title "Fix login" --field component=auth --field points:number=3
+  pace task create --bulk '[{"title":"Fix login","fields":{"component":"auth","customer":true}}]'
+
+Use --atomic to create all tasks or none if any of them fails.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		// Handle bulk creation
 		if createBulk != "" {
@@ -40,25 +67,48 @@ For bulk creation, use --bulk with a JSON array or '-' for stdin:
 			output.ErrorMsg("title is required")
 		}
 
-		status, err := task.ParseStatus(createStatus)
+		svc, err := task.NewService()
 		if err != nil {
 			output.Error(err)
 		}
+		defer svc.Close()
+		svc.BeginOperation("task create")
 
-		taskType, err := task.ParseTaskType(createType)
+		taskType, err := taskTypes(svc).Parse(createType)
 		if err != nil {
 			output.Error(err)
 		}
 
-		svc, err := task.NewService()
+		w := workflow(svc)
+		status := w.Initial()
+		if createStatus != "" {
+			status, err = w.Parse(createStatus)
+			if err != nil {
+				output.Error(err)
+			}
+		}
+
+		newTask := task.NewTaskComplete("", status, taskType, createTitle, createDescription, createPriority, createLink)
+		if createParent != "" {
+			newTask.SetParentID(resolveID(svc, createParent))
+		}
+		if createDue != "" {
+			due, err := task.ParseDueDate(createDue)
+			if err != nil {
+				output.Error(err)
+			}
+			newTask.SetDue(due)
+		}
+		newTask.SetEstimate(createEstimate)
+		assignee, err := task.ParseAssignee(createAssignee)
 		if err != nil {
 			output.Error(err)
 		}
-		defer svc.Close()
+		newTask.SetAssignee(assignee)
+		newTask.SetFields(parseFieldFlags(createFields))
 
-		newTask := task.NewTaskComplete(svc.GenerateTaskID(), status, taskType, createTitle, createDescription, createPriority, createLink)
-
-		if err := svc.CreateTask(newTask); err != nil {
+		newTask, err = svc.CreateNewTask(newTask)
+		if err != nil {
 			output.Error(err)
 		}
 
@@ -104,90 +154,112 @@ func handleBulkCreate(bulkInput string) error {
 		output.Error(err)
 	}
 	defer svc.Close()
+	svc.BeginOperation("task create --bulk")
 
-	result := output.BulkResult{
-		Total: len(inputs),
-	}
+	result := runBatch(svc, createAtomic, len(inputs), func(i int) (output.BulkItem, error) {
+		return createFromInput(svc, inputs[i])
+	})
 
-	for _, input := range inputs {
-		if input.Title == "" {
-			result.Failed = append(result.Failed, output.BulkItem{
-				Title: "(empty)",
-				Error: "title is required",
-			})
-			continue
-		}
+	output.BulkSuccess("tasks created", result)
+	return nil
+}
 
-		// Parse status (default to todo)
-		statusStr := input.Status
-		if statusStr == "" {
-			statusStr = "todo"
-		}
-		status, err := task.ParseStatus(statusStr)
+// createFromInput creates a single task from bulk input
+func createFromInput(svc *task.Service, input task.TaskInput) (output.BulkItem, error) {
+	if input.Title == "" {
+		return output.BulkItem{Title: "(empty)"}, errors.New("title is required")
+	}
+	item := output.BulkItem{Title: input.Title}
+
+	// Parse status (default to the first status of the workflow)
+	w, err := svc.Workflow()
+	if err != nil {
+		return item, err
+	}
+	status := w.Initial()
+	if input.Status != "" {
+		status, err = w.Parse(input.Status)
 		if err != nil {
-			result.Failed = append(result.Failed, output.BulkItem{
-				Title: input.Title,
-				Error: err.Error(),
-			})
-			continue
+			return item, err
 		}
+	}
+
+	// Parse type (default to task)
+	types, err := svc.Types()
+	if err != nil {
+		return item, err
+	}
+	taskType, err := types.Parse(input.Type)
+	if err != nil {
+		return item, err
+	}
+
+	// Default priority to 3 (normal) if not specified
+	priority := input.Priority
+	if priority == 0 {
+		priority = 3
+	}
 
-		// Parse type (default to task)
-		typeStr := input.Type
-		if typeStr == "" {
-			typeStr = "task"
+	newTask := task.NewTaskComplete("", status, taskType, input.Title, input.Description, priority, input.Link)
+	if input.Parent != "" {
+		parentID, err := svc.ResolveID(input.Parent)
+		if err != nil {
+			return item, err
 		}
-		taskType, err := task.ParseTaskType(typeStr)
+		newTask.SetParentID(parentID)
+	}
+	if input.Due != "" {
+		due, err := task.ParseDueDate(input.Due)
 		if err != nil {
-			result.Failed = append(result.Failed, output.BulkItem{
-				Title: input.Title,
-				Error: err.Error(),
-			})
-			continue
+			return item, err
 		}
-
-		// Default priority to 3 (normal) if not specified
-		priority := input.Priority
-		if priority == 0 {
-			priority = 3
+		newTask.SetDue(due)
+	}
+	newTask.SetEstimate(input.Estimate)
+	assignee, err := task.ParseAssignee(input.Assignee)
+	if err != nil {
+		return item, err
+	}
+	newTask.SetAssignee(assignee)
+	var fields []task.Field
+	for key, value := range input.Fields {
+		field, err := task.FieldFromJSON(key, value)
+		if err != nil {
+			return item, err
 		}
+		fields = append(fields, field)
+	}
+	newTask.SetFields(fields)
 
-		newTask := task.NewTaskComplete(svc.GenerateTaskID(), status, taskType, input.Title, input.Description, priority, input.Link)
-
-		if err := svc.CreateTask(newTask); err != nil {
-			result.Failed = append(result.Failed, output.BulkItem{
-				Title: input.Title,
-				Error: err.Error(),
-			})
-			continue
-		}
+	newTask, err = svc.CreateNewTask(newTask)
+	if err != nil {
+		return item, err
+	}
+	item.ID = newTask.ID()
 
-		// Add labels if specified, track warnings for failures
-		var warnings []string
-		for _, label := range input.Labels {
-			if err := svc.AddLabel(newTask.ID(), label); err != nil {
-				warnings = append(warnings, "add label '"+label+"': "+err.Error())
-			}
+	// Add labels if specified, track warnings for failures
+	for _, label := range input.Labels {
+		if err := svc.AddLabel(newTask.ID(), label); err != nil {
+			item.Warnings = append(item.Warnings, "add label '"+label+"': "+err.Error())
 		}
-
-		result.Succeeded = append(result.Succeeded, output.BulkItem{
-			ID:       newTask.ID(),
-			Title:    input.Title,
-			Warnings: warnings,
-		})
 	}
 
-	output.BulkSuccess("tasks created", result)
-	return nil
+	return item, nil
 }
 
 func init() {
 	createCmd.Flags().StringVar(&createTitle, "title", "", "Task title (required for single task creation)")
 	createCmd.Flags().StringVar(&createDescription, "description", "", "Task description")
-	createCmd.Flags().StringVar(&createStatus, "status", "todo", "Task status (todo, in-progress, done)")
-	createCmd.Flags().StringVar(&createType, "type", "task", "Task type (task, bug, feature, chore, docs)")
+	createCmd.Flags().StringVar(&createStatus, "status", "", "Task status (default: the first status of the workflow)")
+	createCmd.Flags().StringVar(&createType, "type", "task", "Task type (task, bug, feature, chore, docs, or a custom type)")
 	createCmd.Flags().IntVar(&createPriority, "priority", 3, "Task priority (1=urgent, 2=high, 3=normal, 4=low)")
 	createCmd.Flags().StringSliceVar(&createLabels, "label", nil, "Task labels (can be specified multiple times)")
 	createCmd.Flags().StringVar(&createLink, "url", "", "URL associated with the task (e.g., google.com)")
+	createCmd.Flags().StringVar(&createParent, "parent", "", "Parent task ID, making this a subtask")
+	createCmd.Flags().StringVar(&createDue, "due", "", "Due date (YYYY-MM-DD, today, tomorrow, +3d, +2w, or a weekday)")
+	createCmd.Flags().Float64Var(&createEstimate, "estimate", 0, "Estimate in the store's estimate unit (points by default)")
+	createCmd.Flags().StringVar(&createAssignee, "assignee", "", "Who the task is assigned to (\"me\" for yourself, agent:<name> for an agent)")
+	createCmd.Flags().StringArrayVar(&createFields, "field", nil, "Custom field as key=value or key:type=value (can be specified multiple times)")
 	createCmd.Flags().StringVar(&createBulk, "bulk", "", "JSON array of tasks to create, or '-' for stdin")
+	createCmd.Flags().BoolVar(&createAtomic, "atomic", false, "With --bulk, create all tasks or none")
 }
@@ -9,6 +9,7 @@ import (
 var (
 	deleteFilters []string
 	deleteDryRun  bool
+	deleteAtomic  bool
 )
 
 var deleteCmd = &cobra.Command{
@@ -23,7 +24,13 @@ Delete by ID:
 Delete by filter:
   pace task delete --filter status=done
   pace task delete --filter type=bug --filter priority=4
-  pace task delete --filter label=sprint-1 --dry-run`,
+  pace task delete --filter label=sprint-1 --dry-run
+  pace task delete --filter archived=true
+  pace task delete --filter 'status=done AND updated<30d'
+
+Delete is permanent. Use 'pace task archive' to hide tasks and keep them restorable.
+
+Use --atomic to delete all tasks or none if any deletion fails.`,
 	Args: cobra.ArbitraryArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		// Check for conflicting options
@@ -46,10 +53,11 @@ Delete by filter:
 			output.Error(err)
 		}
 		defer svc.Close()
+		svc.BeginOperation("task delete")
 
 		// Single ID: backward compatible behavior
 		if len(args) == 1 {
-			taskID := args[0]
+			taskID := resolveID(svc, args[0])
 			if err := svc.DeleteTask(taskID); err != nil {
 				output.Error(err)
 			}
@@ -60,22 +68,13 @@ Delete by filter:
 		}
 
 		// Multiple IDs: bulk delete
-		result := output.BulkResult{
-			Total: len(args),
-		}
-
-		for _, taskID := range args {
-			if err := svc.DeleteTask(taskID); err != nil {
-				result.Failed = append(result.Failed, output.BulkItem{
-					ID:    taskID,
-					Error: err.Error(),
-				})
-			} else {
-				result.Succeeded = append(result.Succeeded, output.BulkItem{
-					ID: taskID,
-				})
+		result := runBatch(svc, deleteAtomic, len(args), func(i int) (output.BulkItem, error) {
+			taskID, err := svc.ResolveID(args[i])
+			if err != nil {
+				return output.BulkItem{ID: args[i]}, err
 			}
-		}
+			return output.BulkItem{ID: taskID}, svc.DeleteTask(taskID)
+		})
 
 		output.BulkSuccess("tasks deleted", result)
 		return nil
@@ -83,16 +82,7 @@ Delete by filter:
 }
 
 func handleFilterDelete() error {
-	// Parse filters
-	var filters []*task.TaskFilter
-	for _, f := range deleteFilters {
-		filter, err := task.ParseFilter(f)
-		if err != nil {
-			output.Error(err)
-		}
-		filters = append(filters, filter)
-	}
-	mergedFilter, err := task.MergeFilters(filters)
+	mergedFilter, err := task.ParseFilters(deleteFilters)
 	if err != nil {
 		output.Error(err)
 	}
@@ -102,9 +92,14 @@ func handleFilterDelete() error {
 		output.Error(err)
 	}
 	defer svc.Close()
+	svc.BeginOperation("task delete --filter")
 
-	// Load all tasks
-	tasks, err := svc.LoadAllTasks()
+	if err := svc.ValidateFilter(mergedFilter); err != nil {
+		output.Error(err)
+	}
+
+	// Archived tasks are only matched by an explicit archived filter
+	tasks, err := svc.LoadTasks(mergedFilter.SelectsArchived())
 	if err != nil {
 		output.Error(err)
 	}
@@ -143,30 +138,17 @@ func handleFilterDelete() error {
 	}
 
 	// Delete matching tasks
-	result := output.BulkResult{
-		Total: len(matchingTasks),
-	}
-
-	for _, t := range matchingTasks {
-		if err := svc.DeleteTask(t.ID()); err != nil {
-			result.Failed = append(result.Failed, output.BulkItem{
-				ID:    t.ID(),
-				Title: t.Title(),
-				Error: err.Error(),
-			})
-		} else {
-			result.Succeeded = append(result.Succeeded, output.BulkItem{
-				ID:    t.ID(),
-				Title: t.Title(),
-			})
-		}
-	}
+	result := runBatch(svc, deleteAtomic, len(matchingTasks), func(i int) (output.BulkItem, error) {
+		t := matchingTasks[i]
+		return output.BulkItem{ID: t.ID(), Title: t.Title()}, svc.DeleteTask(t.ID())
+	})
 
 	output.BulkSuccess("tasks deleted", result)
 	return nil
 }
 
 func init() {
-	deleteCmd.Flags().StringArrayVar(&deleteFilters, "filter", nil, "Filter tasks to delete (status=X, type=X, priority=X, label=X)")
+	deleteCmd.Flags().StringArrayVar(&deleteFilters, "filter", nil, filterHelp(" selecting the tasks to delete"))
 	deleteCmd.Flags().BoolVar(&deleteDryRun, "dry-run", false, "Preview deletions without applying them")
+	deleteCmd.Flags().BoolVar(&deleteAtomic, "atomic", false, "Delete all tasks or none")
 }
@@ -2,6 +2,7 @@ package task
 
 import (
 	"fmt"
+	"io"
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
@@ -22,14 +23,15 @@ var depAddCmd = &cobra.Command{
 	Long:  `Creates a blocking relationship where the first task blocks the second task.`,
 	Args:  cobra.ExactArgs(2),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		blockerID := args[0]
-		blockedID := args[1]
-
 		svc, err := task.NewService()
 		if err != nil {
 			output.Error(err)
 		}
 		defer svc.Close()
+		svc.BeginOperation("task dep add")
+
+		blockerID := resolveID(svc, args[0])
+		blockedID := resolveID(svc, args[1])
 
 		if err := svc.AddDependency(blockerID, blockedID); err != nil {
 			output.Error(err)
@@ -49,14 +51,15 @@ var depRemoveCmd = &cobra.Command{
 	Long:  `Removes a blocking relationship between two tasks.`,
 	Args:  cobra.ExactArgs(2),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		blockerID := args[0]
-		blockedID := args[1]
-
 		svc, err := task.NewService()
 		if err != nil {
 			output.Error(err)
 		}
 		defer svc.Close()
+		svc.BeginOperation("task dep remove")
+
+		blockerID := resolveID(svc, args[0])
+		blockedID := resolveID(svc, args[1])
 
 		if err := svc.RemoveDependency(blockerID, blockedID); err != nil {
 			output.Error(err)
@@ -76,20 +79,20 @@ var depListCmd = &cobra.Command{
 	Long:  `Shows what tasks block the given task and what tasks it blocks.`,
 	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		taskID := args[0]
-
 		svc, err := task.NewService()
 		if err != nil {
 			output.Error(err)
 		}
 		defer svc.Close()
 
+		taskID := resolveID(svc, args[0])
+
 		t, err := svc.GetTaskByID(taskID)
 		if err != nil {
 			output.Error(err)
 		}
 
-		output.JSON(map[string]any{
+		output.Print(map[string]any{
 			"task_id":    taskID,
 			"blocked_by": t.BlockedBy(),
 			"blocks":     t.Blocks(),
@@ -98,6 +101,39 @@ var depListCmd = &cobra.Command{
 	},
 }
 
+var depCheckCmd = &cobra.Command{
+	Use:   "check",
+	Short: "Report dependency cycles",
+	Long: `Checks every dependency for cycles, where tasks block each other and can
+never become ready. New dependencies that would close a cycle are rejected,
+so cycles only exist in stores created before that check.
+
+Each cycle is reported once, as the path of blocking tasks starting and
+ending with the same task. Exits with an error if any cycle is found; remove
+one of its dependencies with 'pace task dep remove' to break it.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		svc, err := task.NewService()
+		if err != nil {
+			output.Error(err)
+		}
+		defer svc.Close()
+
+		if err := svc.CheckDependencies(); err != nil {
+			output.Error(err)
+		}
+
+		output.Success("no dependency cycles", map[string]any{
+			"cycles": [][]string{},
+			"count":  0,
+		})
+		return nil
+	},
+}
+
+// Flags for dep chain command
+var chainAtomic bool
+
 // Flags for dep tree command
 var (
 	treeDirection string
@@ -106,15 +142,20 @@ var (
 )
 
 var depTreeCmd = &cobra.Command{
-	Use:   "tree <task-id>",
-	Short: "Visualize dependency tree for a task",
+	Use:         "tree <task-id>",
+	Short:       "Visualize dependency tree for a task",
+	Annotations: map[string]string{output.FormatAnnotation: output.FormatPretty},
 	Long: `Shows an ASCII tree of blockers (what blocks this task) and what this task blocks.
+Other output formats, such as --format json, nest the same tasks as data.
 
 Use --direction to control which relationships to show:
   - up:   Show what blocks this task (default)
   - down: Show what this task blocks
   - both: Show full graph in both directions
 
+The task's parent and its subtasks are always shown, with subtasks nested
+and their progress rolled up.
+
 Examples:
   pace task dep tree pace-abc                      # Show what blocks pace-abc
   pace task dep tree pace-abc --direction=down     # Show what pace-abc blocks
@@ -122,28 +163,29 @@ Examples:
   pace task dep tree pace-abc -d 2                 # Limit to 2 levels deep`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		taskID := args[0]
-
 		// Validate direction flag
 		if treeDirection != "down" && treeDirection != "up" && treeDirection != "both" {
 			output.Error(fmt.Errorf("invalid direction: %s (valid: down, up, both)", treeDirection))
 		}
 
+		svc, err := task.NewService()
+		if err != nil {
+			output.Error(err)
+		}
+		defer svc.Close()
+
 		// Validate status flag if provided
+		w := workflow(svc)
 		var filterStatus *task.Status
 		if treeStatus != "" {
-			s, err := task.ParseStatus(treeStatus)
+			s, err := w.Parse(treeStatus)
 			if err != nil {
 				output.Error(err)
 			}
 			filterStatus = &s
 		}
 
-		svc, err := task.NewService()
-		if err != nil {
-			output.Error(err)
-		}
-		defer svc.Close()
+		taskID := resolveID(svc, args[0])
 
 		// Load all tasks to build the full dependency graph
 		tasks, err := svc.LoadAllTasks()
@@ -168,8 +210,12 @@ Examples:
 			filterStatus: filterStatus,
 			maxDepth:     treeMaxDepth,
 			taskMap:      taskMap,
+			workflow:     w,
 		}
-		printDepTree(rootTask, opts)
+		output.Print(output.WithPretty(buildDepTree(rootTask, opts), func(out io.Writer) {
+			opts.out = out
+			printDepTree(rootTask, opts)
+		}))
 		return nil
 	},
 }
@@ -182,7 +228,12 @@ The first task blocks the second, the second blocks the third, and so on.
 
 Example:
   pace task dep chain pace-001 pace-002 pace-003
-  Creates: pace-001 blocks pace-002, pace-002 blocks pace-003`,
+  Creates: pace-001 blocks pace-002, pace-002 blocks pace-003
+
+Links that would create a dependency cycle are rejected like any other
+dependency, e.g. repeating pace-001 at the end of the chain above.
+
+Use --atomic to create every link or none if any link fails.`,
 	Args: cobra.MinimumNArgs(2),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		svc, err := task.NewService()
@@ -190,23 +241,41 @@ Example:
 			output.Error(err)
 		}
 		defer svc.Close()
+		svc.BeginOperation("task dep chain")
+
+		// Resolve every ID up front so a typo fails before any link is added
+		for i := range args {
+			args[i] = resolveID(svc, args[i])
+		}
 
 		var dependencies []map[string]string
 		var errors []string
 
 		// Create sequential dependencies
-		for i := 0; i < len(args)-1; i++ {
-			blockerID := args[i]
-			blockedID := args[i+1]
-
-			if err := svc.AddDependency(blockerID, blockedID); err != nil {
-				errors = append(errors, fmt.Sprintf("%s->%s: %s", blockerID, blockedID, err.Error()))
-			} else {
-				dependencies = append(dependencies, map[string]string{
-					"blocker": blockerID,
-					"blocked": blockedID,
-				})
+		addChain := func() error {
+			for i := 0; i < len(args)-1; i++ {
+				blockerID := args[i]
+				blockedID := args[i+1]
+
+				if err := svc.AddDependency(blockerID, blockedID); err != nil {
+					if chainAtomic {
+						return fmt.Errorf("%s->%s: %w", blockerID, blockedID, err)
+					}
+					errors = append(errors, fmt.Sprintf("%s->%s: %s", blockerID, blockedID, err.Error()))
+				} else {
+					dependencies = append(dependencies, map[string]string{
+						"blocker": blockerID,
+						"blocked": blockedID,
+					})
+				}
 			}
+			return nil
+		}
+
+		if !chainAtomic {
+			addChain()
+		} else if err := svc.Atomic(addChain); err != nil {
+			output.ErrorMsg("dependency chain rolled back: " + err.Error())
 		}
 
 		if len(errors) > 0 && len(dependencies) == 0 {
@@ -231,10 +300,14 @@ func init() {
 	depCmd.AddCommand(depListCmd)
 	depCmd.AddCommand(depTreeCmd)
 	depCmd.AddCommand(depChainCmd)
+	depCmd.AddCommand(depCheckCmd)
+
+	// Chain command flags
+	depChainCmd.Flags().BoolVar(&chainAtomic, "atomic", false, "Create every link or none")
 
 	// Tree command flags
 	depTreeCmd.Flags().StringVar(&treeDirection, "direction", "up", "Tree direction: 'up' (blockers), 'down' (blocks), or 'both'")
-	depTreeCmd.Flags().StringVar(&treeStatus, "status", "", "Filter by status (todo, in-progress, done)")
+	depTreeCmd.Flags().StringVar(&treeStatus, "status", "", "Filter by status (see 'pace task statuses')")
 	depTreeCmd.Flags().IntVarP(&treeMaxDepth, "max-depth", "d", 50, "Maximum tree depth to display")
 }
 
@@ -247,6 +320,7 @@ var (
 	treeBranchStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("243"))
 	treeLabelStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("245"))
 	treeReadyStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("42")).Bold(true)
+	treeSubtaskStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("141"))
 )
 
 // treeOptions holds configuration for tree printing
@@ -255,11 +329,84 @@ type treeOptions struct {
 	filterStatus *task.Status
 	maxDepth     int
 	taskMap      map[string]task.Task
+	workflow     task.Workflow
+	out          io.Writer
+}
+
+// depTreeNode is a task in a dependency tree, with the tasks below it
+type depTreeNode struct {
+	ID      string        `json:"id"`
+	Title   string        `json:"title,omitempty"`
+	Status  string        `json:"status,omitempty"`
+	Ready   bool          `json:"ready,omitempty"`
+	Missing bool          `json:"missing,omitempty"`
+	Cycle   bool          `json:"cycle,omitempty"`
+	Tasks   []depTreeNode `json:"tasks,omitempty"`
+}
+
+// depTreeResponse is the dependency tree of a task for the formats other
+// than pretty
+type depTreeResponse struct {
+	Task      depTreeNode   `json:"task"`
+	ParentID  string        `json:"parent_id,omitempty"`
+	BlockedBy []depTreeNode `json:"blocked_by,omitempty"`
+	Subtasks  []depTreeNode `json:"subtasks,omitempty"`
+	Blocks    []depTreeNode `json:"blocks,omitempty"`
+}
+
+// buildDepTree collects the same tasks as printDepTree
+func buildDepTree(root task.Task, opts treeOptions) depTreeResponse {
+	resp := depTreeResponse{
+		Task: depTreeNode{
+			ID:     root.ID(),
+			Title:  root.Title(),
+			Status: root.Status().String(),
+			Ready:  isTaskReady(root, opts),
+		},
+		ParentID: root.ParentID(),
+		Subtasks: buildTree(root.Children(), opts, make(map[string]bool), task.Task.Children, 0),
+	}
+	if opts.direction == "up" || opts.direction == "both" {
+		resp.BlockedBy = buildTree(root.BlockedBy(), opts, make(map[string]bool), task.Task.BlockedBy, 0)
+	}
+	if opts.direction == "down" || opts.direction == "both" {
+		resp.Blocks = buildTree(root.Blocks(), opts, make(map[string]bool), task.Task.Blocks, 0)
+	}
+	return resp
+}
+
+// buildTree returns the tree nodes for taskIDs, following next from each
+// task to the tasks below it, as printTree prints them
+func buildTree(taskIDs []string, opts treeOptions, visited map[string]bool, next func(task.Task) []string, depth int) []depTreeNode {
+	if depth >= opts.maxDepth {
+		return nil
+	}
+	var nodes []depTreeNode
+	for _, id := range filterTreeIDs(taskIDs, opts) {
+		t, exists := opts.taskMap[id]
+		switch {
+		case !exists:
+			nodes = append(nodes, depTreeNode{ID: id, Missing: true})
+		case visited[id]:
+			nodes = append(nodes, depTreeNode{ID: id, Title: t.Title(), Cycle: true})
+		default:
+			visited[id] = true
+			nodes = append(nodes, depTreeNode{
+				ID:     id,
+				Title:  t.Title(),
+				Status: t.Status().String(),
+				Ready:  isTaskReady(t, opts),
+				Tasks:  buildTree(next(t), opts, visited, next, depth+1),
+			})
+			delete(visited, id)
+		}
+	}
+	return nodes
 }
 
 // printDepTree prints an ASCII tree visualization of task dependencies
 func printDepTree(root task.Task, opts treeOptions) {
-	fmt.Println()
+	fmt.Fprintln(opts.out)
 
 	showBlockers := opts.direction == "up" || opts.direction == "both"
 	showBlocks := opts.direction == "down" || opts.direction == "both"
@@ -267,46 +414,66 @@ func printDepTree(root task.Task, opts treeOptions) {
 	// Print blockers section (what blocks this task)
 	blockers := root.BlockedBy()
 	if showBlockers && len(blockers) > 0 {
-		fmt.Println(treeBlockerStyle.Render("BLOCKED BY:"))
-		printTree(blockers, opts, "", make(map[string]bool), true, 0)
-		fmt.Println()
+		fmt.Fprintln(opts.out, treeBlockerStyle.Render("BLOCKED BY:"))
+		printTree(blockers, opts, "", make(map[string]bool), task.Task.BlockedBy, 0)
+		fmt.Fprintln(opts.out)
+	}
+
+	// Print the parent task, if any
+	if parentID := root.ParentID(); parentID != "" {
+		parentLine := parentID
+		if parent, exists := opts.taskMap[parentID]; exists {
+			parentLine = fmt.Sprintf("%s: %s", parentID, truncateTitle(parent.Title(), 50))
+		}
+		fmt.Fprintln(opts.out, treeLabelStyle.Render("PARENT: ")+treeNodeStyle.Render(parentLine))
 	}
 
 	// Print the root task
-	fmt.Print(treeRootStyle.Render(fmt.Sprintf("► %s: %s", root.ID(), root.Title())))
+	fmt.Fprint(opts.out, treeRootStyle.Render(fmt.Sprintf("► %s: %s", root.ID(), root.Title())))
 	// Show [READY] indicator if task has no unresolved blockers
-	if isTaskReady(root, opts.taskMap) {
-		fmt.Print(" " + treeReadyStyle.Render("[READY]"))
+	if isTaskReady(root, opts) {
+		fmt.Fprint(opts.out, " "+treeReadyStyle.Render("[READY]"))
+	}
+	fmt.Fprintln(opts.out)
+	printTaskStatus(opts.out, root, opts.workflow)
+	fmt.Fprintln(opts.out)
+
+	// Print subtasks section, nested by hierarchy
+	if children := root.Children(); len(children) > 0 {
+		header := "SUBTASKS:"
+		if p := root.Progress(); p != nil && p.Total > 0 {
+			header = fmt.Sprintf("SUBTASKS (%d/%d done, %d%%):", p.Done, p.Total, p.Percent)
+		}
+		fmt.Fprintln(opts.out, treeSubtaskStyle.Render(header))
+		printTree(children, opts, "", make(map[string]bool), task.Task.Children, 0)
+		fmt.Fprintln(opts.out)
 	}
-	fmt.Println()
-	printTaskStatus(root)
-	fmt.Println()
 
 	// Print blocks section (what this task blocks)
 	blocks := root.Blocks()
 	if showBlocks && len(blocks) > 0 {
-		fmt.Println(treeBlocksStyle.Render("BLOCKS:"))
-		printTree(blocks, opts, "", make(map[string]bool), false, 0)
-		fmt.Println()
+		fmt.Fprintln(opts.out, treeBlocksStyle.Render("BLOCKS:"))
+		printTree(blocks, opts, "", make(map[string]bool), task.Task.Blocks, 0)
+		fmt.Fprintln(opts.out)
 	}
 
 	// If no dependencies in the requested direction, print a message
 	hasBlockers := showBlockers && len(blockers) > 0
 	hasBlocks := showBlocks && len(blocks) > 0
 	if !hasBlockers && !hasBlocks {
-		fmt.Println(treeLabelStyle.Render("No dependencies found."))
-		fmt.Println()
+		fmt.Fprintln(opts.out, treeLabelStyle.Render("No dependencies found."))
+		fmt.Fprintln(opts.out)
 	}
 }
 
 // isTaskReady checks if a task has no unresolved blockers
-func isTaskReady(t task.Task, taskMap map[string]task.Task) bool {
-	if t.Status() == task.Done {
+func isTaskReady(t task.Task, opts treeOptions) bool {
+	if opts.workflow.IsTerminal(t.Status()) {
 		return false // Done tasks aren't "ready"
 	}
 	for _, blockerID := range t.BlockedBy() {
-		if blocker, exists := taskMap[blockerID]; exists {
-			if blocker.Status() != task.Done {
+		if blocker, exists := opts.taskMap[blockerID]; exists {
+			if !opts.workflow.IsTerminal(blocker.Status()) {
 				return false
 			}
 		}
@@ -315,17 +482,8 @@ func isTaskReady(t task.Task, taskMap map[string]task.Task) bool {
 }
 
 // printTaskStatus prints the status of a task in a compact format
-func printTaskStatus(t task.Task) {
-	var statusStr string
-	switch t.Status() {
-	case task.Todo:
-		statusStr = todoStyle.Render("○ todo")
-	case task.InProgress:
-		statusStr = progressStyle.Render("● in-progress")
-	case task.Done:
-		statusStr = doneStyle.Render("● done")
-	}
-	fmt.Printf("  %s", statusStr)
+func printTaskStatus(out io.Writer, t task.Task, w task.Workflow) {
+	fmt.Fprintf(out, "  %s %s", statusSymbol(w, t.Status()), t.Status())
 
 	if p := t.Priority(); p > 0 {
 		var pStyle lipgloss.Style
@@ -339,14 +497,24 @@ func printTaskStatus(t task.Task) {
 		case 4:
 			pStyle = p4Style
 		}
-		fmt.Printf(" %s", pStyle.Render(fmt.Sprintf("P%d", p)))
+		fmt.Fprintf(out, " %s", pStyle.Render(fmt.Sprintf("P%d", p)))
 	}
-	fmt.Println()
+	fmt.Fprintln(out)
 }
 
-// printTree recursively prints tasks in a tree structure
-func printTree(taskIDs []string, opts treeOptions, prefix string, visited map[string]bool, isBlocker bool, depth int) {
-	// Filter task IDs based on status if filter is set
+// printTree recursively prints tasks in a tree structure, following next
+// from each task to the tasks below it
+func printTree(taskIDs []string, opts treeOptions, prefix string, visited map[string]bool, next func(task.Task) []string, depth int) {
+	filteredIDs := filterTreeIDs(taskIDs, opts)
+	for i, id := range filteredIDs {
+		isLast := i == len(filteredIDs)-1
+		printTreeNode(id, opts, prefix, isLast, visited, next, depth)
+	}
+}
+
+// filterTreeIDs keeps the tasks matching the status filter, if one is set,
+// and tasks that no longer exist
+func filterTreeIDs(taskIDs []string, opts treeOptions) []string {
 	var filteredIDs []string
 	for _, id := range taskIDs {
 		if t, exists := opts.taskMap[id]; exists {
@@ -358,15 +526,11 @@ func printTree(taskIDs []string, opts treeOptions, prefix string, visited map[st
 			filteredIDs = append(filteredIDs, id)
 		}
 	}
-
-	for i, id := range filteredIDs {
-		isLast := i == len(filteredIDs)-1
-		printTreeNode(id, opts, prefix, isLast, visited, isBlocker, depth)
-	}
+	return filteredIDs
 }
 
 // printTreeNode prints a single node in the tree and recursively prints children
-func printTreeNode(id string, opts treeOptions, prefix string, isLast bool, visited map[string]bool, isBlocker bool, depth int) {
+func printTreeNode(id string, opts treeOptions, prefix string, isLast bool, visited map[string]bool, next func(task.Task) []string, depth int) {
 	// Check max depth before printing (depth is 0-indexed, maxDepth=1 means show 1 level)
 	if depth >= opts.maxDepth {
 		return
@@ -385,7 +549,7 @@ func printTreeNode(id string, opts treeOptions, prefix string, isLast bool, visi
 	t, exists := opts.taskMap[id]
 	if !exists {
 		// Task doesn't exist (might have been deleted)
-		fmt.Printf("%s%s%s\n",
+		fmt.Fprintf(opts.out, "%s%s%s\n",
 			treeBranchStyle.Render(prefix+branch),
 			treeLabelStyle.Render(id),
 			treeLabelStyle.Render(" (not found)"))
@@ -394,7 +558,7 @@ func printTreeNode(id string, opts treeOptions, prefix string, isLast bool, visi
 
 	// Check for cycles
 	if visited[id] {
-		fmt.Printf("%s%s%s\n",
+		fmt.Fprintf(opts.out, "%s%s%s\n",
 			treeBranchStyle.Render(prefix+branch),
 			treeNodeStyle.Render(fmt.Sprintf("%s: %s", id, t.Title())),
 			treeLabelStyle.Render(" (cycle)"))
@@ -402,26 +566,18 @@ func printTreeNode(id string, opts treeOptions, prefix string, isLast bool, visi
 	}
 
 	// Format status indicator
-	var statusIndicator string
-	switch t.Status() {
-	case task.Todo:
-		statusIndicator = todoStyle.Render("○")
-	case task.InProgress:
-		statusIndicator = progressStyle.Render("●")
-	case task.Done:
-		statusIndicator = doneStyle.Render("●")
-	}
+	statusIndicator := statusSymbol(opts.workflow, t.Status())
 
 	// Build the node line
 	nodeLine := fmt.Sprintf("%s: %s", id, truncateTitle(t.Title(), 50))
 
 	// Add [READY] indicator for ready tasks
-	if isTaskReady(t, opts.taskMap) {
+	if isTaskReady(t, opts) {
 		nodeLine += " " + treeReadyStyle.Render("[READY]")
 	}
 
 	// Print the node
-	fmt.Printf("%s%s %s\n",
+	fmt.Fprintf(opts.out, "%s%s %s\n",
 		treeBranchStyle.Render(prefix+branch),
 		statusIndicator,
 		treeNodeStyle.Render(nodeLine))
@@ -430,15 +586,8 @@ func printTreeNode(id string, opts treeOptions, prefix string, isLast bool, visi
 	visited[id] = true
 
 	// Recursively print children
-	var children []string
-	if isBlocker {
-		children = t.BlockedBy() // Go up the blocker chain
-	} else {
-		children = t.Blocks() // Go down the blocks chain
-	}
-
-	if len(children) > 0 {
-		printTree(children, opts, childPrefix, visited, isBlocker, depth+1)
+	if children := next(t); len(children) > 0 {
+		printTree(children, opts, childPrefix, visited, next, depth+1)
 	}
 
 	// Unmark for other branches (allow same task to appear in different branches)
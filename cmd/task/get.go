@@ -12,20 +12,20 @@ var getCmd = &cobra.Command{
 	Long:  `Outputs a single task in JSON format.`,
 	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		taskID := args[0]
-
 		svc, err := task.NewService()
 		if err != nil {
 			output.Error(err)
 		}
 		defer svc.Close()
 
+		taskID := resolveID(svc, args[0])
+
 		t, err := svc.GetTaskByID(taskID)
 		if err != nil {
 			output.Error(err)
 		}
 
-		output.JSON(t.ToJSON())
+		output.Print(t.ToJSON())
 		return nil
 	},
 }
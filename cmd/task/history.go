@@ -0,0 +1,48 @@
+package task
+
+import (
+	"errors"
+
+	"github.com/lucas-tremaroli/pace/internal/output"
+	"github.com/lucas-tremaroli/pace/internal/task"
+	"github.com/spf13/cobra"
+)
+
+var historyCmd = &cobra.Command{
+	Use:   "history <id>",
+	Short: "Show the change history of a task",
+	Long: `Outputs every recorded change to a task in JSON format, oldest first.
+
+Each event records who made the change (from $PACE_ACTOR, or the OS username)
+and, for field updates, the old and new values. History is kept after a task
+is deleted.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		svc, err := task.NewService()
+		if err != nil {
+			output.Error(err)
+		}
+		defer svc.Close()
+
+		// Deleted tasks keep their history, so an ID that no longer
+		// resolves is looked up as given
+		taskID, err := svc.ResolveID(args[0])
+		if errors.Is(err, task.ErrTaskNotFound) {
+			taskID = args[0]
+		} else if err != nil {
+			output.Error(err)
+		}
+
+		events, err := svc.History(taskID)
+		if err != nil {
+			output.Error(err)
+		}
+
+		output.Print(map[string]any{
+			"task_id": taskID,
+			"events":  events,
+			"count":   len(events),
+		})
+		return nil
+	},
+}
@@ -0,0 +1,125 @@
+package task
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/lucas-tremaroli/pace/internal/output"
+	"github.com/lucas-tremaroli/pace/internal/task"
+	"github.com/spf13/cobra"
+)
+
+var (
+	linkKind  string
+	linkTitle string
+)
+
+var linkCmd = &cobra.Command{
+	Use:   "link",
+	Short: "Manage task links and code references",
+	Long: `Manage the links of a task: pull requests, issues, docs and designs, and
+references to code in the repository.
+
+A target with a scheme must be an http(s) URL. Anything else is a path
+relative to the project root, optionally with a line or line range:
+  internal/task/service.go
+  internal/task/service.go:120
+  internal/task/service.go:120-160`,
+}
+
+var linkAddCmd = &cobra.Command{
+	Use:   "add <task-id> <url-or-path>",
+	Short: "Add a link or code reference to a task",
+	Long: `Adds a link to a task. Without --kind, pull request and issue URLs are
+detected, other URLs are docs, and paths are code references.
+
+Examples:
+  pace task link add pace-a1b https://github.com/org/repo/pull/42
+  pace task link add pace-a1b https://figma.com/file/abc --kind design --title "Login mockups"
+  pace task link add pace-a1b internal/task/service.go:120-160 --title "Update path"`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		svc, err := task.NewService()
+		if err != nil {
+			output.Error(err)
+		}
+		defer svc.Close()
+		svc.BeginOperation("task link add")
+
+		taskID := resolveID(svc, args[0])
+		link, err := svc.AddLink(taskID, args[1], linkKind, linkTitle)
+		if err != nil {
+			output.Error(err)
+		}
+
+		output.Success("link added", link)
+		return nil
+	},
+}
+
+var linkListCmd = &cobra.Command{
+	Use:   "list <task-id>",
+	Short: "List the links of a task",
+	Long:  `Outputs the links of a task in JSON format, in the order they were added.`,
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		svc, err := task.NewService()
+		if err != nil {
+			output.Error(err)
+		}
+		defer svc.Close()
+
+		taskID := resolveID(svc, args[0])
+		links, err := svc.ListLinks(taskID)
+		if err != nil {
+			output.Error(err)
+		}
+
+		output.Print(map[string]any{
+			"task_id": taskID,
+			"links":   links,
+			"count":   len(links),
+		})
+		return nil
+	},
+}
+
+var linkRemoveCmd = &cobra.Command{
+	Use:   "remove <link-id>",
+	Short: "Remove a link from a task",
+	Long:  `Removes a link by the numeric ID shown in 'pace task link list'.`,
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		linkID, err := strconv.ParseInt(args[0], 10, 64)
+		if err != nil {
+			output.ErrorMsg("invalid link ID: " + args[0])
+		}
+
+		svc, err := task.NewService()
+		if err != nil {
+			output.Error(err)
+		}
+		defer svc.Close()
+		svc.BeginOperation("task link remove")
+
+		link, err := svc.RemoveLink(linkID)
+		if err != nil {
+			output.Error(err)
+		}
+
+		output.Success("link removed", map[string]any{
+			"id":      link.ID,
+			"task_id": link.TaskID,
+		})
+		return nil
+	},
+}
+
+func init() {
+	linkAddCmd.Flags().StringVar(&linkKind, "kind", "", "Link kind ("+strings.Join(task.LinkKinds, ", ")+")")
+	linkAddCmd.Flags().StringVar(&linkTitle, "title", "", "Short description of the link")
+
+	linkCmd.AddCommand(linkAddCmd)
+	linkCmd.AddCommand(linkListCmd)
+	linkCmd.AddCommand(linkRemoveCmd)
+}
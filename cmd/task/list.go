@@ -2,8 +2,11 @@ package task
 
 import (
 	"fmt"
+	"io"
+	"reflect"
 	"slices"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/lucas-tremaroli/pace/internal/output"
@@ -22,6 +25,11 @@ var (
 	progressStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("226"))
 	doneStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("42"))
 	blockedStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("196"))
+	dueStyle      = lipgloss.NewStyle().Foreground(lipgloss.Color("243"))
+	dueSoonStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("214"))
+	overdueStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Bold(true)
+	humanStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("176"))
+	agentStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("81"))
 	countStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("243"))
 
 	// Priority styles
@@ -31,89 +39,258 @@ var (
 	p4Style = lipgloss.NewStyle().Foreground(lipgloss.Color("245"))
 )
 
-var listPretty bool
+var (
+	listListing listingFlags
+	listSince   string
+	listUntil   string
+
+	listAssignee string
+	listFilters  []string
+	listView     string
+
+	listIncludeArchived bool
+)
 
 type taskListResponse struct {
 	Tasks []task.TaskJSON `json:"tasks"`
 	Count int             `json:"count"`
 }
 
+// pagedListResponse is a page of a task listing, whose tasks may be
+// projected onto some fields
+type pagedListResponse struct {
+	Tasks any `json:"tasks"`
+	Count int `json:"count"`
+	listPage
+}
+
 var listCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List all tasks",
-	Long:  `Outputs all tasks. Use --pretty for human-readable format.`,
+	Long: `Outputs all tasks. Use --pretty (--format pretty) for human-readable format.
+
+Sort and filter by time:
+  pace task list --sort updated
+  pace task list --sort created --since 7d
+  pace task list --since 2026-01-01 --until 2026-02-01
+  pace task list --sort due
+
+Sort by several fields (- reverses one), page through the results and
+output only some fields, to keep the output small:
+  pace task list --sort priority,-due,title
+  pace task list --limit 20 --offset 20 --fields id,title,status
+
+Filter by assignee ("me" for yourself, "" for unassigned tasks):
+  pace task list --assignee agent:claude
+
+Filter by any task attribute or custom field:
+  pace task list --filter status=todo --filter field.component=auth
+
+Combine conditions with AND, OR, NOT, parentheses, ranges and in (...):
+  pace task list --filter 'priority<=2 AND (label=auth OR label=api) AND NOT status=done'
+  pace task list --filter 'type in (bug, chore) due<=friday'
+
+Use a saved view (see 'pace view'); --filter narrows it and --sort overrides it:
+  pace task list --view urgent-auth --filter assignee=me`,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		fields := listListing.parse(reflect.TypeFor[task.TaskJSON]())
+		queryFilter, err := task.ParseFilters(listFilters)
+		if err != nil {
+			output.Error(err)
+		}
+
+		filter := &task.TaskFilter{}
+		if listSince != "" {
+			since, err := task.ParseTimeBound(listSince)
+			if err != nil {
+				output.Error(err)
+			}
+			filter.Since = &since
+		}
+		if listUntil != "" {
+			until, err := task.ParseTimeUntil(listUntil)
+			if err != nil {
+				output.Error(err)
+			}
+			filter.Until = &until
+		}
+		if cmd.Flags().Changed("assignee") {
+			assignee, err := task.ParseAssignee(listAssignee)
+			if err != nil {
+				output.Error(err)
+			}
+			filter.Assignee = &assignee
+		}
+		filter, err = task.MergeFilters([]*task.TaskFilter{queryFilter, filter})
+		if err != nil {
+			output.Error(err)
+		}
+
 		svc, err := task.NewService()
 		if err != nil {
 			output.Error(err)
 		}
 		defer svc.Close()
 
-		tasks, err := svc.LoadAllTasks()
+		if err := svc.ValidateFilter(filter); err != nil {
+			output.Error(err)
+		}
+
+		if listView != "" {
+			view, err := svc.GetView(listView)
+			if err != nil {
+				output.Error(err)
+			}
+			viewFilter, err := svc.ViewFilter(view)
+			if err != nil {
+				output.Error(err)
+			}
+			filter = task.AndFilters(viewFilter, filter)
+			if !cmd.Flags().Changed("sort") && view.Sort != "" {
+				listListing.sort = view.Sort
+			}
+		}
+
+		allTasks, err := svc.LoadTasks(listIncludeArchived || filter.SelectsArchived())
 		if err != nil {
 			output.Error(err)
 		}
 
-		// Sort by priority (P1 first, P4 last)
-		slices.SortFunc(tasks, func(a, b task.Task) int {
-			return a.Priority() - b.Priority()
-		})
+		var tasks []task.Task
+		for _, t := range allTasks {
+			if filter.Matches(t) {
+				tasks = append(tasks, t)
+			}
+		}
 
-		if listPretty {
-			printTasksPretty(tasks)
-			return nil
+		if err := task.SortTasks(tasks, listListing.sort); err != nil {
+			output.Error(err)
 		}
+		tasks, pos := page(&listListing, tasks)
 
 		taskJSONs := make([]task.TaskJSON, len(tasks))
 		for i, t := range tasks {
 			taskJSONs[i] = t.ToJSON()
 		}
 
-		output.JSON(taskListResponse{
-			Tasks: taskJSONs,
-			Count: len(taskJSONs),
-		})
+		var result any = pagedListResponse{
+			Tasks:    project(taskJSONs, fields),
+			Count:    len(taskJSONs),
+			listPage: pos,
+		}
+		if fields == nil {
+			result = output.WithPretty(result, func(out io.Writer) {
+				printTasksPretty(out, tasks, workflow(svc), taskTypes(svc))
+			})
+		}
+		output.Print(result)
 		return nil
 	},
 }
 
 func init() {
-	listCmd.Flags().BoolVar(&listPretty, "pretty", false, "Human-readable formatted output")
+	listCmd.Flags().Bool("pretty", false, "Shorthand for --format pretty")
+	listListing.register(listCmd, "priority")
+	listCmd.Flags().StringVar(&listSince, "since", "", "Only tasks updated at or after this time (YYYY-MM-DD, RFC3339, or 24h/7d/2w ago)")
+	listCmd.Flags().StringVar(&listUntil, "until", "", "Only tasks updated at or before this time (YYYY-MM-DD, RFC3339, or 24h/7d/2w ago)")
+	listCmd.Flags().StringVar(&listAssignee, "assignee", "", "Only tasks assigned to this person or agent (\"me\" for yourself)")
+	listCmd.Flags().StringArrayVar(&listFilters, "filter", nil, filterHelp(""))
+	listCmd.Flags().StringVar(&listView, "view", "", "List the tasks of a saved view (see 'pace view')")
+	listCmd.Flags().BoolVar(&listIncludeArchived, "include-archived", false, "Include archived tasks")
 }
 
-// printTasksPretty prints tasks in a human-readable format
-func printTasksPretty(tasks []task.Task) {
+// printTasksPretty prints tasks in a human-readable format, with subtasks
+// nested under their parent when both are listed
+func printTasksPretty(out io.Writer, tasks []task.Task, w task.Workflow, types task.TypeRegistry) {
 	if len(tasks) == 0 {
-		fmt.Println(countStyle.Render("No tasks found."))
+		fmt.Fprintln(out, countStyle.Render("No tasks found."))
 		return
 	}
 
+	listed := make(map[string]bool, len(tasks))
 	for _, t := range tasks {
-		fmt.Println(formatTaskPretty(t))
+		listed[t.ID()] = true
+	}
+	children := make(map[string][]task.Task)
+	var roots []task.Task
+	for _, t := range tasks {
+		if listed[t.ParentID()] {
+			children[t.ParentID()] = append(children[t.ParentID()], t)
+		} else {
+			roots = append(roots, t)
+		}
+	}
+
+	for _, t := range roots {
+		printTaskNested(out, t, children, w, types, 0)
+	}
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, countStyle.Render(fmt.Sprintf("%d task(s) \n", len(tasks))))
+	printLegend(out, w)
+}
+
+// printTaskLines prints tasks one per line followed by a summary, or the
+// empty message if there are none
+func printTaskLines(out io.Writer, tasks []task.Task, w task.Workflow, types task.TypeRegistry, empty, summary string) {
+	if len(tasks) == 0 {
+		fmt.Fprintln(out, countStyle.Render(empty))
+		return
+	}
+	for _, t := range tasks {
+		fmt.Fprintln(out, formatTaskPretty(t, w, types))
+	}
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, countStyle.Render(summary))
+}
+
+// printTaskNested prints a task followed by its subtasks, indented
+func printTaskNested(out io.Writer, t task.Task, children map[string][]task.Task, w task.Workflow, types task.TypeRegistry, depth int) {
+	indent := ""
+	if depth > 0 {
+		indent = strings.Repeat("  ", depth-1) + depStyle.Render("└ ")
+	}
+	fmt.Fprintln(out, indent+formatTaskPretty(t, w, types))
+	for _, child := range children[t.ID()] {
+		printTaskNested(out, child, children, w, types, depth+1)
+	}
+}
+
+// statusSymbol renders a status: ○ until work starts (up to and including
+// todo, or the first status), ● in progress, and a green ● once terminal
+func statusSymbol(w task.Workflow, s task.Status) string {
+	statuses := w.Statuses()
+	started := slices.Index(statuses, task.Todo)
+	if started < 0 {
+		started = 0
+	}
+	switch i := slices.Index(statuses, s); {
+	case w.IsTerminal(s):
+		return doneStyle.Render("●")
+	case i <= started:
+		return todoStyle.Render("○")
+	default:
+		return progressStyle.Render("●")
 	}
-	fmt.Println()
-	fmt.Println(countStyle.Render(fmt.Sprintf("%d task(s) \n", len(tasks))))
-	printLegend()
 }
 
-func printLegend() {
-	status := countStyle.Render("Status: ") +
-		todoStyle.Render("○") + countStyle.Render(" todo  ") +
-		progressStyle.Render("●") + countStyle.Render(" in-progress  ") +
-		doneStyle.Render("●") + countStyle.Render(" done  ") +
-		blockedStyle.Render("⊘") + countStyle.Render(" blocked")
-	fmt.Println(status)
+func printLegend(out io.Writer, w task.Workflow) {
+	status := countStyle.Render("Status: ")
+	for _, s := range w.Statuses() {
+		status += statusSymbol(w, s) + countStyle.Render(" "+s.String()+"  ")
+	}
+	status += blockedStyle.Render("⊘") + countStyle.Render(" blocked")
+	fmt.Fprintln(out, status)
 
 	priority := countStyle.Render("Priority: ") +
 		p1Style.Render("P1") + countStyle.Render(" urgent  ") +
 		p2Style.Render("P2") + countStyle.Render(" high  ") +
 		p3Style.Render("P3") + countStyle.Render(" normal  ") +
 		p4Style.Render("P4") + countStyle.Render(" low")
-	fmt.Println(priority)
+	fmt.Fprintln(out, priority)
 }
 
 // formatTaskPretty formats a single task for pretty printing
-func formatTaskPretty(t task.Task) string {
+func formatTaskPretty(t task.Task, w task.Workflow, types task.TypeRegistry) string {
 	var parts []string
 
 	// Check if blocked
@@ -123,21 +300,18 @@ func formatTaskPretty(t task.Task) string {
 	if isBlocked {
 		parts = append(parts, blockedStyle.Render("⊘"))
 	} else {
-		switch t.Status() {
-		case task.Todo:
-			parts = append(parts, todoStyle.Render("○"))
-		case task.InProgress:
-			parts = append(parts, progressStyle.Render("●"))
-		case task.Done:
-			parts = append(parts, doneStyle.Render("●"))
-		}
+		parts = append(parts, statusSymbol(w, t.Status()))
 	}
 
 	// ID
 	parts = append(parts, idStyle.Render(t.ID()))
 
 	// Type symbol
-	parts = append(parts, typeStyle.Render(fmt.Sprintf("[%s]", t.Type().Symbol())))
+	style := typeStyle
+	if color := types.Color(t.Type()); color != "" {
+		style = style.Foreground(lipgloss.Color(color))
+	}
+	parts = append(parts, style.Render(fmt.Sprintf("[%s]", types.Symbol(t.Type()))))
 
 	// Priority with color coding
 	if p := t.Priority(); p > 0 {
@@ -173,5 +347,38 @@ func formatTaskPretty(t task.Task) string {
 		parts = append(parts, depStyle.Render(fmt.Sprintf("(blocks:%d)", len(t.Blocks()))))
 	}
 
+	// Due date, highlighted when overdue or due soon unless already done
+	if !t.Due().IsZero() {
+		style := dueStyle
+		if !w.IsTerminal(t.Status()) {
+			switch t.DueState(time.Now()) {
+			case task.Overdue:
+				style = overdueStyle
+			case task.DueSoon:
+				style = dueSoonStyle
+			}
+		}
+		parts = append(parts, style.Render("due:"+task.FormatDate(t.Due())))
+	}
+
+	// Assignee initials, colored by whether a human or an agent owns it
+	if a := t.Assignee(); a != "" {
+		style := humanStyle
+		if task.IsAgent(a) {
+			style = agentStyle
+		}
+		parts = append(parts, style.Render("@"+task.Initials(a)))
+	}
+
+	// Estimate
+	if e := t.Estimate(); e > 0 {
+		parts = append(parts, depStyle.Render(fmt.Sprintf("~%g", e)))
+	}
+
+	// Subtask progress
+	if p := t.Progress(); p != nil && p.Total > 0 {
+		parts = append(parts, depStyle.Render(fmt.Sprintf("(%d/%d done)", p.Done, p.Total)))
+	}
+
 	return strings.Join(parts, " ")
 }
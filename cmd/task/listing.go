@@ -0,0 +1,91 @@
+package task
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+
+	"github.com/lucas-tremaroli/pace/internal/output"
+	"github.com/lucas-tremaroli/pace/internal/task"
+	"github.com/spf13/cobra"
+)
+
+// listingFlags are the sort, paging and projection flags shared by the
+// commands that list tasks
+type listingFlags struct {
+	sort   string
+	limit  int
+	offset int
+	fields string
+}
+
+// register adds the flags to cmd. An empty defaultSort keeps the order the
+// command produces, such as search relevance.
+func (f *listingFlags) register(cmd *cobra.Command, defaultSort string) {
+	sortHelp := "Sort by comma separated fields, - to reverse: " + strings.Join(task.SortFields, ", ")
+	cmd.Flags().StringVar(&f.sort, "sort", defaultSort, sortHelp)
+	cmd.Flags().IntVar(&f.limit, "limit", 0, "Maximum number of tasks to output (0 for all)")
+	cmd.Flags().IntVar(&f.offset, "offset", 0, "Skip this many tasks, for paging with --limit")
+	cmd.Flags().StringVar(&f.fields, "fields", "", "Only output these comma separated fields, e.g. id,title,status")
+}
+
+// parse checks the flags, exiting with an error if any is invalid, and
+// returns the fields to project onto, or nil for all of them. allowed is
+// the JSON type of one listed item.
+func (f *listingFlags) parse(allowed reflect.Type) []string {
+	if f.limit < 0 || f.offset < 0 {
+		output.Error(errors.New("--limit and --offset cannot be negative"))
+	}
+	if f.sort != "" {
+		if _, err := task.ParseSort(f.sort); err != nil {
+			output.Error(err)
+		}
+	}
+	if f.fields == "" {
+		return nil
+	}
+	fields, err := output.ParseFields(f.fields, output.FieldNames(allowed))
+	if err != nil {
+		output.Error(err)
+	}
+	return fields
+}
+
+// filterHelp returns the help of a --filter flag. selects says which tasks
+// the filter picks, e.g. " selecting the tasks to delete", or is empty.
+func filterHelp(selects string) string {
+	return "Filter query" + selects + " (repeatable, all must match): key=X, key!=X, key<X, key in (X, Y), combined with AND/OR/NOT and parentheses; keys: " + task.FilterKeys
+}
+
+// listPage is where a page of a listing sits in the full result
+type listPage struct {
+	Total      int `json:"total"`
+	NextOffset int `json:"next_offset,omitempty"` // Set when there are more items
+}
+
+// page returns the page of items the flags select and its position
+func page[T any](f *listingFlags, items []T) ([]T, listPage) {
+	paged := task.Page(items, f.offset, f.limit)
+	p := listPage{Total: len(items)}
+	if end := f.offset + len(paged); len(paged) > 0 && end < len(items) {
+		p.NextOffset = end
+	}
+	return paged, p
+}
+
+// project returns items as they should be output: unchanged, or as JSON
+// objects with only the given fields
+func project[T any](items []T, fields []string) any {
+	if fields == nil {
+		return items
+	}
+	objects := make([]output.Object, len(items))
+	for i, item := range items {
+		obj, err := output.Project(item, fields)
+		if err != nil {
+			output.Error(err)
+		}
+		objects[i] = obj
+	}
+	return objects
+}
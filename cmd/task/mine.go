@@ -0,0 +1,50 @@
+package task
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/lucas-tremaroli/pace/internal/output"
+	"github.com/lucas-tremaroli/pace/internal/task"
+	"github.com/spf13/cobra"
+)
+
+var mineCmd = &cobra.Command{
+	Use:   "mine",
+	Short: "Show unfinished tasks assigned to you",
+	Long: `Lists unarchived tasks assigned to you that are not in a terminal status,
+by priority. You are $PACE_ACTOR if set, otherwise your OS username; agents
+should set PACE_ACTOR=agent:<name>.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		svc, err := task.NewService()
+		if err != nil {
+			output.Error(err)
+		}
+		defer svc.Close()
+
+		actor := task.Actor()
+		tasks, err := svc.AssignedTo(actor)
+		if err != nil {
+			output.Error(err)
+		}
+
+		taskJSONs := make([]task.TaskJSON, len(tasks))
+		for i, t := range tasks {
+			taskJSONs[i] = t.ToJSON()
+		}
+
+		output.Print(output.WithPretty(map[string]any{
+			"assignee": actor,
+			"tasks":    taskJSONs,
+			"count":    len(taskJSONs),
+		}, func(out io.Writer) {
+			printTaskLines(out, tasks, workflow(svc), taskTypes(svc), "No tasks assigned to "+actor+".", fmt.Sprintf("%d task(s) assigned to %s", len(tasks), actor))
+		}))
+		return nil
+	},
+}
+
+func init() {
+	mineCmd.Flags().Bool("pretty", false, "Shorthand for --format pretty")
+}
@@ -0,0 +1,47 @@
+package task
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/lucas-tremaroli/pace/internal/output"
+	"github.com/lucas-tremaroli/pace/internal/task"
+	"github.com/spf13/cobra"
+)
+
+var overdueCmd = &cobra.Command{
+	Use:   "overdue",
+	Short: "Show tasks past their due date",
+	Long: `Lists unarchived tasks whose due date has passed and that are not in a
+terminal status, most overdue first. Use --pretty (--format pretty) for human-readable format.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		svc, err := task.NewService()
+		if err != nil {
+			output.Error(err)
+		}
+		defer svc.Close()
+
+		tasks, err := svc.GetOverdueTasks()
+		if err != nil {
+			output.Error(err)
+		}
+
+		taskJSONs := make([]task.TaskJSON, len(tasks))
+		for i, t := range tasks {
+			taskJSONs[i] = t.ToJSON()
+		}
+
+		output.Print(output.WithPretty(taskListResponse{
+			Tasks: taskJSONs,
+			Count: len(taskJSONs),
+		}, func(out io.Writer) {
+			printTaskLines(out, tasks, workflow(svc), taskTypes(svc), "No overdue tasks.", fmt.Sprintf("%d overdue task(s)", len(tasks)))
+		}))
+		return nil
+	},
+}
+
+func init() {
+	overdueCmd.Flags().Bool("pretty", false, "Shorthand for --format pretty")
+}
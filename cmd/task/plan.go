@@ -0,0 +1,72 @@
+package task
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/lucas-tremaroli/pace/internal/output"
+	"github.com/lucas-tremaroli/pace/internal/task"
+	"github.com/spf13/cobra"
+)
+
+var planCapacity float64
+
+var planCmd = &cobra.Command{
+	Use:   "plan",
+	Short: "Pick ready tasks that fit a capacity",
+	Long: `Fills a capacity with ready tasks, highest priority first, and explains why
+each other ready task was left out (no estimate, or too big for what is left).
+
+Estimates are in the store's estimate unit (points unless estimate_unit is set):
+  pace task plan --capacity 8
+  pace config set estimate_unit hours
+  pace task plan --capacity 4 --pretty`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if !cmd.Flags().Changed("capacity") {
+			output.ErrorMsg("--capacity is required")
+		}
+
+		svc, err := task.NewService()
+		if err != nil {
+			output.Error(err)
+		}
+		defer svc.Close()
+
+		plan, err := svc.Plan(planCapacity)
+		if err != nil {
+			output.Error(err)
+		}
+
+		output.Print(output.WithPretty(plan, func(out io.Writer) {
+			printPlanPretty(out, plan)
+		}))
+		return nil
+	},
+}
+
+// printPlanPretty prints the selected tasks followed by the skipped ones
+// and why they did not fit
+func printPlanPretty(out io.Writer, plan *task.Plan) {
+	if len(plan.Selected) == 0 {
+		fmt.Fprintln(out, countStyle.Render("No ready tasks fit the capacity."))
+	}
+	for _, item := range plan.Selected {
+		fmt.Fprintf(out, "%s %s %s %s\n", doneStyle.Render("✓"), idStyle.Render(item.ID), titleStyle.Render(item.Title), depStyle.Render(fmt.Sprintf("~%g", item.Estimate)))
+	}
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, countStyle.Render(fmt.Sprintf("%g of %g %s planned, %d task(s)", plan.Used, plan.Capacity, plan.Unit, len(plan.Selected))))
+
+	if len(plan.Skipped) > 0 {
+		fmt.Fprintln(out)
+		fmt.Fprintln(out, countStyle.Render("Skipped:"))
+		for _, item := range plan.Skipped {
+			fmt.Fprintf(out, "%s %s %s %s\n", todoStyle.Render("-"), idStyle.Render(item.ID), titleStyle.Render(item.Title), depStyle.Render("("+item.Reason+")"))
+		}
+	}
+}
+
+func init() {
+	planCmd.Flags().Float64Var(&planCapacity, "capacity", 0, "Capacity to fill, in the store's estimate unit (required)")
+	planCmd.Flags().Bool("pretty", false, "Shorthand for --format pretty")
+}
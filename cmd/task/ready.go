@@ -2,6 +2,8 @@ package task
 
 import (
 	"fmt"
+	"io"
+	"reflect"
 	"slices"
 
 	"github.com/lucas-tremaroli/pace/internal/output"
@@ -9,53 +11,87 @@ import (
 	"github.com/spf13/cobra"
 )
 
-var readyPretty bool
+var (
+	readyIncludeArchived bool
+	readyFilters         []string
+	readyListing         listingFlags
+)
 
 var readyCmd = &cobra.Command{
 	Use:   "ready",
 	Short: "Show tasks ready to work on",
-	Long:  `Lists tasks that have no blockers (or all blockers are done). Use --pretty for human-readable format.`,
+	Long: `Lists tasks that have no blockers (or all blockers are done). Use --pretty (--format pretty) for human-readable format.
+
+Narrow the ready tasks with a filter query:
+  pace task ready --filter 'priority<=2 AND label in (auth, api)'
+
+Take the next few in a given order, with only the fields you need:
+  pace task ready --sort priority,due --limit 3 --fields id,title,priority
+
+The tasks print as a JSON array. With --limit or --offset they print as an
+object with the page of tasks, the total and the next offset instead.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		fields := readyListing.parse(reflect.TypeFor[task.TaskJSON]())
+		filter, err := task.ParseFilters(readyFilters)
+		if err != nil {
+			output.Error(err)
+		}
+
 		svc, err := task.NewService()
 		if err != nil {
 			output.Error(err)
 		}
 		defer svc.Close()
 
-		tasks, err := svc.GetReadyTasks()
-		if err != nil {
+		if err := svc.ValidateFilter(filter); err != nil {
 			output.Error(err)
 		}
 
-		// Sort by priority (P1 first, P4 last)
-		slices.SortFunc(tasks, func(a, b task.Task) int {
-			return a.Priority() - b.Priority()
+		tasks, err := svc.GetReadyTasks(readyIncludeArchived || filter.SelectsArchived())
+		if err != nil {
+			output.Error(err)
+		}
+		tasks = slices.DeleteFunc(tasks, func(t task.Task) bool {
+			return !filter.Matches(t)
 		})
 
-		if readyPretty {
-			if len(tasks) == 0 {
-				fmt.Println(countStyle.Render("No ready tasks."))
-				return nil
-			}
-			for _, t := range tasks {
-				fmt.Println(formatTaskPretty(t))
-			}
-			fmt.Println()
-			fmt.Println(countStyle.Render(fmt.Sprintf("%d ready task(s)", len(tasks))))
-			printLegend()
-			return nil
+		if err := task.SortTasks(tasks, readyListing.sort); err != nil {
+			output.Error(err)
 		}
+		tasks, pos := page(&readyListing, tasks)
 
 		var tasksJSON []task.TaskJSON
 		for _, t := range tasks {
 			tasksJSON = append(tasksJSON, t.ToJSON())
 		}
 
-		output.JSON(tasksJSON)
+		// ready prints a bare array, which agents parse; only paging
+		// through it reports where the page sits
+		result := project(tasksJSON, fields)
+		if cmd.Flags().Changed("limit") || cmd.Flags().Changed("offset") {
+			result = pagedListResponse{
+				Tasks:    result,
+				Count:    len(tasksJSON),
+				listPage: pos,
+			}
+		}
+		if fields == nil {
+			result = output.WithPretty(result, func(out io.Writer) {
+				w := workflow(svc)
+				printTaskLines(out, tasks, w, taskTypes(svc), "No ready tasks.", fmt.Sprintf("%d ready task(s)", len(tasks)))
+				if len(tasks) > 0 {
+					printLegend(out, w)
+				}
+			})
+		}
+		output.Print(result)
 		return nil
 	},
 }
 
 func init() {
-	readyCmd.Flags().BoolVar(&readyPretty, "pretty", false, "Human-readable formatted output")
+	readyCmd.Flags().Bool("pretty", false, "Shorthand for --format pretty")
+	readyCmd.Flags().BoolVar(&readyIncludeArchived, "include-archived", false, "Include archived tasks")
+	readyListing.register(readyCmd, "priority")
+	readyCmd.Flags().StringArrayVar(&readyFilters, "filter", nil, filterHelp(""))
 }
@@ -0,0 +1,136 @@
+package task
+
+import (
+	"strings"
+
+	"github.com/lucas-tremaroli/pace/internal/output"
+	"github.com/lucas-tremaroli/pace/internal/task"
+	"github.com/spf13/cobra"
+)
+
+var relClose bool
+
+var relCmd = &cobra.Command{
+	Use:   "rel",
+	Short: "Manage non-blocking task relations",
+	Long: `Manage relations between tasks that, unlike dependencies, never block them:
+
+  relates-to     the tasks are related
+  duplicate-of   the task repeats another (duplicated-by from the other side)
+  supersedes     the task replaces another (superseded-by from the other side)
+  caused-by      the task was caused by another (causes from the other side)
+
+Relations read left to right, and the inverse names can be used too:
+  pace task rel add pace-b2c duplicate-of pace-a1b
+  pace task rel add pace-a1b duplicated-by pace-b2c   # same relation`,
+}
+
+var relAddCmd = &cobra.Command{
+	Use:   "add <task-id> <kind> <other-id>",
+	Short: "Relate two tasks",
+	Long: `Records a relation between two tasks.
+
+With duplicate-of, --close also moves the duplicate's labels onto the
+original and moves the duplicate to the first done status:
+  pace task rel add pace-b2c duplicate-of pace-a1b --close`,
+	Args: cobra.ExactArgs(3),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		kind, swapped, err := task.ParseRelationKind(args[1])
+		if err != nil {
+			output.Error(err)
+		}
+		if relClose && (kind != task.RelDuplicateOf || swapped) {
+			output.ErrorMsg("--close can only be used with duplicate-of")
+		}
+
+		svc, err := task.NewService()
+		if err != nil {
+			output.Error(err)
+		}
+		defer svc.Close()
+		svc.BeginOperation("task rel add")
+
+		taskID := resolveID(svc, args[0])
+		otherID := resolveID(svc, args[2])
+
+		if relClose {
+			err = svc.MarkDuplicate(taskID, otherID)
+		} else {
+			err = svc.AddRelation(taskID, args[1], otherID)
+		}
+		if err != nil {
+			output.Error(err)
+		}
+
+		output.Success("relation added", map[string]any{
+			"task_id":  taskID,
+			"kind":     strings.ToLower(args[1]),
+			"other_id": otherID,
+			"closed":   relClose,
+		})
+		return nil
+	},
+}
+
+var relRemoveCmd = &cobra.Command{
+	Use:   "remove <task-id> <kind> <other-id>",
+	Short: "Remove a relation between two tasks",
+	Args:  cobra.ExactArgs(3),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		svc, err := task.NewService()
+		if err != nil {
+			output.Error(err)
+		}
+		defer svc.Close()
+		svc.BeginOperation("task rel remove")
+
+		taskID := resolveID(svc, args[0])
+		otherID := resolveID(svc, args[2])
+
+		if err := svc.RemoveRelation(taskID, args[1], otherID); err != nil {
+			output.Error(err)
+		}
+
+		output.Success("relation removed", map[string]any{
+			"task_id":  taskID,
+			"kind":     strings.ToLower(args[1]),
+			"other_id": otherID,
+		})
+		return nil
+	},
+}
+
+var relListCmd = &cobra.Command{
+	Use:   "list <task-id>",
+	Short: "List the relations of a task",
+	Long:  `Outputs the relations of a task in JSON format, as seen from the task.`,
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		svc, err := task.NewService()
+		if err != nil {
+			output.Error(err)
+		}
+		defer svc.Close()
+
+		taskID := resolveID(svc, args[0])
+		relations, err := svc.ListRelations(taskID)
+		if err != nil {
+			output.Error(err)
+		}
+
+		output.Print(map[string]any{
+			"task_id":   taskID,
+			"relations": relations,
+			"count":     len(relations),
+		})
+		return nil
+	},
+}
+
+func init() {
+	relAddCmd.Flags().BoolVar(&relClose, "close", false, "With duplicate-of, move labels to the original and close the duplicate")
+
+	relCmd.AddCommand(relAddCmd)
+	relCmd.AddCommand(relRemoveCmd)
+	relCmd.AddCommand(relListCmd)
+}
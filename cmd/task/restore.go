@@ -0,0 +1,44 @@
+package task
+
+import (
+	"github.com/lucas-tremaroli/pace/internal/output"
+	"github.com/lucas-tremaroli/pace/internal/task"
+	"github.com/spf13/cobra"
+)
+
+var restoreCmd = &cobra.Command{
+	Use:   "restore <id> [id2] ...",
+	Short: "Restore archived tasks",
+	Long:  `Makes archived tasks visible again, with their labels and dependencies intact.`,
+	Args:  cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		svc, err := task.NewService()
+		if err != nil {
+			output.Error(err)
+		}
+		defer svc.Close()
+		svc.BeginOperation("task restore")
+
+		if len(args) == 1 {
+			taskID := resolveID(svc, args[0])
+			if err := svc.RestoreTask(taskID); err != nil {
+				output.Error(err)
+			}
+			output.Success("task restored", map[string]string{
+				"id": taskID,
+			})
+			return nil
+		}
+
+		result := runBatch(svc, false, len(args), func(i int) (output.BulkItem, error) {
+			taskID, err := svc.ResolveID(args[i])
+			if err != nil {
+				return output.BulkItem{ID: args[i]}, err
+			}
+			return output.BulkItem{ID: taskID}, svc.RestoreTask(taskID)
+		})
+
+		output.BulkSuccess("tasks restored", result)
+		return nil
+	},
+}
@@ -1,20 +1,51 @@
 package task
 
 import (
-	"strings"
+	"fmt"
+	"io"
+	"reflect"
+	"slices"
 
 	"github.com/lucas-tremaroli/pace/internal/output"
 	"github.com/lucas-tremaroli/pace/internal/task"
 	"github.com/spf13/cobra"
 )
 
+var (
+	searchIncludeArchived bool
+	searchListing         listingFlags
+)
+
+type searchResponse struct {
+	Query string `json:"query"`
+	pagedListResponse
+}
+
 var searchCmd = &cobra.Command{
 	Use:   "search <query>",
 	Short: "Search tasks by text query",
-	Long:  `Full-text search across task titles and descriptions.`,
-	Args:  cobra.ExactArgs(1),
+	Long: `Full-text search across task titles, descriptions, labels and comments.
+
+Results are ranked best match first, each with a snippet of the matching
+text where matched terms are wrapped in ** and a relevance score.
+
+Query syntax:
+  word            Tasks containing the word (all words must match)
+  "exact phrase"  Tasks containing the words in this order
+  prefix*         Words starting with prefix
+  -word           Exclude tasks containing the word (also -"phrase")
+
+Case and accents are ignored.
+
+Use --sort to order the results by task fields instead of relevance.
+
+Examples:
+  pace task search "login timeout"
+  pace task search 'auth* -"single sign-on"'
+  pace task search flaky --limit 5 --fields id,title,snippet`,
+	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		query := strings.ToLower(args[0])
+		fields := searchListing.parse(reflect.TypeFor[task.SearchResultJSON]())
 
 		svc, err := task.NewService()
 		if err != nil {
@@ -22,26 +53,61 @@ var searchCmd = &cobra.Command{
 		}
 		defer svc.Close()
 
-		allTasks, err := svc.LoadAllTasks()
+		results, err := svc.Search(args[0], searchIncludeArchived)
 		if err != nil {
 			output.Error(err)
 		}
-
-		var matches []task.TaskJSON
-		for _, t := range allTasks {
-			titleMatch := strings.Contains(strings.ToLower(t.Title()), query)
-			descMatch := strings.Contains(strings.ToLower(t.Description()), query)
-			if titleMatch || descMatch {
-				matches = append(matches, t.ToJSON())
+		if searchListing.sort != "" {
+			compare, err := task.ParseSort(searchListing.sort)
+			if err != nil {
+				output.Error(err)
 			}
+			slices.SortStableFunc(results, func(a, b task.SearchResult) int {
+				return compare(a.Task, b.Task)
+			})
+		}
+		results, pos := page(&searchListing, results)
+
+		matches := make([]task.SearchResultJSON, 0, len(results))
+		for _, r := range results {
+			matches = append(matches, r.ToJSON())
 		}
 
-		output.JSON(map[string]any{
-			"query":   args[0],
-			"tasks":   matches,
-			"count":   len(matches),
-		})
+		var result any = searchResponse{
+			Query: args[0],
+			pagedListResponse: pagedListResponse{
+				Tasks:    project(matches, fields),
+				Count:    len(matches),
+				listPage: pos,
+			},
+		}
+		if fields == nil {
+			result = output.WithPretty(result, func(out io.Writer) {
+				printSearchPretty(out, results, workflow(svc), taskTypes(svc))
+			})
+		}
+		output.Print(result)
 		return nil
 	},
 }
 
+// printSearchPretty prints each result with its snippet below it
+func printSearchPretty(out io.Writer, results []task.SearchResult, w task.Workflow, types task.TypeRegistry) {
+	if len(results) == 0 {
+		fmt.Fprintln(out, countStyle.Render("No matching tasks."))
+		return
+	}
+	for _, r := range results {
+		fmt.Fprintln(out, formatTaskPretty(r.Task, w, types))
+		if r.Snippet != "" {
+			fmt.Fprintln(out, "  "+depStyle.Render(r.Snippet))
+		}
+	}
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, countStyle.Render(fmt.Sprintf("%d matching task(s)", len(results))))
+}
+
+func init() {
+	searchCmd.Flags().BoolVar(&searchIncludeArchived, "include-archived", false, "Include archived tasks")
+	searchListing.register(searchCmd, "")
+}
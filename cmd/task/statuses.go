@@ -0,0 +1,35 @@
+package task
+
+import (
+	"github.com/lucas-tremaroli/pace/internal/output"
+	"github.com/lucas-tremaroli/pace/internal/task"
+	"github.com/spf13/cobra"
+)
+
+var statusesCmd = &cobra.Command{
+	Use:   "statuses",
+	Short: "List the workflow statuses",
+	Long: `Outputs the statuses tasks move through, in board order, and whether
+each is terminal (counts as done).
+
+Statuses are configured per store:
+  pace config set statuses backlog,todo,in-progress,review,done
+  pace config set terminal_statuses done
+
+Without terminal_statuses, the last status is terminal.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		svc, err := task.NewService()
+		if err != nil {
+			output.Error(err)
+		}
+		defer svc.Close()
+
+		w := workflow(svc)
+		output.Print(map[string]any{
+			"statuses": w,
+			"count":    len(w),
+		})
+		return nil
+	},
+}
@@ -8,13 +8,21 @@ import (
 	"github.com/spf13/cobra"
 )
 
+var (
+	tuiIncludeArchived bool
+	tuiView            string
+)
+
 var tuiCmd = &cobra.Command{
 	Use:     "tui",
 	GroupID: "interactive",
 	Short:   "Launch the Kanban board TUI",
-	Long:    `Launch an interactive TUI to manage your tasks in a Kanban-style board.`,
+	Long: `Launch an interactive TUI to manage your tasks in a Kanban-style board.
+
+Use --view to show only the tasks of a saved view (see 'pace view'):
+  pace task tui --view urgent-auth`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		board, err := task.NewBoard()
+		board, err := task.NewBoard(tuiIncludeArchived, tuiView)
 		if err != nil {
 			return fmt.Errorf("failed to initialize task board: %w", err)
 		}
@@ -25,3 +33,8 @@ var tuiCmd = &cobra.Command{
 		return nil
 	},
 }
+
+func init() {
+	tuiCmd.Flags().BoolVar(&tuiIncludeArchived, "include-archived", false, "Show archived tasks on the board")
+	tuiCmd.Flags().StringVar(&tuiView, "view", "", "Show only the tasks of a saved view")
+}
@@ -0,0 +1,106 @@
+package task
+
+import (
+	"github.com/lucas-tremaroli/pace/internal/output"
+	"github.com/lucas-tremaroli/pace/internal/task"
+	"github.com/spf13/cobra"
+)
+
+var (
+	typeSymbol string
+	typeColor  string
+)
+
+var typeCmd = &cobra.Command{
+	Use:   "type",
+	Short: "Manage task types",
+	Long: `Manage the task types of the current store.
+
+The built-in types task, bug, feature, chore and docs are always available.
+Custom types are added per store and can be used anywhere a type is
+accepted, e.g. 'pace task create --type spike'.`,
+}
+
+var typeListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the task types",
+	Long:  `Outputs the built-in and custom task types in JSON format.`,
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		svc, err := task.NewService()
+		if err != nil {
+			output.Error(err)
+		}
+		defer svc.Close()
+
+		types := taskTypes(svc)
+		output.Print(map[string]any{
+			"types": types,
+			"count": len(types),
+		})
+		return nil
+	},
+}
+
+var typeAddCmd = &cobra.Command{
+	Use:   "add <name>",
+	Short: "Add a custom task type",
+	Long: `Adds a custom task type. The symbol (1-3 characters) is shown in
+listings and the TUI; the color is an ANSI color number or a hex color.
+
+Examples:
+  pace task type add spike --symbol S
+  pace task type add incident --symbol !! --color "#ff5f5f"`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if typeSymbol == "" {
+			output.ErrorMsg("--symbol is required")
+		}
+
+		svc, err := task.NewService()
+		if err != nil {
+			output.Error(err)
+		}
+		defer svc.Close()
+
+		def, err := svc.AddType(args[0], typeSymbol, typeColor)
+		if err != nil {
+			output.Error(err)
+		}
+
+		output.Success("task type added", def)
+		return nil
+	},
+}
+
+var typeRemoveCmd = &cobra.Command{
+	Use:   "remove <name>",
+	Short: "Remove a custom task type",
+	Long:  `Removes a custom task type. Types still used by a task, including archived tasks, cannot be removed.`,
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		svc, err := task.NewService()
+		if err != nil {
+			output.Error(err)
+		}
+		defer svc.Close()
+
+		if err := svc.RemoveType(args[0]); err != nil {
+			output.Error(err)
+		}
+
+		output.Success("task type removed", map[string]any{
+			"name": args[0],
+		})
+		return nil
+	},
+}
+
+func init() {
+	typeCmd.AddCommand(typeListCmd)
+	typeCmd.AddCommand(typeAddCmd)
+	typeCmd.AddCommand(typeRemoveCmd)
+
+	typeAddCmd.Flags().StringVar(&typeSymbol, "symbol", "", "Short symbol shown in listings (required)")
+	typeAddCmd.Flags().StringVar(&typeColor, "color", "", "Symbol color: ANSI number (0-255) or hex (#rrggbb)")
+}
@@ -2,6 +2,7 @@ package task
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/lucas-tremaroli/pace/internal/output"
 	"github.com/lucas-tremaroli/pace/internal/task"
@@ -17,8 +18,14 @@ var (
 	updateAddLabels    []string
 	updateRemoveLabels []string
 	updateLink         string
+	updateParent       string
+	updateDue          string
+	updateEstimate     float64
+	updateAssignee     string
+	updateFields       []string
 	updateFilters      []string
 	updateDryRun       bool
+	updateAtomic       bool
 )
 
 var updateCmd = &cobra.Command{
@@ -29,7 +36,29 @@ var updateCmd = &cobra.Command{
 For batch updates, use --filter with update flags:
   pace task update --filter status=todo --priority 1
   pace task update --filter type=bug --priority 1 --status in-progress
-  pace task update --filter label=sprint-1 --status done --dry-run`,
+  pace task update --filter label=sprint-1 --status done --dry-run
+  pace task update --filter 'status!=done AND (label=auth OR label=api)' --priority 2
+
+Use --parent to move tasks under an epic, or --parent "" to make them top-level:
+  pace task update pace-c3d --parent pace-a1b
+  pace task update --filter label=auth --parent pace-a1b
+
+Use --due to set a due date, or --due "" to clear it:
+  pace task update pace-c3d --due +3d
+  pace task update --filter label=sprint-1 --due 2026-11-01
+
+Use --estimate to size a task, or --estimate 0 to clear it:
+  pace task update pace-c3d --estimate 5
+
+Use --assignee to assign tasks ("me" for yourself), or --assignee "" to unassign:
+  pace task update pace-c3d --assignee me
+  pace task update --filter assignee=agent:claude --assignee ""
+
+Use --field to set custom fields, or --field key= to remove one:
+  pace task update pace-c3d --field component=auth --field customer=
+  pace task update --filter field.component=auth --field team=identity
+
+Use --atomic with --filter to update all matched tasks or none.`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		// Check for conflicting options
@@ -47,73 +76,126 @@ For batch updates, use --filter with update flags:
 			output.ErrorMsg("task ID required (or use --filter for batch updates)")
 		}
 
-		taskID := args[0]
-
 		svc, err := task.NewService()
 		if err != nil {
 			output.Error(err)
 		}
 		defer svc.Close()
+		svc.BeginOperation("task update")
 
-		// Get existing task
-		existingTask, err := svc.GetTaskByID(taskID)
-		if err != nil {
-			output.Error(err)
-		}
-
-		// Apply updates only for flags that were explicitly set
-		title := existingTask.Title()
-		description := existingTask.Description()
-		status := existingTask.Status()
-		taskType := existingTask.Type()
-		priority := existingTask.Priority()
-		link := existingTask.Link()
+		taskID := resolveID(svc, args[0])
 
-		if cmd.Flags().Changed("title") {
-			title = updateTitle
-		}
-		if cmd.Flags().Changed("description") {
-			description = updateDescription
-		}
+		// Parse flags before touching the store
+		var newStatus *task.Status
+		var newType *task.TaskType
 		if cmd.Flags().Changed("status") {
-			parsedStatus, err := task.ParseStatus(updateStatus)
+			parsedStatus, err := workflow(svc).Parse(updateStatus)
 			if err != nil {
 				output.Error(err)
 			}
-			status = parsedStatus
+			newStatus = &parsedStatus
 		}
 		if cmd.Flags().Changed("type") {
-			parsedType, err := task.ParseTaskType(updateType)
+			parsedType, err := taskTypes(svc).Parse(updateType)
 			if err != nil {
 				output.Error(err)
 			}
-			taskType = parsedType
-		}
-		if cmd.Flags().Changed("priority") {
-			priority = updatePriority
-		}
-		if cmd.Flags().Changed("url") {
-			link = updateLink
+			newType = &parsedType
 		}
+		newDue := parseDueFlag(cmd)
+		newAssignee := parseAssigneeFlag(cmd)
+		newFields := parseFieldFlags(updateFields)
+
+		// Read and write in one transaction so a concurrent update from
+		// another process is never overwritten with stale values
+		err = svc.Atomic(func() error {
+			existingTask, err := svc.GetTaskByID(taskID)
+			if err != nil {
+				return err
+			}
 
-		updatedTask := task.NewTaskComplete(taskID, status, taskType, title, description, priority, link)
+			// Apply updates only for flags that were explicitly set
+			title := existingTask.Title()
+			description := existingTask.Description()
+			status := existingTask.Status()
+			taskType := existingTask.Type()
+			priority := existingTask.Priority()
+			link := existingTask.Link()
 
-		if err := svc.UpdateTask(updatedTask); err != nil {
-			output.Error(err)
-		}
+			if cmd.Flags().Changed("title") {
+				title = updateTitle
+			}
+			if cmd.Flags().Changed("description") {
+				description = updateDescription
+			}
+			if newStatus != nil {
+				status = *newStatus
+			}
+			if newType != nil {
+				taskType = *newType
+			}
+			if cmd.Flags().Changed("priority") {
+				priority = updatePriority
+			}
+			if cmd.Flags().Changed("url") {
+				link = updateLink
+			}
 
-		// Add labels if specified
-		for _, label := range updateAddLabels {
-			if err := svc.AddLabel(taskID, label); err != nil {
-				output.Error(err)
+			updatedTask := task.NewTaskComplete(taskID, status, taskType, title, description, priority, link)
+
+			if err := svc.UpdateTask(updatedTask); err != nil {
+				return err
 			}
-		}
 
-		// Remove labels if specified
-		for _, label := range updateRemoveLabels {
-			if err := svc.RemoveLabel(taskID, label); err != nil {
-				output.Error(err)
+			if cmd.Flags().Changed("parent") {
+				parentID := ""
+				if updateParent != "" {
+					if parentID, err = svc.ResolveID(updateParent); err != nil {
+						return err
+					}
+				}
+				if err := svc.SetParent(taskID, parentID); err != nil {
+					return err
+				}
+			}
+			if newDue != nil {
+				if err := svc.SetDue(taskID, *newDue); err != nil {
+					return err
+				}
+			}
+			if cmd.Flags().Changed("estimate") {
+				if err := svc.SetEstimate(taskID, updateEstimate); err != nil {
+					return err
+				}
+			}
+			if newAssignee != nil {
+				if err := svc.SetAssignee(taskID, *newAssignee); err != nil {
+					return err
+				}
+			}
+			for _, f := range newFields {
+				if err := svc.SetField(taskID, f); err != nil {
+					return err
+				}
+			}
+
+			// Add labels if specified
+			for _, label := range updateAddLabels {
+				if err := svc.AddLabel(taskID, label); err != nil {
+					return err
+				}
+			}
+
+			// Remove labels if specified
+			for _, label := range updateRemoveLabels {
+				if err := svc.RemoveLabel(taskID, label); err != nil {
+					return err
+				}
 			}
+			return nil
+		})
+		if err != nil {
+			output.Error(err)
 		}
 
 		// Fetch updated task to include label changes in output
@@ -133,16 +215,7 @@ func handleBatchUpdate(cmd *cobra.Command) error {
 		output.ErrorMsg("--title, --description, and --url cannot be used with --filter (would set same value for all matched tasks)")
 	}
 
-	// Parse filters
-	var filters []*task.TaskFilter
-	for _, f := range updateFilters {
-		filter, err := task.ParseFilter(f)
-		if err != nil {
-			output.Error(err)
-		}
-		filters = append(filters, filter)
-	}
-	mergedFilter, err := task.MergeFilters(filters)
+	mergedFilter, err := task.ParseFilters(updateFilters)
 	if err != nil {
 		output.Error(err)
 	}
@@ -169,11 +242,19 @@ func handleBatchUpdate(cmd *cobra.Command) error {
 	if cmd.Flags().Changed("priority") {
 		batchPriority = &updatePriority
 	}
+	batchDue := parseDueFlag(cmd)
+	batchAssignee := parseAssigneeFlag(cmd)
+	batchFields := parseFieldFlags(updateFields)
 
 	// Validate we have something to update
-	if batchStatus == nil && batchType == nil && batchPriority == nil &&
-		len(updateAddLabels) == 0 && len(updateRemoveLabels) == 0 {
-		output.ErrorMsg("no updates specified (use --status, --type, --priority, --label, or --remove-label)")
+	if batchStatus == nil && batchType == nil && batchPriority == nil && batchDue == nil && batchAssignee == nil && len(batchFields) == 0 &&
+		!cmd.Flags().Changed("parent") && !cmd.Flags().Changed("estimate") && len(updateAddLabels) == 0 && len(updateRemoveLabels) == 0 {
+		output.ErrorMsg("no updates specified (use --status, --type, --priority, --parent, --due, --estimate, --assignee, --field, --label, or --remove-label)")
+	}
+	if cmd.Flags().Changed("estimate") {
+		if err := task.ValidateEstimate(updateEstimate); err != nil {
+			output.Error(err)
+		}
 	}
 
 	svc, err := task.NewService()
@@ -181,9 +262,33 @@ func handleBatchUpdate(cmd *cobra.Command) error {
 		output.Error(err)
 	}
 	defer svc.Close()
+	svc.BeginOperation("task update --filter")
 
-	// Load all tasks
-	tasks, err := svc.LoadAllTasks()
+	if err := svc.ValidateFilter(mergedFilter); err != nil {
+		output.Error(err)
+	}
+	if batchStatus != nil {
+		if err := workflow(svc).Check(*batchStatus); err != nil {
+			output.Error(err)
+		}
+	}
+	if batchType != nil {
+		if err := taskTypes(svc).Check(*batchType); err != nil {
+			output.Error(err)
+		}
+	}
+
+	var batchParent *string
+	if cmd.Flags().Changed("parent") {
+		parentID := ""
+		if updateParent != "" {
+			parentID = resolveID(svc, updateParent)
+		}
+		batchParent = &parentID
+	}
+
+	// Archived tasks are only matched by an explicit archived filter
+	tasks, err := svc.LoadTasks(mergedFilter.SelectsArchived())
 	if err != nil {
 		output.Error(err)
 	}
@@ -219,6 +324,26 @@ func handleBatchUpdate(cmd *cobra.Command) error {
 			if batchPriority != nil {
 				changes["priority"] = fmt.Sprintf("%d -> %d", t.Priority(), *batchPriority)
 			}
+			if batchParent != nil {
+				changes["parent"] = fmt.Sprintf("%s -> %s", t.ParentID(), *batchParent)
+			}
+			if batchDue != nil {
+				changes["due"] = fmt.Sprintf("%s -> %s", task.FormatDate(t.Due()), task.FormatDate(*batchDue))
+			}
+			if cmd.Flags().Changed("estimate") {
+				changes["estimate"] = fmt.Sprintf("%g -> %g", t.Estimate(), updateEstimate)
+			}
+			if batchAssignee != nil {
+				changes["assignee"] = fmt.Sprintf("%s -> %s", t.Assignee(), *batchAssignee)
+			}
+			if len(batchFields) > 0 {
+				fields := make(map[string]string)
+				for _, f := range batchFields {
+					old, _ := t.Field(f.Key)
+					fields[f.Key] = fmt.Sprintf("%s -> %s", old.Value, f.Value)
+				}
+				changes["fields"] = fields
+			}
 			if len(updateAddLabels) > 0 {
 				changes["add_labels"] = updateAddLabels
 			}
@@ -235,11 +360,15 @@ func handleBatchUpdate(cmd *cobra.Command) error {
 	}
 
 	// Apply updates
-	result := output.BulkResult{
-		Total: len(matchingTasks),
-	}
+	result := runBatch(svc, updateAtomic, len(matchingTasks), func(i int) (output.BulkItem, error) {
+		item := output.BulkItem{ID: matchingTasks[i].ID(), Title: matchingTasks[i].Title()}
+
+		// Re-read inside the transaction in case another process changed it
+		t, err := svc.GetTaskByID(item.ID)
+		if err != nil {
+			return item, err
+		}
 
-	for _, t := range matchingTasks {
 		// Apply changes
 		status := t.Status()
 		taskType := t.Type()
@@ -258,37 +387,48 @@ func handleBatchUpdate(cmd *cobra.Command) error {
 		updatedTask := task.NewTaskComplete(t.ID(), status, taskType, t.Title(), t.Description(), priority, t.Link())
 
 		if err := svc.UpdateTask(updatedTask); err != nil {
-			result.Failed = append(result.Failed, output.BulkItem{
-				ID:    t.ID(),
-				Title: t.Title(),
-				Error: err.Error(),
-			})
-			continue
+			return item, err
+		}
+		if batchParent != nil {
+			if err := svc.SetParent(t.ID(), *batchParent); err != nil {
+				return item, err
+			}
+		}
+		if batchDue != nil {
+			if err := svc.SetDue(t.ID(), *batchDue); err != nil {
+				return item, err
+			}
+		}
+		if cmd.Flags().Changed("estimate") {
+			if err := svc.SetEstimate(t.ID(), updateEstimate); err != nil {
+				return item, err
+			}
+		}
+		if batchAssignee != nil {
+			if err := svc.SetAssignee(t.ID(), *batchAssignee); err != nil {
+				return item, err
+			}
+		}
+		for _, f := range batchFields {
+			if err := svc.SetField(t.ID(), f); err != nil {
+				return item, err
+			}
 		}
 
 		// Track warnings for non-fatal label errors
-		var warnings []string
-
-		// Add labels
 		for _, label := range updateAddLabels {
 			if err := svc.AddLabel(t.ID(), label); err != nil {
-				warnings = append(warnings, "add label '"+label+"': "+err.Error())
+				item.Warnings = append(item.Warnings, "add label '"+label+"': "+err.Error())
 			}
 		}
-
-		// Remove labels
 		for _, label := range updateRemoveLabels {
 			if err := svc.RemoveLabel(t.ID(), label); err != nil {
-				warnings = append(warnings, "remove label '"+label+"': "+err.Error())
+				item.Warnings = append(item.Warnings, "remove label '"+label+"': "+err.Error())
 			}
 		}
 
-		result.Succeeded = append(result.Succeeded, output.BulkItem{
-			ID:       t.ID(),
-			Title:    t.Title(),
-			Warnings: warnings,
-		})
-	}
+		return item, nil
+	})
 
 	output.BulkSuccess("tasks updated", result)
 	return nil
@@ -297,12 +437,60 @@ func handleBatchUpdate(cmd *cobra.Command) error {
 func init() {
 	updateCmd.Flags().StringVar(&updateTitle, "title", "", "Task title")
 	updateCmd.Flags().StringVar(&updateDescription, "description", "", "Task description")
-	updateCmd.Flags().StringVar(&updateStatus, "status", "", "Task status (todo, in-progress, done)")
-	updateCmd.Flags().StringVar(&updateType, "type", "", "Task type (task, bug, feature, chore, docs)")
+	updateCmd.Flags().StringVar(&updateStatus, "status", "", "Task status (see 'pace task statuses')")
+	updateCmd.Flags().StringVar(&updateType, "type", "", "Task type (task, bug, feature, chore, docs, or a custom type)")
 	updateCmd.Flags().IntVar(&updatePriority, "priority", 0, "Task priority (0=none, 1=urgent, 2=high, 3=normal, 4=low)")
 	updateCmd.Flags().StringSliceVar(&updateAddLabels, "label", nil, "Add labels (can be specified multiple times)")
 	updateCmd.Flags().StringSliceVar(&updateRemoveLabels, "remove-label", nil, "Remove labels (can be specified multiple times)")
 	updateCmd.Flags().StringVar(&updateLink, "url", "", "URL associated with the task (e.g., google.com)")
-	updateCmd.Flags().StringArrayVar(&updateFilters, "filter", nil, "Filter tasks to update (status=X, type=X, priority=X, label=X)")
+	updateCmd.Flags().StringVar(&updateParent, "parent", "", "Parent task ID (empty to make the task top-level)")
+	updateCmd.Flags().StringVar(&updateDue, "due", "", "Due date (YYYY-MM-DD, today, tomorrow, +3d, +2w, or a weekday; empty to clear)")
+	updateCmd.Flags().Float64Var(&updateEstimate, "estimate", 0, "Estimate in the store's estimate unit (0 to clear)")
+	updateCmd.Flags().StringVar(&updateAssignee, "assignee", "", "Who the task is assigned to (\"me\" for yourself; empty to unassign)")
+	updateCmd.Flags().StringArrayVar(&updateFields, "field", nil, "Set a custom field as key=value or key:type=value, or remove it with key= (can be specified multiple times)")
+	updateCmd.Flags().StringArrayVar(&updateFilters, "filter", nil, filterHelp(" selecting the tasks to update"))
 	updateCmd.Flags().BoolVar(&updateDryRun, "dry-run", false, "Preview changes without applying them")
+	updateCmd.Flags().BoolVar(&updateAtomic, "atomic", false, "With --filter, update all matched tasks or none")
+}
+
+// parseDueFlag returns the due date set with --due, a zero time if it was
+// set to "", or nil if it was not given
+func parseDueFlag(cmd *cobra.Command) *time.Time {
+	if !cmd.Flags().Changed("due") {
+		return nil
+	}
+	var due time.Time
+	if updateDue != "" {
+		var err error
+		if due, err = task.ParseDueDate(updateDue); err != nil {
+			output.Error(err)
+		}
+	}
+	return &due
+}
+
+// parseAssigneeFlag returns the assignee set with --assignee, "" to
+// unassign, or nil if it was not given
+func parseAssigneeFlag(cmd *cobra.Command) *string {
+	if !cmd.Flags().Changed("assignee") {
+		return nil
+	}
+	assignee, err := task.ParseAssignee(updateAssignee)
+	if err != nil {
+		output.Error(err)
+	}
+	return &assignee
+}
+
+// parseFieldFlags parses the custom fields given with --field
+func parseFieldFlags(values []string) []task.Field {
+	var fields []task.Field
+	for _, v := range values {
+		field, err := task.ParseField(v)
+		if err != nil {
+			output.Error(err)
+		}
+		fields = append(fields, field)
+	}
+	return fields
 }
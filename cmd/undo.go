@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"github.com/lucas-tremaroli/pace/internal/output"
+	"github.com/lucas-tremaroli/pace/internal/task"
+	"github.com/spf13/cobra"
+)
+
+var undoCmd = &cobra.Command{
+	Use:   "undo",
+	Short: "Undo the last task change",
+	Long: `Reverts the most recent change made by 'pace task create', 'update', 'delete',
+'dep add', 'dep remove', 'dep chain', or the task TUI, and outputs the result in JSON format.
+
+A deleted task is restored together with its labels and dependencies. A change
+made by a batch command (--bulk or --filter) is undone as a whole. Undo refuses
+to run if a task it would restore has been changed since.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		svc, err := task.NewService()
+		if err != nil {
+			output.Error(err)
+		}
+		defer svc.Close()
+
+		op, err := svc.Undo()
+		if err != nil {
+			output.Error(err)
+		}
+
+		output.Success("operation undone", op)
+		return nil
+	},
+}
+
+var redoCmd = &cobra.Command{
+	Use:   "redo",
+	Short: "Redo the last undone task change",
+	Long: `Reapplies the most recently undone change and outputs the result in JSON format.
+
+Any new task change discards the changes that could be redone.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		svc, err := task.NewService()
+		if err != nil {
+			output.Error(err)
+		}
+		defer svc.Close()
+
+		op, err := svc.Redo()
+		if err != nil {
+			output.Error(err)
+		}
+
+		output.Success("operation redone", op)
+		return nil
+	},
+}
+
+func init() {
+	undoCmd.GroupID = "core"
+	redoCmd.GroupID = "core"
+	rootCmd.AddCommand(undoCmd)
+	rootCmd.AddCommand(redoCmd)
+}
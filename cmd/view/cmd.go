@@ -0,0 +1,20 @@
+package view
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var ViewCmd = &cobra.Command{
+	Use:   "view",
+	Short: "Manage saved task views",
+	Long: `Manage saved views: named filter queries and a sort, stored in the
+current pace storage. Use them with 'pace task list --view <name>' or
+'pace task tui --view <name>'.`,
+}
+
+func init() {
+	ViewCmd.GroupID = "core"
+	ViewCmd.AddCommand(saveCmd)
+	ViewCmd.AddCommand(listCmd)
+	ViewCmd.AddCommand(removeCmd)
+}
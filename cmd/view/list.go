@@ -0,0 +1,35 @@
+package view
+
+import (
+	"github.com/lucas-tremaroli/pace/internal/output"
+	"github.com/lucas-tremaroli/pace/internal/task"
+	"github.com/spf13/cobra"
+)
+
+var listCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the saved views",
+	Long:  `Outputs the saved views in JSON format, by name.`,
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		svc, err := task.NewService()
+		if err != nil {
+			output.Error(err)
+		}
+		defer svc.Close()
+
+		views, err := svc.ListViews()
+		if err != nil {
+			output.Error(err)
+		}
+		if views == nil {
+			views = []task.View{}
+		}
+
+		output.Print(map[string]any{
+			"views": views,
+			"count": len(views),
+		})
+		return nil
+	},
+}
@@ -0,0 +1,30 @@
+package view
+
+import (
+	"github.com/lucas-tremaroli/pace/internal/output"
+	"github.com/lucas-tremaroli/pace/internal/task"
+	"github.com/spf13/cobra"
+)
+
+var removeCmd = &cobra.Command{
+	Use:   "remove <name>",
+	Short: "Remove a saved view",
+	Long:  `Removes a saved view. Tasks are not affected.`,
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		svc, err := task.NewService()
+		if err != nil {
+			output.Error(err)
+		}
+		defer svc.Close()
+
+		if err := svc.RemoveView(args[0]); err != nil {
+			output.Error(err)
+		}
+
+		output.Success("view removed", map[string]string{
+			"name": args[0],
+		})
+		return nil
+	},
+}
@@ -0,0 +1,46 @@
+package view
+
+import (
+	"github.com/lucas-tremaroli/pace/internal/output"
+	"github.com/lucas-tremaroli/pace/internal/task"
+	"github.com/spf13/cobra"
+)
+
+var (
+	saveFilters []string
+	saveSort    string
+)
+
+var saveCmd = &cobra.Command{
+	Use:   "save <name>",
+	Short: "Save a named view",
+	Long: `Saves filter queries and a sort under a name, replacing any view with
+that name. Filters take the same queries as 'pace task list --filter' and
+are evaluated each time the view is used, so relative dates such as
+due<friday or updated>7d stay relative.
+
+Examples:
+  pace view save urgent-auth --filter 'priority<=2 AND label in (auth, api)'
+  pace view save stale --filter 'status!=done AND updated<14d' --sort updated`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		svc, err := task.NewService()
+		if err != nil {
+			output.Error(err)
+		}
+		defer svc.Close()
+
+		view, err := svc.SaveView(args[0], saveFilters, saveSort)
+		if err != nil {
+			output.Error(err)
+		}
+
+		output.Success("view saved", view)
+		return nil
+	},
+}
+
+func init() {
+	saveCmd.Flags().StringArrayVar(&saveFilters, "filter", nil, "Filter query (repeatable, all must match), as for 'pace task list --filter'")
+	saveCmd.Flags().StringVar(&saveSort, "sort", "", "Sort by: priority, created, updated, due")
+}
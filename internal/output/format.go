@@ -0,0 +1,596 @@
+package output
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/lipgloss/table"
+)
+
+// Output formats. Commands print JSON unless --format, or the command's
+// FormatAnnotation, selects another one.
+const (
+	FormatJSON     = "json"
+	FormatNDJSON   = "ndjson"
+	FormatYAML     = "yaml"
+	FormatCSV      = "csv"
+	FormatMarkdown = "markdown"
+	FormatTable    = "table"
+	FormatPretty   = "pretty"
+)
+
+// FormatAnnotation is the cobra annotation a command sets to print in
+// another format than JSON by default, such as note read's raw content
+const FormatAnnotation = "output-format"
+
+// Formatter writes a command's result in one output format
+type Formatter interface {
+	Format(w io.Writer, v any) error
+}
+
+// FormatterFunc adapts a function to a Formatter
+type FormatterFunc func(w io.Writer, v any) error
+
+// Format calls f(w, v)
+func (f FormatterFunc) Format(w io.Writer, v any) error {
+	return f(w, v)
+}
+
+var (
+	formatters = map[string]Formatter{}
+	format     = FormatJSON
+)
+
+func init() {
+	Register(FormatJSON, FormatterFunc(formatJSON))
+	Register(FormatNDJSON, FormatterFunc(formatNDJSON))
+	Register(FormatYAML, FormatterFunc(formatYAML))
+	Register(FormatCSV, FormatterFunc(formatCSV))
+	Register(FormatMarkdown, FormatterFunc(formatMarkdown))
+	Register(FormatTable, FormatterFunc(formatTable))
+	Register(FormatPretty, FormatterFunc(formatPretty))
+}
+
+// Register makes a formatter available under a format name, replacing any
+// formatter already registered under it
+func Register(name string, f Formatter) {
+	formatters[name] = f
+}
+
+// Formats returns the names of the registered formats, sorted
+func Formats() []string {
+	return slices.Sorted(maps.Keys(formatters))
+}
+
+// SetFormat selects the format Print writes in
+func SetFormat(name string) error {
+	if _, ok := formatters[name]; !ok {
+		return fmt.Errorf("unknown output format: %s (valid: %s)", name, strings.Join(Formats(), ", "))
+	}
+	format = name
+	return nil
+}
+
+// CurrentFormat returns the name of the selected format
+func CurrentFormat() string {
+	return format
+}
+
+// Print writes v to stdout in the selected format. Values are formatted
+// as they marshal to JSON.
+func Print(v any) {
+	if err := formatters[format].Format(os.Stdout, v); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// Prettier is a result with its own human-readable rendering, which the
+// pretty format prints instead of a table
+type Prettier interface {
+	Pretty(w io.Writer)
+}
+
+type pretty struct {
+	value  any
+	render func(w io.Writer)
+}
+
+func (p pretty) Pretty(w io.Writer) { p.render(w) }
+
+func (p pretty) MarshalJSON() ([]byte, error) { return json.Marshal(p.value) }
+
+// WithPretty attaches a human-readable rendering to v for the pretty
+// format. Every other format prints v itself.
+func WithPretty(v any, render func(w io.Writer)) any {
+	return pretty{value: v, render: render}
+}
+
+func formatJSON(w io.Writer, v any) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
+
+// formatNDJSON writes each item of a listing on its own line, so they can be
+// processed as they arrive, and any other value as a single line. Items are
+// encoded and written one at a time, never the whole listing at once.
+func formatNDJSON(w io.Writer, v any) error {
+	if p, ok := v.(pretty); ok {
+		v = p.value
+	}
+	encoder := json.NewEncoder(w)
+	items, ok := listValue(reflect.ValueOf(v))
+	if !ok {
+		return encoder.Encode(v)
+	}
+	for i := range items.Len() {
+		if err := encoder.Encode(items.Index(i).Interface()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// listValue is listItems for a value that has not been marshaled: it
+// returns the items of v if v marshals to a listing
+func listValue(v reflect.Value) (reflect.Value, bool) {
+	v = indirect(v)
+	if !v.IsValid() {
+		return reflect.Value{}, false
+	}
+	if o, ok := v.Interface().(Object); ok {
+		return listValue(reflect.ValueOf(o.Values))
+	}
+	if isArray(v) {
+		return v, true
+	}
+	if v.Type().Implements(reflect.TypeFor[json.Marshaler]()) {
+		return reflect.Value{}, false
+	}
+
+	var items reflect.Value
+	count, arrays := false, 0
+	visit := func(key string, value reflect.Value) {
+		count = count || key == "count"
+		if value = indirect(value); isArray(value) {
+			items = value
+			arrays++
+		}
+	}
+	switch v.Kind() {
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			return reflect.Value{}, false
+		}
+		for iter := v.MapRange(); iter.Next(); {
+			visit(iter.Key().String(), iter.Value())
+		}
+	case reflect.Struct:
+		visitFields(v, visit)
+	default:
+		return reflect.Value{}, false
+	}
+	return items, count && arrays == 1 && items.CanInterface()
+}
+
+// visitFields calls fn with the JSON key and value of each field a struct
+// marshals, including those of embedded structs
+func visitFields(v reflect.Value, fn func(key string, value reflect.Value)) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f, value := t.Field(i), v.Field(i)
+		name, opts, _ := strings.Cut(f.Tag.Get("json"), ",")
+		switch {
+		case name == "-":
+		case f.Anonymous && name == "" && f.Type.Kind() == reflect.Struct:
+			visitFields(value, fn)
+		case !f.IsExported():
+		case strings.Contains(opts, "omitempty") && isEmpty(value):
+		case name == "":
+			fn(f.Name, value)
+		default:
+			fn(name, value)
+		}
+	}
+}
+
+// indirect follows pointers and interfaces to the value they hold, or
+// returns the zero Value for nil
+func indirect(v reflect.Value) reflect.Value {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+	return v
+}
+
+// isArray reports whether v marshals to a JSON array
+func isArray(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Slice:
+		return !v.IsNil() && v.Type().Elem().Kind() != reflect.Uint8
+	case reflect.Array:
+		return true
+	}
+	return false
+}
+
+// isEmpty reports whether omitempty leaves v out
+func isEmpty(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Struct:
+		return false
+	}
+	return v.IsZero()
+}
+
+func formatCSV(w io.Writer, v any) error {
+	header, rows, _, err := tabulate(v)
+	if err != nil || len(rows) == 0 {
+		return err
+	}
+	cw := csv.NewWriter(w)
+	cw.Write(header)
+	return cw.WriteAll(rows)
+}
+
+var markdownEscaper = strings.NewReplacer("|", `\|`, "\r\n", "<br>", "\n", "<br>")
+
+func formatMarkdown(w io.Writer, v any) error {
+	header, rows, list, err := tabulate(v)
+	if err != nil || len(rows) == 0 {
+		return err
+	}
+	if !list {
+		header, rows = transpose(header, rows[0])
+	}
+	var b strings.Builder
+	for _, row := range append([][]string{header, nil}, rows...) {
+		b.WriteByte('|')
+		for i := range header {
+			if row == nil {
+				b.WriteString(" --- |")
+				continue
+			}
+			b.WriteString(" " + markdownEscaper.Replace(row[i]) + " |")
+		}
+		b.WriteByte('\n')
+	}
+	_, err = io.WriteString(w, b.String())
+	return err
+}
+
+// maxCellWidth is the width at which table cells are cut off
+const maxCellWidth = 60
+
+var (
+	tableHeaderStyle = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("212")).Padding(0, 1)
+	tableCellStyle   = lipgloss.NewStyle().Padding(0, 1)
+	tableBorderStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
+	emptyStyle       = lipgloss.NewStyle().Foreground(lipgloss.Color("243"))
+	successStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("42")).Bold(true)
+	errorStyle       = lipgloss.NewStyle().Foreground(lipgloss.Color("196")).Bold(true)
+)
+
+func formatTable(w io.Writer, v any) error {
+	header, rows, list, err := tabulate(v)
+	if err != nil {
+		return err
+	}
+	if len(rows) == 0 {
+		_, err := fmt.Fprintln(w, emptyStyle.Render("No results."))
+		return err
+	}
+	if !list {
+		header, rows = transpose(header, rows[0])
+	}
+	for _, row := range rows {
+		for i, c := range row {
+			c = strings.Join(strings.Fields(c), " ")
+			if r := []rune(c); len(r) > maxCellWidth {
+				c = string(r[:maxCellWidth-1]) + "…"
+			}
+			row[i] = c
+		}
+	}
+	t := table.New().
+		Border(lipgloss.RoundedBorder()).
+		BorderStyle(tableBorderStyle).
+		Headers(header...).
+		Rows(rows...).
+		StyleFunc(func(row, _ int) lipgloss.Style {
+			if row == table.HeaderRow {
+				return tableHeaderStyle
+			}
+			return tableCellStyle
+		})
+	_, err = fmt.Fprintln(w, t.Render())
+	return err
+}
+
+// formatPretty uses a result's own rendering if it has one, see WithPretty,
+// and falls back to a table. Responses print their message or error first.
+func formatPretty(w io.Writer, v any) error {
+	switch v := v.(type) {
+	case Prettier:
+		v.Pretty(w)
+		return nil
+	case Response:
+		if v.Success {
+			fmt.Fprintln(w, successStyle.Render("✓ "+v.Message))
+		} else {
+			fmt.Fprintln(w, errorStyle.Render("✗ "+v.Error))
+		}
+		if v.Data == nil {
+			return nil
+		}
+		return formatPretty(w, v.Data)
+	}
+	return formatTable(w, v)
+}
+
+// yamlPlain matches strings YAML reads back unchanged without quotes
+var yamlPlain = regexp.MustCompile(`^[A-Za-z_/][\w./@+()-]*( [\w./@+()-]+)*$`)
+
+func formatYAML(w io.Writer, v any) error {
+	data, err := decode(v)
+	if err != nil {
+		return err
+	}
+	var b strings.Builder
+	writeYAML(&b, data, 0)
+	_, err = io.WriteString(w, b.String())
+	return err
+}
+
+// writeYAML writes a value starting on a new line at the given depth
+func writeYAML(b *strings.Builder, v any, depth int) {
+	pad := strings.Repeat("  ", depth)
+	switch v := v.(type) {
+	case Object:
+		if len(v.Keys) == 0 {
+			b.WriteString(pad + "{}\n")
+		}
+		for _, key := range v.Keys {
+			b.WriteString(pad + yamlScalar(key) + ":")
+			writeYAMLValue(b, v.Values[key], depth+1)
+		}
+	case []any:
+		if len(v) == 0 {
+			b.WriteString(pad + "[]\n")
+		}
+		for _, item := range v {
+			if nested(item) {
+				// Start the item's first line after the dash
+				var sub strings.Builder
+				writeYAML(&sub, item, depth+1)
+				b.WriteString(pad + "- " + strings.TrimPrefix(sub.String(), pad+"  "))
+				continue
+			}
+			b.WriteString(pad + "-")
+			writeYAMLValue(b, item, depth+1)
+		}
+	default:
+		b.WriteString(pad + yamlScalar(v) + "\n")
+	}
+}
+
+// writeYAMLValue writes the value of a key, nested below it unless it is a
+// plain value
+func writeYAMLValue(b *strings.Builder, v any, depth int) {
+	if nested(v) {
+		b.WriteByte('\n')
+		writeYAML(b, v, depth)
+		return
+	}
+	switch v := v.(type) {
+	case Object:
+		b.WriteString(" {}\n")
+	case []any:
+		b.WriteString(" []\n")
+	default:
+		b.WriteString(" " + yamlScalar(v) + "\n")
+	}
+}
+
+// nested reports whether v is a non-empty object or list
+func nested(v any) bool {
+	switch v := v.(type) {
+	case Object:
+		return len(v.Keys) > 0
+	case []any:
+		return len(v) > 0
+	}
+	return false
+}
+
+func yamlScalar(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return "null"
+	case string:
+		switch strings.ToLower(v) {
+		case "true", "false", "yes", "no", "on", "off", "y", "n", "null":
+			return strconv.Quote(v)
+		}
+		if yamlPlain.MatchString(v) {
+			return v
+		}
+		return strconv.Quote(v)
+	}
+	return fmt.Sprint(v)
+}
+
+// decode converts v to the values it marshals to as JSON, keeping the order
+// of object keys: Object, []any, string, json.Number, bool or nil
+func decode(v any) (any, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	return decodeValue(dec)
+}
+
+func decodeValue(dec *json.Decoder) (any, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch tok {
+	case json.Delim('{'):
+		obj := Object{Values: map[string]any{}}
+		for dec.More() {
+			key, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			value, err := decodeValue(dec)
+			if err != nil {
+				return nil, err
+			}
+			obj.Keys = append(obj.Keys, key.(string))
+			obj.Values[key.(string)] = value
+		}
+		_, err := dec.Token()
+		return obj, err
+	case json.Delim('['):
+		items := []any{}
+		for dec.More() {
+			item, err := decodeValue(dec)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, item)
+		}
+		_, err := dec.Token()
+		return items, err
+	}
+	return tok, nil
+}
+
+// listItems returns the items of a listing: a JSON array, or an object
+// with a count and a single array, such as the tasks of task list
+func listItems(data any) ([]any, bool) {
+	switch data := data.(type) {
+	case []any:
+		return data, true
+	case Object:
+		if _, ok := data.Values["count"]; !ok {
+			return nil, false
+		}
+		var items []any
+		arrays := 0
+		for _, key := range data.Keys {
+			if a, ok := data.Values[key].([]any); ok {
+				items = a
+				arrays++
+			}
+		}
+		return items, arrays == 1
+	}
+	return nil, false
+}
+
+// tabulate returns the rows the tabular formats print for v: one per item
+// of a listing, or else v as a single row. A successful Response is
+// replaced by its data. Columns are the keys of the items in the order
+// they first appear; plain items have a single value column.
+func tabulate(v any) (header []string, rows [][]string, list bool, err error) {
+	if p, ok := v.(pretty); ok {
+		v = p.value
+	}
+	if r, ok := v.(Response); ok && r.Success && r.Data != nil {
+		v = r.Data
+	}
+	data, err := decode(v)
+	if err != nil {
+		return nil, nil, false, err
+	}
+	items, list := listItems(data)
+	if !list {
+		items = []any{data}
+	}
+
+	for _, item := range items {
+		obj, ok := item.(Object)
+		if !ok {
+			obj = Object{Keys: []string{"value"}, Values: map[string]any{"value": item}}
+		}
+		for _, key := range obj.Keys {
+			if !slices.Contains(header, key) {
+				header = append(header, key)
+			}
+		}
+	}
+	for _, item := range items {
+		obj, ok := item.(Object)
+		row := make([]string, len(header))
+		for i, key := range header {
+			if !ok {
+				if key == "value" {
+					row[i] = cell(item)
+				}
+				continue
+			}
+			row[i] = cell(obj.Values[key])
+		}
+		rows = append(rows, row)
+	}
+	return header, rows, list, nil
+}
+
+// transpose turns a single row into a field and value row per column,
+// which reads better than one wide row
+func transpose(header, row []string) ([]string, [][]string) {
+	rows := make([][]string, len(header))
+	for i, key := range header {
+		rows[i] = []string{key, row[i]}
+	}
+	return []string{"field", "value"}, rows
+}
+
+// cell formats a value as a table cell. Lists of plain values are joined
+// with commas; other lists and objects stay JSON.
+func cell(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	case bool:
+		return strconv.FormatBool(v)
+	case []any:
+		parts := make([]string, len(v))
+		for i, item := range v {
+			switch item.(type) {
+			case Object, []any:
+				data, _ := json.Marshal(v)
+				return string(data)
+			}
+			parts[i] = cell(item)
+		}
+		return strings.Join(parts, ", ")
+	}
+	data, _ := json.Marshal(v)
+	return string(data)
+}
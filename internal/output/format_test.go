@@ -0,0 +1,157 @@
+package output
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+type listing struct {
+	Tasks []Object `json:"tasks"`
+	Count int      `json:"count"`
+}
+
+func testListing(t *testing.T) listing {
+	t.Helper()
+	a, err := Project(map[string]any{"id": "t-1", "title": "Fix | pipe", "labels": []string{"a", "b"}}, []string{"id", "title", "labels"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	b, err := Project(map[string]any{"id": "t-2", "title": "yes"}, []string{"id", "title", "labels"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return listing{Tasks: []Object{a, b}, Count: 2}
+}
+
+func TestFormatters(t *testing.T) {
+	tests := []struct {
+		format string
+		v      any
+		want   string
+	}{
+		{FormatNDJSON, nil, `{"id":"t-1","title":"Fix | pipe","labels":["a","b"]}
+{"id":"t-2","title":"yes","labels":null}
+`},
+		{FormatCSV, nil, `id,title,labels
+t-1,Fix | pipe,"a, b"
+t-2,yes,
+`},
+		{FormatMarkdown, nil, `| id | title | labels |
+| --- | --- | --- |
+| t-1 | Fix \| pipe | a, b |
+| t-2 | yes |  |
+`},
+		{FormatYAML, nil, `tasks:
+  - id: t-1
+    title: "Fix | pipe"
+    labels:
+      - a
+      - b
+  - id: t-2
+    title: "yes"
+    labels: null
+count: 2
+`},
+		// Successful responses are replaced by their data, and single
+		// objects read as one field per row
+		{FormatCSV, Response{Success: true, Message: "ok", Data: map[string]any{"id": "t-1", "n": 1}}, `id,n
+t-1,1
+`},
+		{FormatMarkdown, map[string]any{"id": "t-1", "empty": []string{}}, `| field | value |
+| --- | --- |
+| empty |  |
+| id | t-1 |
+`},
+		{FormatYAML, map[string]any{"a": map[string]any{}, "b": []any{[]int{1}}, "c": "2026-01-01"}, `a: {}
+b:
+  - - 1
+c: "2026-01-01"
+`},
+	}
+	for _, tt := range tests {
+		v := tt.v
+		if v == nil {
+			v = testListing(t)
+		}
+		var b bytes.Buffer
+		if err := formatters[tt.format].Format(&b, v); err != nil {
+			t.Errorf("%s: unexpected error: %v", tt.format, err)
+			continue
+		}
+		if b.String() != tt.want {
+			t.Errorf("%s: got\n%s\nwant\n%s", tt.format, b.String(), tt.want)
+		}
+	}
+}
+
+func TestWithPretty(t *testing.T) {
+	v := WithPretty(map[string]string{"id": "t-1"}, func(w io.Writer) {
+		io.WriteString(w, "custom\n")
+	})
+
+	var b bytes.Buffer
+	if err := formatPretty(&b, v); err != nil || b.String() != "custom\n" {
+		t.Errorf("pretty = %q, %v, want the custom rendering", b.String(), err)
+	}
+	b.Reset()
+	if err := formatNDJSON(&b, v); err != nil || b.String() != "{\"id\":\"t-1\"}\n" {
+		t.Errorf("ndjson = %q, %v, want the value itself", b.String(), err)
+	}
+}
+
+type failingItem struct{}
+
+func (failingItem) MarshalJSON() ([]byte, error) { return nil, errors.New("cannot marshal") }
+
+type page struct {
+	Total int `json:"total"`
+}
+
+func TestFormatNDJSON(t *testing.T) {
+	tests := []struct {
+		v    any
+		want string
+	}{
+		{map[string]any{"count": 2, "notes": []string{"a", "b"}}, "\"a\"\n\"b\"\n"},
+		{struct {
+			Items []int `json:"items"`
+			Count int   `json:"count"`
+			page
+		}{[]int{1, 2}, 2, page{2}}, "1\n2\n"},
+		{[]int{}, ""},
+		// Without a count, or with more than one array, it is not a listing
+		{map[string]any{"a": []int{1}}, "{\"a\":[1]}\n"},
+		{map[string]any{"count": 1, "a": []int{1}, "b": []int{2}}, "{\"a\":[1],\"b\":[2],\"count\":1}\n"},
+	}
+	for _, tt := range tests {
+		var b bytes.Buffer
+		if err := formatNDJSON(&b, tt.v); err != nil || b.String() != tt.want {
+			t.Errorf("ndjson(%v) = %q, %v, want %q", tt.v, b.String(), err, tt.want)
+		}
+	}
+
+	// Items are written as they are encoded, so those before a failing one
+	// are already out
+	var b bytes.Buffer
+	err := formatNDJSON(&b, []any{1, failingItem{}, 3})
+	if err == nil || b.String() != "1\n" {
+		t.Errorf("ndjson = %q, %v, want the first item and an error", b.String(), err)
+	}
+}
+
+func TestSetFormat(t *testing.T) {
+	defer SetFormat(FormatJSON)
+	if err := SetFormat(FormatYAML); err != nil || CurrentFormat() != FormatYAML {
+		t.Errorf("SetFormat(yaml) = %v, current %s", err, CurrentFormat())
+	}
+	err := SetFormat("xml")
+	if err == nil || !strings.Contains(err.Error(), "csv, json") {
+		t.Errorf("SetFormat(xml) = %v, want an error listing the formats", err)
+	}
+	if CurrentFormat() != FormatYAML {
+		t.Errorf("a failed SetFormat changed the format to %s", CurrentFormat())
+	}
+}
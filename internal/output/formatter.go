@@ -1,7 +1,7 @@
 package output
 
 import (
-	"encoding/json"
+	"errors"
 	"os"
 )
 
@@ -13,34 +13,39 @@ type Response struct {
 	Data    any    `json:"data,omitempty"`
 }
 
-// JSON prints any value as formatted JSON to stdout
-func JSON(v any) {
-	encoder := json.NewEncoder(os.Stdout)
-	encoder.SetIndent("", "  ")
-	encoder.Encode(v)
-}
-
 // Success prints a success response with optional data
 func Success(message string, data any) {
-	JSON(Response{
+	Print(Response{
 		Success: true,
 		Message: message,
 		Data:    data,
 	})
 }
 
+// DataError is an error that carries structured details, which Error
+// includes as the response data
+type DataError interface {
+	error
+	ErrorData() any
+}
+
 // Error prints an error response and exits with code 1
 func Error(err error) {
-	JSON(Response{
+	resp := Response{
 		Success: false,
 		Error:   err.Error(),
-	})
+	}
+	var dataErr DataError
+	if errors.As(err, &dataErr) {
+		resp.Data = dataErr.ErrorData()
+	}
+	Print(resp)
 	os.Exit(1)
 }
 
 // ErrorMsg prints an error message response and exits with code 1
 func ErrorMsg(message string) {
-	JSON(Response{
+	Print(Response{
 		Success: false,
 		Error:   message,
 	})
@@ -49,9 +54,10 @@ func ErrorMsg(message string) {
 
 // BulkResult represents the result of a bulk operation
 type BulkResult struct {
-	Succeeded []BulkItem `json:"succeeded"`
-	Failed    []BulkItem `json:"failed"`
-	Total     int        `json:"total"`
+	Succeeded  []BulkItem `json:"succeeded"`
+	Failed     []BulkItem `json:"failed"`
+	Total      int        `json:"total"`
+	RolledBack bool       `json:"rolled_back"` // An --atomic batch failed and nothing was applied
 }
 
 // BulkItem represents a single item in a bulk operation result
@@ -71,10 +77,12 @@ func BulkSuccess(message string, result BulkResult) {
 		Message: message,
 		Data:    result,
 	}
-	if !success && len(result.Failed) > 0 {
+	if result.RolledBack {
+		resp.Error = "batch rolled back: no changes were made"
+	} else if !success && len(result.Failed) > 0 {
 		resp.Error = "all operations failed"
 	}
-	JSON(resp)
+	Print(resp)
 	if !success {
 		os.Exit(1)
 	}
@@ -0,0 +1,102 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// Object is a JSON object that keeps its keys in order, as built by Project
+type Object struct {
+	Keys   []string
+	Values map[string]any
+}
+
+// MarshalJSON writes the object's keys in order
+func (o Object) MarshalJSON() ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteByte('{')
+	for i, key := range o.Keys {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		k, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		v, err := json.Marshal(o.Values[key])
+		if err != nil {
+			return nil, err
+		}
+		b.Write(k)
+		b.WriteByte(':')
+		b.Write(v)
+	}
+	b.WriteByte('}')
+	return b.Bytes(), nil
+}
+
+// FieldNames returns the JSON keys of a struct type, including those of
+// embedded structs, in declaration order
+func FieldNames(t reflect.Type) []string {
+	var names []string
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		switch {
+		case name == "-":
+		case f.Anonymous && name == "" && f.Type.Kind() == reflect.Struct:
+			names = append(names, FieldNames(f.Type)...)
+		case !f.IsExported():
+		case name == "":
+			names = append(names, f.Name)
+		default:
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// ParseFields parses a comma separated list of JSON keys, e.g. for a
+// --fields flag, checking each one is in allowed
+func ParseFields(spec string, allowed []string) ([]string, error) {
+	var fields []string
+	for _, f := range strings.Split(spec, ",") {
+		f = strings.TrimSpace(f)
+		if f == "" {
+			continue
+		}
+		if !slices.Contains(allowed, f) {
+			return nil, fmt.Errorf("unknown field: %s (valid: %s)", f, strings.Join(allowed, ", "))
+		}
+		if !slices.Contains(fields, f) {
+			fields = append(fields, f)
+		}
+	}
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("no fields given (valid: %s)", strings.Join(allowed, ", "))
+	}
+	return fields, nil
+}
+
+// Project returns the JSON object of v with only the given keys, in that
+// order. Keys that v leaves out, such as empty omitempty fields, are null,
+// so every projected object has the same keys.
+func Project(v any, fields []string) (Object, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return Object{}, err
+	}
+	var all map[string]any
+	if err := json.Unmarshal(data, &all); err != nil {
+		return Object{}, err
+	}
+	obj := Object{Keys: fields, Values: make(map[string]any, len(fields))}
+	for _, f := range fields {
+		obj.Values[f] = all[f]
+	}
+	return obj, nil
+}
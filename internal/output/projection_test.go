@@ -0,0 +1,53 @@
+package output
+
+import (
+	"encoding/json"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+type inner struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+	Due   string `json:"due,omitempty"`
+}
+
+type outer struct {
+	inner
+	Score float64 `json:"score"`
+	skip  string
+}
+
+func TestFieldNames(t *testing.T) {
+	got := FieldNames(reflect.TypeFor[outer]())
+	if want := []string{"id", "title", "due", "score"}; !slices.Equal(got, want) {
+		t.Errorf("FieldNames() = %v, want %v", got, want)
+	}
+}
+
+func TestProject(t *testing.T) {
+	allowed := FieldNames(reflect.TypeFor[outer]())
+	fields, err := ParseFields("title, id,due,title", allowed)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	obj, err := Project(outer{inner: inner{ID: "t-1", Title: "x"}, Score: 2}, fields)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	data, err := json.Marshal(obj)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// Keys keep the requested order, and omitted fields are null
+	if want := `{"title":"x","id":"t-1","due":null}`; string(data) != want {
+		t.Errorf("Project() = %s, want %s", data, want)
+	}
+
+	for _, spec := range []string{"", "id,colour"} {
+		if _, err := ParseFields(spec, allowed); err == nil {
+			t.Errorf("ParseFields(%q) expected error, got nil", spec)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package storage
+
+// CommentRecord is a single comment on a task
+type CommentRecord struct {
+	ID        int64  `json:"id"`
+	TaskID    string `json:"task_id"`
+	Author    string `json:"author"`
+	Body      string `json:"body"`
+	CreatedAt string `json:"timestamp"`
+}
+
+const commentColumns = `id, task_id, author, body, created_at`
+
+// AddComment inserts a comment and returns its ID. A zero ID is assigned
+// automatically; a non-zero ID is kept, which is how undo restores comments.
+func (db *DB) AddComment(comment CommentRecord) (int64, error) {
+	var id any
+	if comment.ID != 0 {
+		id = comment.ID
+	}
+	query := `INSERT INTO task_comments (id, task_id, author, body, created_at) VALUES (?, ?, ?, ?, ?)`
+	result, err := db.q.Exec(query, id, comment.TaskID, comment.Author, comment.Body, comment.CreatedAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.LastInsertId()
+}
+
+// GetComment returns a single comment by ID
+func (db *DB) GetComment(id int64) (*CommentRecord, error) {
+	query := `SELECT ` + commentColumns + ` FROM task_comments WHERE id = ?`
+	var c CommentRecord
+	err := db.q.QueryRow(query, id).Scan(&c.ID, &c.TaskID, &c.Author, &c.Body, &c.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// GetComments returns the comments on a task, oldest first
+func (db *DB) GetComments(taskID string) ([]CommentRecord, error) {
+	query := `SELECT ` + commentColumns + ` FROM task_comments WHERE task_id = ? ORDER BY id`
+	rows, err := db.q.Query(query, taskID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var comments []CommentRecord
+	for rows.Next() {
+		var c CommentRecord
+		if err := rows.Scan(&c.ID, &c.TaskID, &c.Author, &c.Body, &c.CreatedAt); err != nil {
+			return nil, err
+		}
+		comments = append(comments, c)
+	}
+	return comments, rows.Err()
+}
+
+// DeleteComment removes a single comment
+func (db *DB) DeleteComment(id int64) error {
+	_, err := db.q.Exec(`DELETE FROM task_comments WHERE id = ?`, id)
+	return err
+}
+
+// RemoveAllComments removes every comment on a task
+func (db *DB) RemoveAllComments(taskID string) error {
+	_, err := db.q.Exec(`DELETE FROM task_comments WHERE task_id = ?`, taskID)
+	return err
+}
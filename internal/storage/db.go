@@ -2,25 +2,78 @@ package storage
 
 import (
 	"database/sql"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"math/rand/v2"
 	"os"
 	"path/filepath"
+	"time"
 
-	_ "modernc.org/sqlite"
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+// Several processes (the TUI and one or more agents) commonly share a store.
+// WAL lets readers proceed while a write is in progress, the busy timeout
+// makes a writer wait for the lock instead of failing, and immediate
+// transactions take the write lock up front so a transaction never fails
+// halfway through upgrading from a read lock.
+const connParams = "?_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)&_pragma=synchronous(NORMAL)&_txlock=immediate"
+
+// Retry settings for beginning a write transaction when the busy timeout
+// alone was not enough
+const (
+	beginAttempts   = 8
+	beginBaseDelay  = 25 * time.Millisecond
+	beginMaxBackoff = time.Second
 )
 
 type DB struct {
 	conn *sql.DB
+	// q runs queries on the connection pool, or on a transaction for a
+	// DB returned by WithTx
+	q querier
+}
+
+// querier is implemented by both *sql.DB and *sql.Tx
+type querier interface {
+	Exec(query string, args ...any) (sql.Result, error)
+	Query(query string, args ...any) (*sql.Rows, error)
+	QueryRow(query string, args ...any) *sql.Row
 }
 
 type TaskRecord struct {
-	ID          string `json:"id"`
-	Title       string `json:"title"`
-	Description string `json:"description"`
-	Status      int    `json:"status"`
-	TaskType    int    `json:"task_type"`
-	Priority    int    `json:"priority"`
-	Link        string `json:"link"`
+	ID          string  `json:"id"`
+	Title       string  `json:"title"`
+	Description string  `json:"description"`
+	Status      string  `json:"status"`
+	TaskType    int     `json:"task_type"`
+	Priority    int     `json:"priority"`
+	Link        string  `json:"link"`
+	CreatedAt   string  `json:"created_at"`
+	UpdatedAt   string  `json:"updated_at"`
+	CompletedAt string  `json:"completed_at"`
+	ArchivedAt  string  `json:"archived_at"`
+	ParentID    string  `json:"parent_id"`
+	DueDate     string  `json:"due_date"`
+	Estimate    float64 `json:"estimate"`
+	Assignee    string  `json:"assignee"`
+}
+
+// taskColumns is the column list shared by every query that loads a TaskRecord
+const taskColumns = `id, title, description, status, task_type, priority, COALESCE(link, ''), COALESCE(created_at, ''), COALESCE(updated_at, ''), COALESCE(completed_at, ''), COALESCE(archived_at, ''), COALESCE(parent_id, ''), COALESCE(due_date, ''), estimate, COALESCE(assignee, '')`
+
+// rowScanner is implemented by both *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// scanTask reads a TaskRecord selected with taskColumns
+func scanTask(row rowScanner) (TaskRecord, error) {
+	var task TaskRecord
+	err := row.Scan(&task.ID, &task.Title, &task.Description, &task.Status, &task.TaskType, &task.Priority, &task.Link, &task.CreatedAt, &task.UpdatedAt, &task.CompletedAt, &task.ArchivedAt, &task.ParentID, &task.DueDate, &task.Estimate, &task.Assignee)
+	return task, err
 }
 
 func NewDB() (*DB, error) {
@@ -32,26 +85,104 @@ func NewDB() (*DB, error) {
 	return NewDBWithPath(dbPath)
 }
 
-// NewDBWithPath creates a new DB instance with a specific database path
+// NewDBWithPath creates a new DB instance with a specific database path,
+// applying any pending schema migrations
 func NewDBWithPath(dbPath string) (*DB, error) {
-	conn, err := sql.Open("sqlite", dbPath)
+	db, err := OpenDBWithPath(dbPath)
 	if err != nil {
-		return nil, fmt.Errorf("failed to open database: %w", err)
+		return nil, err
 	}
 
-	db := &DB{conn: conn}
-	if err := db.createTables(); err != nil {
-		conn.Close()
-		return nil, fmt.Errorf("failed to create tables: %w", err)
+	if _, err := db.Migrate(); err != nil {
+		db.Close()
+		return nil, err
 	}
 
 	return db, nil
 }
 
+// OpenDB opens the resolved database without applying migrations
+func OpenDB() (*DB, error) {
+	dbPath, err := getDBPath()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get database path: %w", err)
+	}
+
+	return OpenDBWithPath(dbPath)
+}
+
+// OpenDBWithPath opens a database without applying migrations, for
+// inspecting or explicitly upgrading its schema
+func OpenDBWithPath(dbPath string) (*DB, error) {
+	conn, err := sql.Open("sqlite", dbPath+connParams)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	return &DB{conn: conn, q: conn}, nil
+}
+
+// isBusy reports whether err means another connection holds the lock
+func isBusy(err error) bool {
+	var sqliteErr *sqlite.Error
+	if !errors.As(err, &sqliteErr) {
+		return false
+	}
+	code := sqliteErr.Code() & 0xff
+	return code == sqlite3.SQLITE_BUSY || code == sqlite3.SQLITE_LOCKED
+}
+
+// begin starts a write transaction, retrying with jittered exponential
+// backoff while the database stays locked by other processes
+func (db *DB) begin() (*sql.Tx, error) {
+	delay := beginBaseDelay
+	for attempt := 1; ; attempt++ {
+		tx, err := db.conn.Begin()
+		if err == nil || !isBusy(err) || attempt == beginAttempts {
+			return tx, err
+		}
+		time.Sleep(delay/2 + rand.N(delay))
+		delay = min(delay*2, beginMaxBackoff)
+	}
+}
+
 func (db *DB) Close() error {
 	return db.conn.Close()
 }
 
+// InTx reports whether the DB is scoped to a transaction
+func (db *DB) InTx() bool {
+	_, ok := db.q.(*sql.Tx)
+	return ok
+}
+
+// WithTx runs fn with a DB whose methods all execute in a single transaction.
+// The transaction is committed if fn returns nil and rolled back otherwise.
+// Calling WithTx on a DB that is already scoped to a transaction runs fn in
+// that same transaction.
+func (db *DB) WithTx(fn func(tx *DB) error) error {
+	if db.InTx() {
+		return fn(db)
+	}
+
+	tx, err := db.begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	if err := fn(&DB{conn: db.conn, q: tx}); err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			return fmt.Errorf("%w (rollback failed: %v)", err, rbErr)
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
 // GetPaceConfigDir returns the pace configuration directory path
 func GetPaceConfigDir() (string, error) {
 	resolved, err := ResolvePaceDir()
@@ -75,80 +206,10 @@ func getDBPath() (string, error) {
 	return filepath.Join(paceDir, "tasks.db"), nil
 }
 
-func (db *DB) createTables() error {
-	query := `
-		CREATE TABLE IF NOT EXISTS tasks (
-			id VARCHAR PRIMARY KEY,
-			title VARCHAR NOT NULL,
-			description VARCHAR,
-			status INTEGER NOT NULL,
-			priority INTEGER NOT NULL DEFAULT 0
-		);
-	`
-	if _, err := db.conn.Exec(query); err != nil {
-		return err
-	}
-
-	// Migration: add priority column if it doesn't exist
-	_, err := db.conn.Exec(`ALTER TABLE tasks ADD COLUMN priority INTEGER NOT NULL DEFAULT 0`)
-	// Ignore error if column already exists
-	_ = err
-
-	// Migration: add task_type column if it doesn't exist (0 = task, 1 = bug, 2 = feature, 3 = chore, 4 = docs)
-	_, err = db.conn.Exec(`ALTER TABLE tasks ADD COLUMN task_type INTEGER NOT NULL DEFAULT 0`)
-	// Ignore error if column already exists
-	_ = err
-
-	// Migration: add link column if it doesn't exist
-	_, err = db.conn.Exec(`ALTER TABLE tasks ADD COLUMN link VARCHAR DEFAULT ''`)
-	// Ignore error if column already exists
-	_ = err
-
-	// Create task_dependencies table for blocking relationships
-	depQuery := `
-		CREATE TABLE IF NOT EXISTS task_dependencies (
-			blocker_id VARCHAR NOT NULL,
-			blocked_id VARCHAR NOT NULL,
-			PRIMARY KEY (blocker_id, blocked_id),
-			FOREIGN KEY (blocker_id) REFERENCES tasks(id) ON DELETE CASCADE,
-			FOREIGN KEY (blocked_id) REFERENCES tasks(id) ON DELETE CASCADE
-		);
-	`
-	if _, err := db.conn.Exec(depQuery); err != nil {
-		return err
-	}
-
-	// Create config table for settings like id_prefix
-	configQuery := `
-		CREATE TABLE IF NOT EXISTS config (
-			key VARCHAR PRIMARY KEY,
-			value VARCHAR NOT NULL
-		);
-	`
-	if _, err := db.conn.Exec(configQuery); err != nil {
-		return err
-	}
-
-	// Create task_labels table for label associations
-	labelsQuery := `
-		CREATE TABLE IF NOT EXISTS task_labels (
-			task_id VARCHAR NOT NULL,
-			label VARCHAR NOT NULL,
-			PRIMARY KEY (task_id, label),
-			FOREIGN KEY (task_id) REFERENCES tasks(id) ON DELETE CASCADE
-		);
-	`
-	if _, err := db.conn.Exec(labelsQuery); err != nil {
-		return err
-	}
-
-	return nil
-}
-
 // GetConfig retrieves a config value by key
 func (db *DB) GetConfig(key string) (string, error) {
 	query := `SELECT value FROM config WHERE key = ?`
-	row := db.conn.QueryRow(query, key)
+	row := db.q.QueryRow(query, key)
 	var value string
 	err := row.Scan(&value)
 	return value, err
@@ -157,14 +218,14 @@ func (db *DB) GetConfig(key string) (string, error) {
 // SetConfig sets a config value
 func (db *DB) SetConfig(key, value string) error {
 	query := `INSERT OR REPLACE INTO config (key, value) VALUES (?, ?)`
-	_, err := db.conn.Exec(query, key, value)
+	_, err := db.q.Exec(query, key, value)
 	return err
 }
 
 // DeleteConfig removes a config value by key
 func (db *DB) DeleteConfig(key string) error {
 	query := `DELETE FROM config WHERE key = ?`
-	result, err := db.conn.Exec(query, key)
+	result, err := db.q.Exec(query, key)
 	if err != nil {
 		return err
 	}
@@ -181,7 +242,7 @@ func (db *DB) DeleteConfig(key string) error {
 // GetAllConfig retrieves all config key-value pairs
 func (db *DB) GetAllConfig() (map[string]string, error) {
 	query := `SELECT key, value FROM config ORDER BY key`
-	rows, err := db.conn.Query(query)
+	rows, err := db.q.Query(query)
 	if err != nil {
 		return nil, err
 	}
@@ -198,15 +259,38 @@ func (db *DB) GetAllConfig() (map[string]string, error) {
 	return config, rows.Err()
 }
 
-func (db *DB) CreateTask(id, title, description string, status, taskType, priority int, link string) error {
-	query := `INSERT INTO tasks (id, title, description, status, task_type, priority, link) VALUES (?, ?, ?, ?, ?, ?, ?)`
-	_, err := db.conn.Exec(query, id, title, description, status, taskType, priority, link)
+// CreateTask inserts a new task record
+func (db *DB) CreateTask(task TaskRecord) error {
+	query := `INSERT INTO tasks (id, title, description, status, task_type, priority, link, created_at, updated_at, completed_at, archived_at, parent_id, due_date, estimate, assignee) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+	_, err := db.q.Exec(query, task.ID, task.Title, task.Description, task.Status, task.TaskType, task.Priority, task.Link, task.CreatedAt, task.UpdatedAt, nullIfEmpty(task.CompletedAt), nullIfEmpty(task.ArchivedAt), nullIfEmpty(task.ParentID), nullIfEmpty(task.DueDate), task.Estimate, nullIfEmpty(task.Assignee))
 	return err
 }
 
 func (db *DB) GetAllTasks() ([]TaskRecord, error) {
-	query := `SELECT id, title, description, status, task_type, priority, COALESCE(link, '') FROM tasks ORDER BY priority DESC, title`
-	rows, err := db.conn.Query(query)
+	return db.queryTasks(`SELECT ` + taskColumns + ` FROM tasks ORDER BY priority DESC, title`)
+}
+
+// GetTasksByIDs returns the tasks with the given IDs. IDs with no task are
+// skipped.
+func (db *DB) GetTasksByIDs(ids []string) ([]TaskRecord, error) {
+	return db.queryTasks(`SELECT `+taskColumns+` FROM tasks WHERE id IN `+idList+` ORDER BY priority DESC, title`, idArg(ids))
+}
+
+// idList is an IN operand that expands the JSON array of IDs made by idArg,
+// so a query takes any number of IDs as a single argument
+const idList = `(SELECT value FROM json_each(?))`
+
+// idArg returns ids as the argument for idList
+func idArg(ids []string) string {
+	if ids == nil {
+		ids = []string{}
+	}
+	data, _ := json.Marshal(ids)
+	return string(data)
+}
+
+func (db *DB) queryTasks(query string, args ...any) ([]TaskRecord, error) {
+	rows, err := db.q.Query(query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -214,8 +298,7 @@ func (db *DB) GetAllTasks() ([]TaskRecord, error) {
 
 	var tasks []TaskRecord
 	for rows.Next() {
-		var task TaskRecord
-		err := rows.Scan(&task.ID, &task.Title, &task.Description, &task.Status, &task.TaskType, &task.Priority, &task.Link)
+		task, err := scanTask(rows)
 		if err != nil {
 			return nil, err
 		}
@@ -225,48 +308,80 @@ func (db *DB) GetAllTasks() ([]TaskRecord, error) {
 	return tasks, rows.Err()
 }
 
-func (db *DB) UpdateTask(id, title, description string, status, taskType, priority int, link string) error {
-	query := `UPDATE tasks SET title = ?, description = ?, status = ?, task_type = ?, priority = ?, link = ? WHERE id = ?`
-	_, err := db.conn.Exec(query, title, description, status, taskType, priority, link, id)
+// UpdateTask overwrites all fields of an existing task record
+func (db *DB) UpdateTask(task TaskRecord) error {
+	query := `UPDATE tasks SET title = ?, description = ?, status = ?, task_type = ?, priority = ?, link = ?, created_at = ?, updated_at = ?, completed_at = ?, archived_at = ?, parent_id = ?, due_date = ?, estimate = ?, assignee = ? WHERE id = ?`
+	_, err := db.q.Exec(query, task.Title, task.Description, task.Status, task.TaskType, task.Priority, task.Link, task.CreatedAt, task.UpdatedAt, nullIfEmpty(task.CompletedAt), nullIfEmpty(task.ArchivedAt), nullIfEmpty(task.ParentID), nullIfEmpty(task.DueDate), task.Estimate, nullIfEmpty(task.Assignee), task.ID)
 	return err
 }
 
 func (db *DB) DeleteTask(id string) error {
 	query := `DELETE FROM tasks WHERE id = ?`
-	_, err := db.conn.Exec(query, id)
+	_, err := db.q.Exec(query, id)
 	return err
 }
 
 func (db *DB) GetTaskByID(id string) (*TaskRecord, error) {
-	query := `SELECT id, title, description, status, task_type, priority, COALESCE(link, '') FROM tasks WHERE id = ?`
-	row := db.conn.QueryRow(query, id)
-
-	var task TaskRecord
-	err := row.Scan(&task.ID, &task.Title, &task.Description, &task.Status, &task.TaskType, &task.Priority, &task.Link)
+	query := `SELECT ` + taskColumns + ` FROM tasks WHERE id = ?`
+	task, err := scanTask(db.q.QueryRow(query, id))
 	if err != nil {
 		return nil, err
 	}
 	return &task, nil
 }
 
+// GetChildTasks returns the tasks whose parent is parentID
+func (db *DB) GetChildTasks(parentID string) ([]TaskRecord, error) {
+	return db.queryTasks(`SELECT `+taskColumns+` FROM tasks WHERE parent_id = ? ORDER BY priority DESC, title`, parentID)
+}
+
+// GetChildTasksOf returns the tasks whose parent is one of parentIDs
+func (db *DB) GetChildTasksOf(parentIDs []string) ([]TaskRecord, error) {
+	return db.queryTasks(`SELECT `+taskColumns+` FROM tasks WHERE parent_id IN `+idList+` ORDER BY priority DESC, title`, idArg(parentIDs))
+}
+
+// TaskExists reports whether a task with the given ID exists
+func (db *DB) TaskExists(id string) (bool, error) {
+	var exists bool
+	err := db.q.QueryRow(`SELECT EXISTS (SELECT 1 FROM tasks WHERE id = ?)`, id).Scan(&exists)
+	return exists, err
+}
+
+// CountTaskIDs counts the task IDs that start with prefix and are exactly
+// length characters long
+func (db *DB) CountTaskIDs(prefix string, length int) (int, error) {
+	var count int
+	query := `SELECT COUNT(*) FROM tasks WHERE substr(id, 1, ?) = ? AND length(id) = ?`
+	err := db.q.QueryRow(query, len(prefix), prefix, length).Scan(&count)
+	return count, err
+}
+
+// nullIfEmpty stores empty optional values as NULL
+func nullIfEmpty(s string) any {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
 // AddDependency creates a blocking relationship where blocker blocks blocked
 func (db *DB) AddDependency(blockerID, blockedID string) error {
 	query := `INSERT OR IGNORE INTO task_dependencies (blocker_id, blocked_id) VALUES (?, ?)`
-	_, err := db.conn.Exec(query, blockerID, blockedID)
+	_, err := db.q.Exec(query, blockerID, blockedID)
 	return err
 }
 
 // RemoveDependency removes a blocking relationship
 func (db *DB) RemoveDependency(blockerID, blockedID string) error {
 	query := `DELETE FROM task_dependencies WHERE blocker_id = ? AND blocked_id = ?`
-	_, err := db.conn.Exec(query, blockerID, blockedID)
+	_, err := db.q.Exec(query, blockerID, blockedID)
 	return err
 }
 
 // GetBlockers returns the IDs of tasks that block the given task
 func (db *DB) GetBlockers(taskID string) ([]string, error) {
 	query := `SELECT blocker_id FROM task_dependencies WHERE blocked_id = ?`
-	rows, err := db.conn.Query(query, taskID)
+	rows, err := db.q.Query(query, taskID)
 	if err != nil {
 		return nil, err
 	}
@@ -286,7 +401,7 @@ func (db *DB) GetBlockers(taskID string) ([]string, error) {
 // GetBlocking returns the IDs of tasks that the given task blocks
 func (db *DB) GetBlocking(taskID string) ([]string, error) {
 	query := `SELECT blocked_id FROM task_dependencies WHERE blocker_id = ?`
-	rows, err := db.conn.Query(query, taskID)
+	rows, err := db.q.Query(query, taskID)
 	if err != nil {
 		return nil, err
 	}
@@ -305,8 +420,19 @@ func (db *DB) GetBlocking(taskID string) ([]string, error) {
 
 // GetAllDependencies returns all dependency relationships
 func (db *DB) GetAllDependencies() (map[string][]string, map[string][]string, error) {
-	query := `SELECT blocker_id, blocked_id FROM task_dependencies`
-	rows, err := db.conn.Query(query)
+	return db.queryDependencies(`SELECT blocker_id, blocked_id FROM task_dependencies`)
+}
+
+// GetDependenciesOf returns the dependency relationships in which any of
+// the given tasks takes part
+func (db *DB) GetDependenciesOf(ids []string) (map[string][]string, map[string][]string, error) {
+	arg := idArg(ids)
+	query := `SELECT blocker_id, blocked_id FROM task_dependencies WHERE blocker_id IN ` + idList + ` OR blocked_id IN ` + idList
+	return db.queryDependencies(query, arg, arg)
+}
+
+func (db *DB) queryDependencies(query string, args ...any) (map[string][]string, map[string][]string, error) {
+	rows, err := db.q.Query(query, args...)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -330,28 +456,28 @@ func (db *DB) GetAllDependencies() (map[string][]string, map[string][]string, er
 // RemoveAllDependencies removes all dependencies for a task (both directions)
 func (db *DB) RemoveAllDependencies(taskID string) error {
 	query := `DELETE FROM task_dependencies WHERE blocker_id = ? OR blocked_id = ?`
-	_, err := db.conn.Exec(query, taskID, taskID)
+	_, err := db.q.Exec(query, taskID, taskID)
 	return err
 }
 
 // AddLabel adds a label to a task
 func (db *DB) AddLabel(taskID, label string) error {
 	query := `INSERT OR IGNORE INTO task_labels (task_id, label) VALUES (?, ?)`
-	_, err := db.conn.Exec(query, taskID, label)
+	_, err := db.q.Exec(query, taskID, label)
 	return err
 }
 
 // RemoveLabel removes a label from a task
 func (db *DB) RemoveLabel(taskID, label string) error {
 	query := `DELETE FROM task_labels WHERE task_id = ? AND label = ?`
-	_, err := db.conn.Exec(query, taskID, label)
+	_, err := db.q.Exec(query, taskID, label)
 	return err
 }
 
 // GetLabels returns all labels for a task
 func (db *DB) GetLabels(taskID string) ([]string, error) {
 	query := `SELECT label FROM task_labels WHERE task_id = ? ORDER BY label`
-	rows, err := db.conn.Query(query, taskID)
+	rows, err := db.q.Query(query, taskID)
 	if err != nil {
 		return nil, err
 	}
@@ -370,8 +496,16 @@ func (db *DB) GetLabels(taskID string) ([]string, error) {
 
 // GetAllLabels returns a map of task ID to labels for all tasks
 func (db *DB) GetAllLabels() (map[string][]string, error) {
-	query := `SELECT task_id, label FROM task_labels ORDER BY task_id, label`
-	rows, err := db.conn.Query(query)
+	return db.queryLabels(`SELECT task_id, label FROM task_labels ORDER BY task_id, label`)
+}
+
+// GetLabelsOf returns a map of task ID to labels for the given tasks
+func (db *DB) GetLabelsOf(ids []string) (map[string][]string, error) {
+	return db.queryLabels(`SELECT task_id, label FROM task_labels WHERE task_id IN `+idList+` ORDER BY task_id, label`, idArg(ids))
+}
+
+func (db *DB) queryLabels(query string, args ...any) (map[string][]string, error) {
+	rows, err := db.q.Query(query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -391,6 +525,6 @@ func (db *DB) GetAllLabels() (map[string][]string, error) {
 // RemoveAllLabels removes all labels for a task
 func (db *DB) RemoveAllLabels(taskID string) error {
 	query := `DELETE FROM task_labels WHERE task_id = ?`
-	_, err := db.conn.Exec(query, taskID)
+	_, err := db.q.Exec(query, taskID)
 	return err
 }
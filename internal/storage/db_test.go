@@ -0,0 +1,54 @@
+package storage
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+var errTest = errors.New("test error")
+
+func TestOpenDB_UsesWAL(t *testing.T) {
+	db, err := NewDBWithPath(filepath.Join(t.TempDir(), "tasks.db"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer db.Close()
+
+	var mode string
+	if err := db.conn.QueryRow(`PRAGMA journal_mode`).Scan(&mode); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if mode != "wal" {
+		t.Errorf("expected wal journal mode, got %q", mode)
+	}
+
+	var timeout int
+	if err := db.conn.QueryRow(`PRAGMA busy_timeout`).Scan(&timeout); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if timeout == 0 {
+		t.Error("expected a busy timeout to be set")
+	}
+}
+
+func TestWithTx_RollsBackOnError(t *testing.T) {
+	db, err := NewDBWithPath(filepath.Join(t.TempDir(), "tasks.db"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer db.Close()
+
+	err = db.WithTx(func(tx *DB) error {
+		if err := tx.SetConfig("key", "value"); err != nil {
+			return err
+		}
+		return errTest
+	})
+	if err != errTest {
+		t.Fatalf("expected errTest, got %v", err)
+	}
+	if _, err := db.GetConfig("key"); err == nil {
+		t.Error("expected config write to be rolled back")
+	}
+}
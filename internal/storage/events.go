@@ -0,0 +1,59 @@
+package storage
+
+// EventRecord is a single entry in the append-only task history
+type EventRecord struct {
+	ID        int64  `json:"id"`
+	TaskID    string `json:"task_id"`
+	Action    string `json:"action"`
+	Field     string `json:"field,omitempty"`
+	OldValue  string `json:"old_value,omitempty"`
+	NewValue  string `json:"new_value,omitempty"`
+	Actor     string `json:"actor,omitempty"`
+	CreatedAt string `json:"timestamp"`
+}
+
+const eventColumns = `id, task_id, action, field, old_value, new_value, actor, created_at`
+
+// AddEvent appends an event to the task history
+func (db *DB) AddEvent(event EventRecord) error {
+	query := `INSERT INTO task_events (task_id, action, field, old_value, new_value, actor, created_at) VALUES (?, ?, ?, ?, ?, ?, ?)`
+	_, err := db.q.Exec(query, event.TaskID, event.Action, event.Field, event.OldValue, event.NewValue, event.Actor, event.CreatedAt)
+	return err
+}
+
+// GetEvents returns the history of a single task, oldest first
+func (db *DB) GetEvents(taskID string) ([]EventRecord, error) {
+	query := `SELECT ` + eventColumns + ` FROM task_events WHERE task_id = ? ORDER BY id`
+	return db.queryEvents(query, taskID)
+}
+
+// GetEventsSince returns events recorded at or after the given timestamp,
+// oldest first. A limit of 0 returns all matching events; otherwise only the
+// most recent limit events are returned.
+func (db *DB) GetEventsSince(since string, limit int) ([]EventRecord, error) {
+	query := `SELECT ` + eventColumns + ` FROM task_events WHERE created_at >= ? ORDER BY id`
+	args := []any{since}
+	if limit > 0 {
+		query = `SELECT * FROM (SELECT ` + eventColumns + ` FROM task_events WHERE created_at >= ? ORDER BY id DESC LIMIT ?) ORDER BY id`
+		args = append(args, limit)
+	}
+	return db.queryEvents(query, args...)
+}
+
+func (db *DB) queryEvents(query string, args ...any) ([]EventRecord, error) {
+	rows, err := db.q.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []EventRecord
+	for rows.Next() {
+		var e EventRecord
+		if err := rows.Scan(&e.ID, &e.TaskID, &e.Action, &e.Field, &e.OldValue, &e.NewValue, &e.Actor, &e.CreatedAt); err != nil {
+			return nil, err
+		}
+		events = append(events, e)
+	}
+	return events, rows.Err()
+}
@@ -0,0 +1,78 @@
+package storage
+
+// FieldRecord is a custom field value on a task. Value holds the canonical
+// text form of the value; Type says how to interpret it.
+type FieldRecord struct {
+	Key   string `json:"key"`
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+// SetTaskField adds or replaces a custom field on a task
+func (db *DB) SetTaskField(taskID string, f FieldRecord) error {
+	query := `INSERT INTO task_fields (task_id, key, type, value) VALUES (?, ?, ?, ?)
+		ON CONFLICT (task_id, key) DO UPDATE SET type = excluded.type, value = excluded.value`
+	_, err := db.q.Exec(query, taskID, f.Key, f.Type, f.Value)
+	return err
+}
+
+// RemoveTaskField removes a custom field from a task
+func (db *DB) RemoveTaskField(taskID, key string) error {
+	_, err := db.q.Exec(`DELETE FROM task_fields WHERE task_id = ? AND key = ?`, taskID, key)
+	return err
+}
+
+// GetTaskFields returns the custom fields of a task, by key
+func (db *DB) GetTaskFields(taskID string) ([]FieldRecord, error) {
+	rows, err := db.q.Query(`SELECT key, type, value FROM task_fields WHERE task_id = ? ORDER BY key`, taskID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var fields []FieldRecord
+	for rows.Next() {
+		var f FieldRecord
+		if err := rows.Scan(&f.Key, &f.Type, &f.Value); err != nil {
+			return nil, err
+		}
+		fields = append(fields, f)
+	}
+	return fields, rows.Err()
+}
+
+// GetAllTaskFields returns a map of task ID to custom fields for all tasks
+func (db *DB) GetAllTaskFields() (map[string][]FieldRecord, error) {
+	return db.queryTaskFields(`SELECT task_id, key, type, value FROM task_fields ORDER BY task_id, key`)
+}
+
+// GetTaskFieldsOf returns a map of task ID to custom fields for the given
+// tasks
+func (db *DB) GetTaskFieldsOf(ids []string) (map[string][]FieldRecord, error) {
+	return db.queryTaskFields(`SELECT task_id, key, type, value FROM task_fields WHERE task_id IN `+idList+` ORDER BY task_id, key`, idArg(ids))
+}
+
+func (db *DB) queryTaskFields(query string, args ...any) (map[string][]FieldRecord, error) {
+	rows, err := db.q.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	fields := make(map[string][]FieldRecord)
+	for rows.Next() {
+		var taskID string
+		var f FieldRecord
+		if err := rows.Scan(&taskID, &f.Key, &f.Type, &f.Value); err != nil {
+			return nil, err
+		}
+		fields[taskID] = append(fields[taskID], f)
+	}
+	return fields, rows.Err()
+}
+
+// RemoveAllTaskFields removes every custom field of a task
+func (db *DB) RemoveAllTaskFields(taskID string) error {
+	_, err := db.q.Exec(`DELETE FROM task_fields WHERE task_id = ?`, taskID)
+	return err
+}
@@ -0,0 +1,118 @@
+package storage
+
+// Operation states in the undo journal
+const (
+	OperationDone   = "done"
+	OperationUndone = "undone"
+)
+
+// OperationRecord is a single user-level mutation in the undo journal
+type OperationRecord struct {
+	ID        int64  `json:"id"`
+	Command   string `json:"command"`
+	Actor     string `json:"actor,omitempty"`
+	CreatedAt string `json:"timestamp"`
+	State     string `json:"state"`
+}
+
+// OperationTaskRecord holds the state of one task before and after an
+// operation. An empty snapshot means the task did not exist.
+type OperationTaskRecord struct {
+	OperationID int64
+	TaskID      string
+	Before      string
+	After       string
+}
+
+// CreateOperation starts a new journal entry. Undone operations are discarded,
+// since a new change invalidates anything that could have been redone.
+func (db *DB) CreateOperation(command, actor, createdAt string) (int64, error) {
+	if err := db.deleteOperations(`state = ?`, OperationUndone); err != nil {
+		return 0, err
+	}
+
+	query := `INSERT INTO operations (command, actor, created_at, state) VALUES (?, ?, ?, ?)`
+	result, err := db.q.Exec(query, command, actor, createdAt, OperationDone)
+	if err != nil {
+		return 0, err
+	}
+	return result.LastInsertId()
+}
+
+// PruneOperations keeps only the most recent keep operations
+func (db *DB) PruneOperations(keep int) error {
+	return db.deleteOperations(`id NOT IN (SELECT id FROM operations ORDER BY id DESC LIMIT ?)`, keep)
+}
+
+// deleteOperations removes operations matching a condition along with their task snapshots
+func (db *DB) deleteOperations(where string, args ...any) error {
+	query := `DELETE FROM operation_tasks WHERE operation_id IN (SELECT id FROM operations WHERE ` + where + `)`
+	if _, err := db.q.Exec(query, args...); err != nil {
+		return err
+	}
+	_, err := db.q.Exec(`DELETE FROM operations WHERE `+where, args...)
+	return err
+}
+
+// AddOperationTask records the state of a task before an operation first touches it.
+// Later calls for the same task are ignored so the earliest state is kept.
+func (db *DB) AddOperationTask(operationID int64, taskID, before string) error {
+	query := `INSERT OR IGNORE INTO operation_tasks (operation_id, task_id, before, after) VALUES (?, ?, ?, ?)`
+	_, err := db.q.Exec(query, operationID, taskID, before, before)
+	return err
+}
+
+// SetOperationTaskAfter records the state of a task after an operation changed it
+func (db *DB) SetOperationTaskAfter(operationID int64, taskID, after string) error {
+	query := `UPDATE operation_tasks SET after = ? WHERE operation_id = ? AND task_id = ?`
+	_, err := db.q.Exec(query, after, operationID, taskID)
+	return err
+}
+
+// GetOperationTasks returns the task snapshots recorded for an operation
+func (db *DB) GetOperationTasks(operationID int64) ([]OperationTaskRecord, error) {
+	query := `SELECT operation_id, task_id, before, after FROM operation_tasks WHERE operation_id = ? ORDER BY rowid`
+	rows, err := db.q.Query(query, operationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var records []OperationTaskRecord
+	for rows.Next() {
+		var r OperationTaskRecord
+		if err := rows.Scan(&r.OperationID, &r.TaskID, &r.Before, &r.After); err != nil {
+			return nil, err
+		}
+		records = append(records, r)
+	}
+	return records, rows.Err()
+}
+
+// LatestDoneOperation returns the most recent operation that can be undone
+func (db *DB) LatestDoneOperation() (*OperationRecord, error) {
+	query := `SELECT id, command, actor, created_at, state FROM operations WHERE state = ? ORDER BY id DESC LIMIT 1`
+	return db.scanOperation(query, OperationDone)
+}
+
+// EarliestUndoneOperation returns the next operation that can be redone
+func (db *DB) EarliestUndoneOperation() (*OperationRecord, error) {
+	query := `SELECT id, command, actor, created_at, state FROM operations WHERE state = ? ORDER BY id ASC LIMIT 1`
+	return db.scanOperation(query, OperationUndone)
+}
+
+func (db *DB) scanOperation(query string, args ...any) (*OperationRecord, error) {
+	var op OperationRecord
+	err := db.q.QueryRow(query, args...).Scan(&op.ID, &op.Command, &op.Actor, &op.CreatedAt, &op.State)
+	if err != nil {
+		return nil, err
+	}
+	return &op, nil
+}
+
+// SetOperationState marks an operation as done or undone
+func (db *DB) SetOperationState(operationID int64, state string) error {
+	query := `UPDATE operations SET state = ? WHERE id = ?`
+	_, err := db.q.Exec(query, state, operationID)
+	return err
+}
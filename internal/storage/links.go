@@ -0,0 +1,88 @@
+package storage
+
+// LinkRecord is a typed link on a task: a URL or a repo-relative code
+// reference such as internal/task/service.go:120-160
+type LinkRecord struct {
+	ID     int64  `json:"id"`
+	TaskID string `json:"task_id"`
+	Kind   string `json:"kind"`
+	Title  string `json:"title,omitempty"`
+	Target string `json:"target"`
+}
+
+const linkColumns = `id, task_id, kind, title, target`
+
+// AddLink inserts a link and returns its ID. A zero ID is assigned
+// automatically; a non-zero ID is kept, which is how undo restores links.
+func (db *DB) AddLink(link LinkRecord) (int64, error) {
+	var id any
+	if link.ID != 0 {
+		id = link.ID
+	}
+	query := `INSERT INTO task_links (id, task_id, kind, title, target) VALUES (?, ?, ?, ?, ?)`
+	result, err := db.q.Exec(query, id, link.TaskID, link.Kind, link.Title, link.Target)
+	if err != nil {
+		return 0, err
+	}
+	return result.LastInsertId()
+}
+
+// GetLink returns a single link by ID
+func (db *DB) GetLink(id int64) (*LinkRecord, error) {
+	query := `SELECT ` + linkColumns + ` FROM task_links WHERE id = ?`
+	var l LinkRecord
+	err := db.q.QueryRow(query, id).Scan(&l.ID, &l.TaskID, &l.Kind, &l.Title, &l.Target)
+	if err != nil {
+		return nil, err
+	}
+	return &l, nil
+}
+
+// GetLinks returns the links of a task, in the order they were added
+func (db *DB) GetLinks(taskID string) ([]LinkRecord, error) {
+	all, err := db.queryLinks(`SELECT `+linkColumns+` FROM task_links WHERE task_id = ? ORDER BY id`, taskID)
+	if err != nil {
+		return nil, err
+	}
+	return all[taskID], nil
+}
+
+// GetAllLinks returns the links of every task, keyed by task ID
+func (db *DB) GetAllLinks() (map[string][]LinkRecord, error) {
+	return db.queryLinks(`SELECT ` + linkColumns + ` FROM task_links ORDER BY task_id, id`)
+}
+
+// GetLinksOf returns the links of the given tasks, keyed by task ID
+func (db *DB) GetLinksOf(ids []string) (map[string][]LinkRecord, error) {
+	return db.queryLinks(`SELECT `+linkColumns+` FROM task_links WHERE task_id IN `+idList+` ORDER BY task_id, id`, idArg(ids))
+}
+
+func (db *DB) queryLinks(query string, args ...any) (map[string][]LinkRecord, error) {
+	rows, err := db.q.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	links := make(map[string][]LinkRecord)
+	for rows.Next() {
+		var l LinkRecord
+		if err := rows.Scan(&l.ID, &l.TaskID, &l.Kind, &l.Title, &l.Target); err != nil {
+			return nil, err
+		}
+		links[l.TaskID] = append(links[l.TaskID], l)
+	}
+	return links, rows.Err()
+}
+
+// DeleteLink removes a single link
+func (db *DB) DeleteLink(id int64) error {
+	_, err := db.q.Exec(`DELETE FROM task_links WHERE id = ?`, id)
+	return err
+}
+
+// RemoveAllLinks removes every link of a task
+func (db *DB) RemoveAllLinks(taskID string) error {
+	_, err := db.q.Exec(`DELETE FROM task_links WHERE task_id = ?`, taskID)
+	return err
+}
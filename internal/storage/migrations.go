@@ -0,0 +1,625 @@
+package storage
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrSchemaTooNew is returned when a database was written by a newer pace
+var ErrSchemaTooNew = errors.New("database schema is newer than this version of pace supports")
+
+// migration is a single numbered schema change applied inside a transaction
+type migration struct {
+	version     int
+	description string
+	up          func(tx *sql.Tx) error
+}
+
+// migrations is the ordered list of schema changes. Versions must be
+// sequential starting at 1, and released migrations must never be edited.
+var migrations = []migration{
+	{1, "initial schema", migrateInitialSchema},
+	{2, "task timestamps", migrateTaskTimestamps},
+	{3, "task event history", migrateTaskEvents},
+	{4, "operation journal", migrateOperationJournal},
+	{5, "task archive", migrateTaskArchive},
+	{6, "task comments", migrateTaskComments},
+	{7, "task hierarchy", migrateTaskHierarchy},
+	{8, "named task statuses", migrateStatusNames},
+	{9, "task types", migrateTaskTypes},
+	{10, "task due dates", migrateTaskDueDates},
+	{11, "task estimates", migrateTaskEstimates},
+	{12, "task assignees", migrateTaskAssignees},
+	{13, "task custom fields", migrateTaskFields},
+	{14, "task links", migrateTaskLinks},
+	{15, "task relations", migrateTaskRelations},
+	{16, "task search index", migrateTaskSearch},
+	{17, "saved views", migrateViews},
+	{18, "internal sequences", migrateSequences},
+}
+
+// MigrationInfo describes a migration for status reporting
+type MigrationInfo struct {
+	Version     int    `json:"version"`
+	Description string `json:"description"`
+	AppliedAt   string `json:"applied_at,omitempty"`
+}
+
+// MigrationStatus reports the schema version of a database
+type MigrationStatus struct {
+	Current int             `json:"current"`
+	Latest  int             `json:"latest"`
+	Applied []MigrationInfo `json:"applied"`
+	Pending []MigrationInfo `json:"pending"`
+}
+
+// LatestSchemaVersion returns the schema version this build of pace writes
+func LatestSchemaVersion() int {
+	return migrations[len(migrations)-1].version
+}
+
+// ensureSchemaVersionTable creates the table that records applied migrations
+func (db *DB) ensureSchemaVersionTable() error {
+	query := `
+		CREATE TABLE IF NOT EXISTS schema_version (
+			version INTEGER PRIMARY KEY,
+			description VARCHAR NOT NULL,
+			applied_at VARCHAR NOT NULL
+		);
+	`
+	_, err := db.conn.Exec(query)
+	return err
+}
+
+// SchemaVersion returns the highest migration version applied to the database
+func (db *DB) SchemaVersion() (int, error) {
+	if err := db.ensureSchemaVersionTable(); err != nil {
+		return 0, err
+	}
+	var version int
+	err := db.conn.QueryRow(`SELECT COALESCE(MAX(version), 0) FROM schema_version`).Scan(&version)
+	return version, err
+}
+
+// checkSchemaVersion refuses databases written by a newer pace
+func checkSchemaVersion(version int) error {
+	if latest := LatestSchemaVersion(); version > latest {
+		return fmt.Errorf("%w: database is at version %d, this pace supports up to %d (upgrade pace to use this store)", ErrSchemaTooNew, version, latest)
+	}
+	return nil
+}
+
+// Migrate applies all pending migrations in order, each in its own transaction.
+// Returns the migrations that were applied.
+func (db *DB) Migrate() ([]MigrationInfo, error) {
+	current, err := db.SchemaVersion()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read schema version: %w", err)
+	}
+	if err := checkSchemaVersion(current); err != nil {
+		return nil, err
+	}
+
+	var applied []MigrationInfo
+	for _, m := range migrations {
+		if m.version <= current {
+			continue
+		}
+		info, err := db.applyMigration(m)
+		if err != nil {
+			return applied, fmt.Errorf("migration %d (%s) failed: %w", m.version, m.description, err)
+		}
+		if info != nil {
+			applied = append(applied, *info)
+		}
+	}
+	return applied, nil
+}
+
+// applyMigration runs a single migration and records it. Returns nil info if
+// another process applied the same migration first.
+func (db *DB) applyMigration(m migration) (*MigrationInfo, error) {
+	tx, err := db.begin()
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	// Re-check inside the transaction in case another process got here first
+	var exists int
+	if err := tx.QueryRow(`SELECT COUNT(*) FROM schema_version WHERE version = ?`, m.version).Scan(&exists); err != nil {
+		return nil, err
+	}
+	if exists > 0 {
+		return nil, nil
+	}
+
+	if err := m.up(tx); err != nil {
+		return nil, err
+	}
+
+	appliedAt := time.Now().UTC().Format(time.RFC3339)
+	if _, err := tx.Exec(`INSERT INTO schema_version (version, description, applied_at) VALUES (?, ?, ?)`, m.version, m.description, appliedAt); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return &MigrationInfo{Version: m.version, Description: m.description, AppliedAt: appliedAt}, nil
+}
+
+// MigrationStatus returns the applied and pending migrations for the
+// database. It only reads: a store with no schema_version table, which no
+// migration has touched yet, is at version 0 with every migration pending.
+func (db *DB) MigrationStatus() (MigrationStatus, error) {
+	status := MigrationStatus{Latest: LatestSchemaVersion()}
+
+	var tracked bool
+	query := `SELECT EXISTS (SELECT 1 FROM sqlite_master WHERE type = 'table' AND name = 'schema_version')`
+	if err := db.conn.QueryRow(query).Scan(&tracked); err != nil {
+		return status, err
+	}
+	if !tracked {
+		for _, m := range migrations {
+			status.Pending = append(status.Pending, MigrationInfo{Version: m.version, Description: m.description})
+		}
+		return status, nil
+	}
+
+	rows, err := db.conn.Query(`SELECT version, description, applied_at FROM schema_version ORDER BY version`)
+	if err != nil {
+		return status, err
+	}
+	defer rows.Close()
+
+	appliedVersions := make(map[int]bool)
+	for rows.Next() {
+		var info MigrationInfo
+		if err := rows.Scan(&info.Version, &info.Description, &info.AppliedAt); err != nil {
+			return status, err
+		}
+		appliedVersions[info.Version] = true
+		status.Applied = append(status.Applied, info)
+		if info.Version > status.Current {
+			status.Current = info.Version
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return status, err
+	}
+
+	for _, m := range migrations {
+		if !appliedVersions[m.version] {
+			status.Pending = append(status.Pending, MigrationInfo{Version: m.version, Description: m.description})
+		}
+	}
+
+	return status, nil
+}
+
+// columnExists reports whether a table has a column with the given name
+func columnExists(tx *sql.Tx, table, column string) (bool, error) {
+	rows, err := tx.Query(fmt.Sprintf(`PRAGMA table_info(%s)`, table))
+	if err != nil {
+		return false, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			cid       int
+			name      string
+			colType   string
+			notNull   int
+			dfltValue sql.NullString
+			pk        int
+		)
+		if err := rows.Scan(&cid, &name, &colType, &notNull, &dfltValue, &pk); err != nil {
+			return false, err
+		}
+		if name == column {
+			return true, nil
+		}
+	}
+	return false, rows.Err()
+}
+
+// addColumnIfMissing adds a column unless it already exists
+func addColumnIfMissing(tx *sql.Tx, table, column, definition string) error {
+	exists, err := columnExists(tx, table, column)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return nil
+	}
+	_, err = tx.Exec(fmt.Sprintf(`ALTER TABLE %s ADD COLUMN %s %s`, table, column, definition))
+	return err
+}
+
+// migrateInitialSchema creates the base tables. Stores created before schema
+// versioning may already have some of these, possibly without the columns
+// that were added later, so every step tolerates existing objects.
+func migrateInitialSchema(tx *sql.Tx) error {
+	tasksQuery := `
+		CREATE TABLE IF NOT EXISTS tasks (
+			id VARCHAR PRIMARY KEY,
+			title VARCHAR NOT NULL,
+			description VARCHAR,
+			status INTEGER NOT NULL,
+			priority INTEGER NOT NULL DEFAULT 0
+		);
+	`
+	if _, err := tx.Exec(tasksQuery); err != nil {
+		return err
+	}
+
+	if err := addColumnIfMissing(tx, "tasks", "priority", "INTEGER NOT NULL DEFAULT 0"); err != nil {
+		return err
+	}
+	// 0 = task, 1 = bug, 2 = feature, 3 = chore, 4 = docs
+	if err := addColumnIfMissing(tx, "tasks", "task_type", "INTEGER NOT NULL DEFAULT 0"); err != nil {
+		return err
+	}
+	if err := addColumnIfMissing(tx, "tasks", "link", "VARCHAR DEFAULT ''"); err != nil {
+		return err
+	}
+
+	// Blocking relationships between tasks
+	depQuery := `
+		CREATE TABLE IF NOT EXISTS task_dependencies (
+			blocker_id VARCHAR NOT NULL,
+			blocked_id VARCHAR NOT NULL,
+			PRIMARY KEY (blocker_id, blocked_id),
+			FOREIGN KEY (blocker_id) REFERENCES tasks(id) ON DELETE CASCADE,
+			FOREIGN KEY (blocked_id) REFERENCES tasks(id) ON DELETE CASCADE
+		);
+	`
+	if _, err := tx.Exec(depQuery); err != nil {
+		return err
+	}
+
+	// Settings like id_prefix
+	configQuery := `
+		CREATE TABLE IF NOT EXISTS config (
+			key VARCHAR PRIMARY KEY,
+			value VARCHAR NOT NULL
+		);
+	`
+	if _, err := tx.Exec(configQuery); err != nil {
+		return err
+	}
+
+	// Label associations
+	labelsQuery := `
+		CREATE TABLE IF NOT EXISTS task_labels (
+			task_id VARCHAR NOT NULL,
+			label VARCHAR NOT NULL,
+			PRIMARY KEY (task_id, label),
+			FOREIGN KEY (task_id) REFERENCES tasks(id) ON DELETE CASCADE
+		);
+	`
+	_, err := tx.Exec(labelsQuery)
+	return err
+}
+
+// migrateTaskTimestamps adds created/updated/completed times to tasks. The
+// real times of existing rows are unknown, so they are backfilled with the
+// migration time, and done tasks are marked completed at that time.
+func migrateTaskTimestamps(tx *sql.Tx) error {
+	for _, column := range []string{"created_at", "updated_at", "completed_at"} {
+		if err := addColumnIfMissing(tx, "tasks", column, "VARCHAR"); err != nil {
+			return err
+		}
+	}
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	if _, err := tx.Exec(`UPDATE tasks SET created_at = ? WHERE created_at IS NULL OR created_at = ''`, now); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(`UPDATE tasks SET updated_at = created_at WHERE updated_at IS NULL OR updated_at = ''`); err != nil {
+		return err
+	}
+	// Status 2 is done
+	_, err := tx.Exec(`UPDATE tasks SET completed_at = updated_at WHERE status = 2 AND completed_at IS NULL`)
+	return err
+}
+
+// migrateTaskEvents creates the append-only task history. Events have no
+// foreign key so that history survives task deletion.
+func migrateTaskEvents(tx *sql.Tx) error {
+	query := `
+		CREATE TABLE IF NOT EXISTS task_events (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			task_id VARCHAR NOT NULL,
+			action VARCHAR NOT NULL,
+			field VARCHAR NOT NULL DEFAULT '',
+			old_value VARCHAR NOT NULL DEFAULT '',
+			new_value VARCHAR NOT NULL DEFAULT '',
+			actor VARCHAR NOT NULL DEFAULT '',
+			created_at VARCHAR NOT NULL
+		);
+		CREATE INDEX IF NOT EXISTS idx_task_events_task ON task_events (task_id, id);
+		CREATE INDEX IF NOT EXISTS idx_task_events_created ON task_events (created_at);
+	`
+	_, err := tx.Exec(query)
+	return err
+}
+
+// migrateOperationJournal creates the journal used by undo and redo. Each
+// operation stores before and after snapshots of every task it touched.
+func migrateOperationJournal(tx *sql.Tx) error {
+	query := `
+		CREATE TABLE IF NOT EXISTS operations (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			command VARCHAR NOT NULL,
+			actor VARCHAR NOT NULL DEFAULT '',
+			created_at VARCHAR NOT NULL,
+			state VARCHAR NOT NULL DEFAULT 'done'
+		);
+		CREATE TABLE IF NOT EXISTS operation_tasks (
+			operation_id INTEGER NOT NULL,
+			task_id VARCHAR NOT NULL,
+			before VARCHAR NOT NULL DEFAULT '',
+			after VARCHAR NOT NULL DEFAULT '',
+			PRIMARY KEY (operation_id, task_id),
+			FOREIGN KEY (operation_id) REFERENCES operations(id) ON DELETE CASCADE
+		);
+	`
+	_, err := tx.Exec(query)
+	return err
+}
+
+// migrateTaskArchive adds the archived time to tasks. Archived tasks keep
+// their row, labels and dependencies and are hidden from listings.
+func migrateTaskArchive(tx *sql.Tx) error {
+	return addColumnIfMissing(tx, "tasks", "archived_at", "VARCHAR")
+}
+
+// migrateTaskComments creates the comment log attached to tasks
+func migrateTaskComments(tx *sql.Tx) error {
+	query := `
+		CREATE TABLE IF NOT EXISTS task_comments (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			task_id VARCHAR NOT NULL,
+			author VARCHAR NOT NULL DEFAULT '',
+			body VARCHAR NOT NULL,
+			created_at VARCHAR NOT NULL,
+			FOREIGN KEY (task_id) REFERENCES tasks(id) ON DELETE CASCADE
+		);
+		CREATE INDEX IF NOT EXISTS idx_task_comments_task ON task_comments (task_id, id);
+	`
+	_, err := tx.Exec(query)
+	return err
+}
+
+// migrateTaskHierarchy adds the parent task, used for epics and subtasks
+func migrateTaskHierarchy(tx *sql.Tx) error {
+	if err := addColumnIfMissing(tx, "tasks", "parent_id", "VARCHAR"); err != nil {
+		return err
+	}
+	_, err := tx.Exec(`CREATE INDEX IF NOT EXISTS idx_tasks_parent ON tasks (parent_id)`)
+	return err
+}
+
+// legacyStatusNames maps the numeric statuses stored before migration 8
+const legacyStatusNames = `CASE %[1]s WHEN 0 THEN 'todo' WHEN 1 THEN 'in-progress' WHEN 2 THEN 'done' ELSE CAST(%[1]s AS TEXT) END`
+
+// migrateStatusNames stores task statuses by name so that stores can define
+// their own workflow. SQLite cannot change a column's type, so the tasks
+// table is rebuilt; foreign keys are not enforced on our connections, so
+// dropping the old table leaves labels, dependencies and comments intact.
+// Journal snapshots are converted too so that older operations can still
+// be undone.
+func migrateStatusNames(tx *sql.Tx) error {
+	query := fmt.Sprintf(`
+		CREATE TABLE tasks_new (
+			id VARCHAR PRIMARY KEY,
+			title VARCHAR NOT NULL,
+			description VARCHAR,
+			status VARCHAR NOT NULL,
+			priority INTEGER NOT NULL DEFAULT 0,
+			task_type INTEGER NOT NULL DEFAULT 0,
+			link VARCHAR DEFAULT '',
+			created_at VARCHAR,
+			updated_at VARCHAR,
+			completed_at VARCHAR,
+			archived_at VARCHAR,
+			parent_id VARCHAR
+		);
+		INSERT INTO tasks_new (id, title, description, status, priority, task_type, link, created_at, updated_at, completed_at, archived_at, parent_id)
+			SELECT id, title, description, %s, priority, task_type, link, created_at, updated_at, completed_at, archived_at, parent_id FROM tasks;
+		DROP TABLE tasks;
+		ALTER TABLE tasks_new RENAME TO tasks;
+		CREATE INDEX IF NOT EXISTS idx_tasks_parent ON tasks (parent_id);
+	`, fmt.Sprintf(legacyStatusNames, "status"))
+	if _, err := tx.Exec(query); err != nil {
+		return err
+	}
+
+	for _, column := range []string{"before", "after"} {
+		status := fmt.Sprintf(`json_extract(%s, '$.task.status')`, column)
+		query := fmt.Sprintf(`UPDATE operation_tasks SET %[1]s = json_set(%[1]s, '$.task.status', %[2]s) WHERE %[1]s != ''`,
+			column, fmt.Sprintf(legacyStatusNames, status))
+		if _, err := tx.Exec(query); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// migrateTaskTypes creates the registry of user-defined task types. Tasks
+// keep storing a type code; codes below FirstCustomTypeCode are built-in.
+func migrateTaskTypes(tx *sql.Tx) error {
+	query := `
+		CREATE TABLE IF NOT EXISTS task_types (
+			code INTEGER PRIMARY KEY,
+			name VARCHAR NOT NULL UNIQUE,
+			symbol VARCHAR NOT NULL,
+			color VARCHAR NOT NULL DEFAULT ''
+		);
+	`
+	_, err := tx.Exec(query)
+	return err
+}
+
+// migrateTaskDueDates adds the due date of a task, stored as YYYY-MM-DD
+func migrateTaskDueDates(tx *sql.Tx) error {
+	if err := addColumnIfMissing(tx, "tasks", "due_date", "VARCHAR"); err != nil {
+		return err
+	}
+	_, err := tx.Exec(`CREATE INDEX IF NOT EXISTS idx_tasks_due ON tasks (due_date)`)
+	return err
+}
+
+// migrateTaskEstimates adds the estimate of a task; 0 means unestimated
+func migrateTaskEstimates(tx *sql.Tx) error {
+	return addColumnIfMissing(tx, "tasks", "estimate", "REAL NOT NULL DEFAULT 0")
+}
+
+// migrateTaskAssignees adds the person or agent a task is assigned to
+func migrateTaskAssignees(tx *sql.Tx) error {
+	if err := addColumnIfMissing(tx, "tasks", "assignee", "VARCHAR"); err != nil {
+		return err
+	}
+	_, err := tx.Exec(`CREATE INDEX IF NOT EXISTS idx_tasks_assignee ON tasks (assignee)`)
+	return err
+}
+
+// migrateTaskFields creates the typed key/value custom fields of tasks
+func migrateTaskFields(tx *sql.Tx) error {
+	query := `
+		CREATE TABLE IF NOT EXISTS task_fields (
+			task_id VARCHAR NOT NULL,
+			key VARCHAR NOT NULL,
+			type VARCHAR NOT NULL,
+			value VARCHAR NOT NULL,
+			PRIMARY KEY (task_id, key),
+			FOREIGN KEY (task_id) REFERENCES tasks(id) ON DELETE CASCADE
+		);
+		CREATE INDEX IF NOT EXISTS idx_task_fields_key ON task_fields (key, value);
+	`
+	_, err := tx.Exec(query)
+	return err
+}
+
+// migrateTaskLinks creates the typed links and code references of tasks
+func migrateTaskLinks(tx *sql.Tx) error {
+	query := `
+		CREATE TABLE IF NOT EXISTS task_links (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			task_id VARCHAR NOT NULL,
+			kind VARCHAR NOT NULL,
+			title VARCHAR NOT NULL DEFAULT '',
+			target VARCHAR NOT NULL,
+			FOREIGN KEY (task_id) REFERENCES tasks(id) ON DELETE CASCADE
+		);
+		CREATE INDEX IF NOT EXISTS idx_task_links_task ON task_links (task_id, id);
+	`
+	_, err := tx.Exec(query)
+	return err
+}
+
+// migrateTaskRelations creates the non-blocking relationships between tasks
+func migrateTaskRelations(tx *sql.Tx) error {
+	query := `
+		CREATE TABLE IF NOT EXISTS task_relations (
+			task_id VARCHAR NOT NULL,
+			kind VARCHAR NOT NULL,
+			related_id VARCHAR NOT NULL,
+			PRIMARY KEY (task_id, kind, related_id),
+			FOREIGN KEY (task_id) REFERENCES tasks(id) ON DELETE CASCADE,
+			FOREIGN KEY (related_id) REFERENCES tasks(id) ON DELETE CASCADE
+		);
+		CREATE INDEX IF NOT EXISTS idx_task_relations_related ON task_relations (related_id);
+	`
+	_, err := tx.Exec(query)
+	return err
+}
+
+// searchDocument selects the indexed text of the task with the given id
+// expression: its title, description, labels and comment bodies
+const searchDocument = `
+	SELECT t.id, t.title, COALESCE(t.description, ''),
+		COALESCE((SELECT group_concat(label, ' ') FROM task_labels WHERE task_id = t.id), ''),
+		COALESCE((SELECT group_concat(body, char(10)) FROM task_comments WHERE task_id = t.id), '')
+	FROM tasks t`
+
+// migrateTaskSearch creates the full-text index over tasks and the triggers
+// that keep it in sync. Any write to a task, its labels or its comments
+// re-indexes that task, so every code path (including undo and migrate)
+// stays searchable without calling into the index.
+func migrateTaskSearch(tx *sql.Tx) error {
+	query := `
+		CREATE VIRTUAL TABLE IF NOT EXISTS task_search USING fts5(
+			task_id UNINDEXED, title, description, labels, comments,
+			tokenize = 'unicode61 remove_diacritics 2'
+		);
+	`
+	if _, err := tx.Exec(query); err != nil {
+		return err
+	}
+
+	reindex := func(id string) string {
+		return `DELETE FROM task_search WHERE task_id = ` + id + `;
+			INSERT INTO task_search (task_id, title, description, labels, comments) ` + searchDocument + ` WHERE t.id = ` + id + `;`
+	}
+	triggers := []struct{ name, event, id string }{
+		{"task_search_insert", "AFTER INSERT ON tasks", "NEW.id"},
+		{"task_search_update", "AFTER UPDATE OF title, description ON tasks", "NEW.id"},
+		{"task_search_delete", "AFTER DELETE ON tasks", "OLD.id"},
+		{"task_search_label_insert", "AFTER INSERT ON task_labels", "NEW.task_id"},
+		{"task_search_label_delete", "AFTER DELETE ON task_labels", "OLD.task_id"},
+		{"task_search_comment_insert", "AFTER INSERT ON task_comments", "NEW.task_id"},
+		{"task_search_comment_update", "AFTER UPDATE OF body ON task_comments", "NEW.task_id"},
+		{"task_search_comment_delete", "AFTER DELETE ON task_comments", "OLD.task_id"},
+	}
+	for _, t := range triggers {
+		query := `CREATE TRIGGER IF NOT EXISTS ` + t.name + ` ` + t.event + ` BEGIN ` + reindex(t.id) + ` END`
+		if _, err := tx.Exec(query); err != nil {
+			return err
+		}
+	}
+
+	// Index the tasks that already exist
+	if _, err := tx.Exec(`DELETE FROM task_search`); err != nil {
+		return err
+	}
+	_, err := tx.Exec(`INSERT INTO task_search (task_id, title, description, labels, comments) ` + searchDocument)
+	return err
+}
+
+// migrateViews creates the saved views: named filter queries and a sort
+func migrateViews(tx *sql.Tx) error {
+	query := `
+		CREATE TABLE IF NOT EXISTS views (
+			name VARCHAR PRIMARY KEY,
+			filters VARCHAR NOT NULL DEFAULT '[]',
+			sort VARCHAR NOT NULL DEFAULT '',
+			created_at VARCHAR NOT NULL,
+			updated_at VARCHAR NOT NULL
+		);
+	`
+	_, err := tx.Exec(query)
+	return err
+}
+
+// migrateSequences creates the counters pace keeps for itself, out of the
+// user's config, and moves the type code counter there from config
+func migrateSequences(tx *sql.Tx) error {
+	query := `
+		CREATE TABLE IF NOT EXISTS sequences (
+			name VARCHAR PRIMARY KEY,
+			value INTEGER NOT NULL
+		);
+		INSERT OR IGNORE INTO sequences (name, value)
+			SELECT 'task_type_code', CAST(value AS INTEGER) FROM config
+			WHERE key = 'type_code_sequence' AND value GLOB '[0-9]*' AND value NOT GLOB '*[^0-9]*';
+		DELETE FROM config WHERE key = 'type_code_sequence';
+	`
+	_, err := tx.Exec(query)
+	return err
+}
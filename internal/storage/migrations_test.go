@@ -0,0 +1,274 @@
+package storage
+
+import (
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+func TestMigrate_FreshDatabase(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "tasks.db")
+
+	db, err := NewDBWithPath(dbPath)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer db.Close()
+
+	version, err := db.SchemaVersion()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if version != LatestSchemaVersion() {
+		t.Errorf("expected version %d, got %d", LatestSchemaVersion(), version)
+	}
+
+	status, err := db.MigrationStatus()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(status.Pending) != 0 {
+		t.Errorf("expected no pending migrations, got %v", status.Pending)
+	}
+	if len(status.Applied) != len(migrations) {
+		t.Errorf("expected %d applied migrations, got %d", len(migrations), len(status.Applied))
+	}
+}
+
+func TestMigrationStatus_ReadOnly(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "tasks.db")
+
+	// A store no migration has run on yet
+	db, err := OpenDBWithPath(dbPath)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer db.Close()
+
+	status, err := db.MigrationStatus()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if status.Current != 0 || len(status.Applied) != 0 || len(status.Pending) != len(migrations) {
+		t.Errorf("expected version 0 with every migration pending, got %+v", status)
+	}
+
+	var tables int
+	if err := db.conn.QueryRow(`SELECT COUNT(*) FROM sqlite_master WHERE type = 'table'`).Scan(&tables); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if tables != 0 {
+		t.Errorf("expected the status check to create no tables, got %d", tables)
+	}
+}
+
+func TestMigrate_Idempotent(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "tasks.db")
+
+	db, err := NewDBWithPath(dbPath)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	db.Close()
+
+	db, err = NewDBWithPath(dbPath)
+	if err != nil {
+		t.Fatalf("unexpected error on reopen: %v", err)
+	}
+	defer db.Close()
+
+	applied, err := db.Migrate()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(applied) != 0 {
+		t.Errorf("expected no migrations to be applied, got %v", applied)
+	}
+}
+
+func TestMigrate_LegacyDatabase(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "tasks.db")
+
+	// Simulate a store created before priority, task_type and link existed
+	conn, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	_, err = conn.Exec(`CREATE TABLE tasks (id VARCHAR PRIMARY KEY, title VARCHAR NOT NULL, description VARCHAR, status INTEGER NOT NULL)`)
+	if err != nil {
+		t.Fatalf("failed to create legacy table: %v", err)
+	}
+	_, err = conn.Exec(`INSERT INTO tasks (id, title, description, status) VALUES ('old-1', 'legacy task', '', 1), ('old-2', 'finished task', '', 2)`)
+	if err != nil {
+		t.Fatalf("failed to insert legacy tasks: %v", err)
+	}
+	conn.Close()
+
+	db, err := NewDBWithPath(dbPath)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer db.Close()
+
+	task, err := db.GetTaskByID("old-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if task.Title != "legacy task" || task.Status != "in-progress" {
+		t.Errorf("legacy task not preserved: %+v", task)
+	}
+	if task.Priority != 0 || task.TaskType != 0 || task.Link != "" {
+		t.Errorf("expected default values for added columns, got %+v", task)
+	}
+	if task.CreatedAt == "" || task.UpdatedAt == "" {
+		t.Errorf("expected timestamps to be backfilled, got %+v", task)
+	}
+	if task.CompletedAt != "" {
+		t.Errorf("expected no completed_at for in-progress task, got %q", task.CompletedAt)
+	}
+
+	done, err := db.GetTaskByID("old-2")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if done.CompletedAt == "" {
+		t.Errorf("expected completed_at to be backfilled for done task, got %+v", done)
+	}
+
+	hits, err := db.SearchTasks(`"legacy"`, "[", "]", false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(hits) != 1 || hits[0].TaskID != "old-1" || hits[0].Snippet != "[legacy] task" {
+		t.Errorf("expected existing tasks to be indexed for search, got %+v", hits)
+	}
+}
+
+func TestMigrate_StatusNames(t *testing.T) {
+	db, err := OpenDBWithPath(filepath.Join(t.TempDir(), "tasks.db"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer db.Close()
+
+	// Bring the store to version 7, when statuses were still numbers
+	if err := db.ensureSchemaVersionTable(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, m := range migrations[:7] {
+		if _, err := db.applyMigration(m); err != nil {
+			t.Fatalf("migration %d failed: %v", m.version, err)
+		}
+	}
+	setup := `
+		INSERT INTO tasks (id, title, description, status, created_at, updated_at) VALUES
+			('old-1', 'todo task', '', 0, '2026-01-01T00:00:00Z', '2026-01-01T00:00:00Z'),
+			('old-2', 'done task', '', 2, '2026-01-01T00:00:00Z', '2026-01-01T00:00:00Z');
+		INSERT INTO task_labels (task_id, label) VALUES ('old-1', 'backend');
+		INSERT INTO task_dependencies (blocker_id, blocked_id) VALUES ('old-2', 'old-1');
+		INSERT INTO operations (id, command, created_at) VALUES (1, 'task update', '2026-01-01T00:00:00Z');
+		INSERT INTO operation_tasks (operation_id, task_id, before, after) VALUES
+			(1, 'old-2', '{"task":{"id":"old-2","status":1}}', '{"task":{"id":"old-2","status":2}}');
+	`
+	if _, err := db.conn.Exec(setup); err != nil {
+		t.Fatalf("failed to insert version 7 data: %v", err)
+	}
+
+	if _, err := db.Migrate(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for id, want := range map[string]string{"old-1": "todo", "old-2": "done"} {
+		task, err := db.GetTaskByID(id)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if task.Status != want {
+			t.Errorf("expected %s to have status %q, got %q", id, want, task.Status)
+		}
+	}
+	labels, err := db.GetLabels("old-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	blockers, err := db.GetBlockers("old-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(labels) != 1 || len(blockers) != 1 {
+		t.Errorf("expected labels and dependencies to survive the rebuild, got %v and %v", labels, blockers)
+	}
+
+	records, err := db.GetOperationTasks(1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(records) != 1 || records[0].Before != `{"task":{"id":"old-2","status":"in-progress"}}` || records[0].After != `{"task":{"id":"old-2","status":"done"}}` {
+		t.Errorf("expected journal statuses to be converted, got %+v", records)
+	}
+}
+
+func TestMigrate_Sequences(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "tasks.db")
+
+	// A store that kept the type code counter in config
+	db, err := NewDBWithPath(dbPath)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, query := range []string{
+		`DROP TABLE sequences`,
+		`DELETE FROM schema_version WHERE version = 18`,
+		`INSERT INTO config (key, value) VALUES ('type_code_sequence', '104')`,
+	} {
+		if _, err := db.conn.Exec(query); err != nil {
+			t.Fatalf("failed to set up store: %v", err)
+		}
+	}
+	db.Close()
+
+	db, err = NewDBWithPath(dbPath)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer db.Close()
+
+	config, err := db.GetAllConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := config["type_code_sequence"]; ok {
+		t.Errorf("expected the counter to leave config, got %v", config)
+	}
+	code, err := db.AddTaskType(TypeRecord{Name: "spike", Symbol: "s"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if code != 105 {
+		t.Errorf("expected code 105 after the moved counter, got %d", code)
+	}
+	if config, _ := db.GetAllConfig(); len(config) != 0 {
+		t.Errorf("expected adding a type to leave config alone, got %v", config)
+	}
+}
+
+func TestMigrate_RefusesNewerSchema(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "tasks.db")
+
+	db, err := NewDBWithPath(dbPath)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	future := LatestSchemaVersion() + 1
+	_, err = db.conn.Exec(`INSERT INTO schema_version (version, description, applied_at) VALUES (?, 'from the future', '2099-01-01T00:00:00Z')`, future)
+	if err != nil {
+		t.Fatalf("failed to insert future version: %v", err)
+	}
+	db.Close()
+
+	_, err = NewDBWithPath(dbPath)
+	if !errors.Is(err, ErrSchemaTooNew) {
+		t.Errorf("expected ErrSchemaTooNew, got %v", err)
+	}
+}
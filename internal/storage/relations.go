@@ -0,0 +1,59 @@
+package storage
+
+// RelationRecord is a non-blocking relationship between two tasks, read as
+// "TaskID <kind> RelatedID", e.g. pace-b2c duplicate-of pace-a1b
+type RelationRecord struct {
+	TaskID    string `json:"task_id"`
+	Kind      string `json:"kind"`
+	RelatedID string `json:"related_id"`
+}
+
+// AddRelation records a relationship between two tasks
+func (db *DB) AddRelation(r RelationRecord) error {
+	query := `INSERT OR IGNORE INTO task_relations (task_id, kind, related_id) VALUES (?, ?, ?)`
+	_, err := db.q.Exec(query, r.TaskID, r.Kind, r.RelatedID)
+	return err
+}
+
+// RemoveRelation removes a relationship between two tasks
+func (db *DB) RemoveRelation(r RelationRecord) error {
+	query := `DELETE FROM task_relations WHERE task_id = ? AND kind = ? AND related_id = ?`
+	_, err := db.q.Exec(query, r.TaskID, r.Kind, r.RelatedID)
+	return err
+}
+
+// GetRelations returns the relationships a task is on either side of
+func (db *DB) GetRelations(taskID string) ([]RelationRecord, error) {
+	query := `SELECT task_id, kind, related_id FROM task_relations
+		WHERE task_id = ? OR related_id = ? ORDER BY rowid`
+	return db.queryRelations(query, taskID, taskID)
+}
+
+// GetAllRelations returns every relationship between tasks
+func (db *DB) GetAllRelations() ([]RelationRecord, error) {
+	return db.queryRelations(`SELECT task_id, kind, related_id FROM task_relations ORDER BY rowid`)
+}
+
+func (db *DB) queryRelations(query string, args ...any) ([]RelationRecord, error) {
+	rows, err := db.q.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var relations []RelationRecord
+	for rows.Next() {
+		var r RelationRecord
+		if err := rows.Scan(&r.TaskID, &r.Kind, &r.RelatedID); err != nil {
+			return nil, err
+		}
+		relations = append(relations, r)
+	}
+	return relations, rows.Err()
+}
+
+// RemoveAllRelations removes every relationship a task is on either side of
+func (db *DB) RemoveAllRelations(taskID string) error {
+	_, err := db.q.Exec(`DELETE FROM task_relations WHERE task_id = ? OR related_id = ?`, taskID, taskID)
+	return err
+}
@@ -0,0 +1,43 @@
+package storage
+
+// SearchHit is a task matched by a full-text search
+type SearchHit struct {
+	TaskID  string
+	Snippet string
+	Rank    float64
+}
+
+// searchWeights weights bm25 ranking by column: task_id (unindexed), title,
+// description, labels and comments
+const searchWeights = `0, 10.0, 4.0, 6.0, 2.0`
+
+// SearchTasks runs an FTS5 MATCH expression against the task search index
+// and returns the hits best first. Rank is the bm25 score, where lower is
+// better. The snippet is taken from the best matching column, with matched
+// terms wrapped in start and end.
+func (db *DB) SearchTasks(match, start, end string, includeArchived bool) ([]SearchHit, error) {
+	query := `
+		SELECT s.task_id, snippet(task_search, -1, ?, ?, '…', 16), bm25(task_search, ` + searchWeights + `) AS rank
+		FROM task_search s JOIN tasks t ON t.id = s.task_id
+		WHERE task_search MATCH ?`
+	if !includeArchived {
+		query += ` AND COALESCE(t.archived_at, '') = ''`
+	}
+	query += ` ORDER BY rank, s.task_id`
+
+	rows, err := db.q.Query(query, start, end, match)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var hits []SearchHit
+	for rows.Next() {
+		var h SearchHit
+		if err := rows.Scan(&h.TaskID, &h.Snippet, &h.Rank); err != nil {
+			return nil, err
+		}
+		hits = append(hits, h)
+	}
+	return hits, rows.Err()
+}
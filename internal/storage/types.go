@@ -0,0 +1,69 @@
+package storage
+
+// TypeRecord is a user-defined task type. Built-in types are not stored.
+type TypeRecord struct {
+	Code   int    `json:"code"`
+	Name   string `json:"name"`
+	Symbol string `json:"symbol"`
+	Color  string `json:"color"`
+}
+
+// FirstCustomTypeCode is the code given to the first user-defined type.
+// Lower codes are reserved for built-in types.
+const FirstCustomTypeCode = 100
+
+// typeCodeSequence is the sequence holding the last code given to a type.
+// Codes only go up, so a removed type's code is never handed to a new
+// type: journal snapshots keep the codes of the tasks they hold.
+const typeCodeSequence = "task_type_code"
+
+// AddTaskType stores a user-defined type under the next unused code and
+// returns the code
+func (db *DB) AddTaskType(t TypeRecord) (int, error) {
+	var code int
+	query := `SELECT MAX(COALESCE(MAX(code) + 1, 0), ?, COALESCE((SELECT value + 1 FROM sequences WHERE name = ?), 0)) FROM task_types`
+	if err := db.q.QueryRow(query, FirstCustomTypeCode, typeCodeSequence).Scan(&code); err != nil {
+		return 0, err
+	}
+	query = `INSERT INTO task_types (code, name, symbol, color) VALUES (?, ?, ?, ?)`
+	if _, err := db.q.Exec(query, code, t.Name, t.Symbol, t.Color); err != nil {
+		return 0, err
+	}
+	query = `INSERT INTO sequences (name, value) VALUES (?, ?) ON CONFLICT (name) DO UPDATE SET value = excluded.value`
+	if _, err := db.q.Exec(query, typeCodeSequence, code); err != nil {
+		return 0, err
+	}
+	return code, nil
+}
+
+// GetTaskTypes returns the user-defined types in the order they were added
+func (db *DB) GetTaskTypes() ([]TypeRecord, error) {
+	rows, err := db.q.Query(`SELECT code, name, symbol, color FROM task_types ORDER BY code`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var types []TypeRecord
+	for rows.Next() {
+		var t TypeRecord
+		if err := rows.Scan(&t.Code, &t.Name, &t.Symbol, &t.Color); err != nil {
+			return nil, err
+		}
+		types = append(types, t)
+	}
+	return types, rows.Err()
+}
+
+// DeleteTaskType removes a user-defined type by code
+func (db *DB) DeleteTaskType(code int) error {
+	_, err := db.q.Exec(`DELETE FROM task_types WHERE code = ?`, code)
+	return err
+}
+
+// CountTasksWithType returns how many tasks, archived or not, have a type
+func (db *DB) CountTasksWithType(code int) (int, error) {
+	var count int
+	err := db.q.QueryRow(`SELECT COUNT(*) FROM tasks WHERE task_type = ?`, code).Scan(&count)
+	return count, err
+}
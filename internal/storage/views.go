@@ -0,0 +1,77 @@
+package storage
+
+import (
+	"database/sql"
+	"encoding/json"
+)
+
+// ViewRecord is a saved view: a named set of filter queries and a sort
+type ViewRecord struct {
+	Name      string   `json:"name"`
+	Filters   []string `json:"filters"`
+	Sort      string   `json:"sort,omitempty"`
+	CreatedAt string   `json:"created_at"`
+	UpdatedAt string   `json:"updated_at"`
+}
+
+// SaveView stores a view, replacing any view with the same name but
+// keeping its creation time
+func (db *DB) SaveView(v ViewRecord) error {
+	filters, err := json.Marshal(v.Filters)
+	if err != nil {
+		return err
+	}
+	query := `INSERT INTO views (name, filters, sort, created_at, updated_at) VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT (name) DO UPDATE SET filters = excluded.filters, sort = excluded.sort, updated_at = excluded.updated_at`
+	_, err = db.q.Exec(query, v.Name, string(filters), v.Sort, v.CreatedAt, v.UpdatedAt)
+	return err
+}
+
+// GetView returns a view by name, or sql.ErrNoRows if there is none
+func (db *DB) GetView(name string) (*ViewRecord, error) {
+	views, err := db.queryViews(`SELECT name, filters, sort, created_at, updated_at FROM views WHERE name = ?`, name)
+	if err != nil {
+		return nil, err
+	}
+	if len(views) == 0 {
+		return nil, sql.ErrNoRows
+	}
+	return &views[0], nil
+}
+
+// GetViews returns every saved view, by name
+func (db *DB) GetViews() ([]ViewRecord, error) {
+	return db.queryViews(`SELECT name, filters, sort, created_at, updated_at FROM views ORDER BY name`)
+}
+
+func (db *DB) queryViews(query string, args ...any) ([]ViewRecord, error) {
+	rows, err := db.q.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var views []ViewRecord
+	for rows.Next() {
+		var v ViewRecord
+		var filters string
+		if err := rows.Scan(&v.Name, &filters, &v.Sort, &v.CreatedAt, &v.UpdatedAt); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal([]byte(filters), &v.Filters); err != nil {
+			return nil, err
+		}
+		views = append(views, v)
+	}
+	return views, rows.Err()
+}
+
+// DeleteView removes a view and reports whether it existed
+func (db *DB) DeleteView(name string) (bool, error) {
+	result, err := db.q.Exec(`DELETE FROM views WHERE name = ?`, name)
+	if err != nil {
+		return false, err
+	}
+	n, err := result.RowsAffected()
+	return n > 0, err
+}
@@ -0,0 +1,22 @@
+package task
+
+import (
+	"os"
+	"os/user"
+	"strings"
+)
+
+// EnvActor is the environment variable that identifies who is making changes
+const EnvActor = "PACE_ACTOR"
+
+// Actor returns the identity of whoever is running pace: $PACE_ACTOR if set,
+// otherwise the OS username
+func Actor() string {
+	if actor := strings.TrimSpace(os.Getenv(EnvActor)); actor != "" {
+		return actor
+	}
+	if u, err := user.Current(); err == nil && u.Username != "" {
+		return u.Username
+	}
+	return "unknown"
+}
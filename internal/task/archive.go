@@ -0,0 +1,57 @@
+package task
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// ArchiveTask hides a task from listings without deleting it. Its labels
+// and dependencies are kept so that RestoreTask brings it back unchanged.
+func (s *Service) ArchiveTask(taskID string) error {
+	return s.Atomic(func() error { return s.setArchived(taskID, true) })
+}
+
+// RestoreTask makes an archived task visible again
+func (s *Service) RestoreTask(taskID string) error {
+	return s.Atomic(func() error { return s.setArchived(taskID, false) })
+}
+
+func (s *Service) setArchived(taskID string, archive bool) error {
+	record, err := s.db.GetTaskByID(taskID)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("%w: %s", ErrTaskNotFound, taskID)
+	}
+	if err != nil {
+		return err
+	}
+
+	archived := record.ArchivedAt != ""
+	if archive && archived {
+		return fmt.Errorf("%w: %s", ErrArchived, taskID)
+	}
+	if !archive && !archived {
+		return fmt.Errorf("%w: %s", ErrNotArchived, taskID)
+	}
+
+	done, err := s.track(taskID)
+	if err != nil {
+		return err
+	}
+
+	ts := formatTimestamp(now())
+	action := EventRestored
+	record.ArchivedAt = ""
+	if archive {
+		action = EventArchived
+		record.ArchivedAt = ts
+	}
+	record.UpdatedAt = ts
+
+	if err := s.db.UpdateTask(*record); err != nil {
+		return err
+	}
+	if err := s.recordEvent(taskID, action, "", "", ""); err != nil {
+		return err
+	}
+	return done()
+}
@@ -0,0 +1,121 @@
+package task
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestArchiveAndRestore_Lossless(t *testing.T) {
+	svc := newTestService(t)
+
+	blocker := NewTaskComplete("test-aaa", Todo, TypeTask, "blocker", "", 3, "")
+	archived := NewTaskComplete("test-bbb", Todo, TypeBug, "archived", "details", 2, "")
+	for _, task := range []Task{blocker, archived} {
+		if err := svc.CreateTask(task); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if err := svc.AddDependency(blocker.ID(), archived.ID()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := svc.AddLabel(archived.ID(), "backend"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := svc.ArchiveTask(archived.ID()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	visible, err := svc.LoadTasks(false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(visible) != 1 || visible[0].ID() != blocker.ID() {
+		t.Errorf("expected only the blocker to be visible, got %d tasks", len(visible))
+	}
+	all, err := svc.LoadTasks(true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(all) != 2 {
+		t.Errorf("expected 2 tasks with archived included, got %d", len(all))
+	}
+
+	// Updating an archived task keeps it archived
+	if err := svc.UpdateTask(NewTaskComplete(archived.ID(), InProgress, TypeBug, "archived", "details", 2, "")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got, err := svc.GetTaskByID(archived.ID())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !got.IsArchived() {
+		t.Fatal("expected task to stay archived after update")
+	}
+	if !slices.Equal(got.Labels(), []string{"backend"}) || !slices.Equal(got.BlockedBy(), []string{blocker.ID()}) {
+		t.Errorf("expected labels and dependencies to be kept, got labels %v blocked by %v", got.Labels(), got.BlockedBy())
+	}
+
+	if err := svc.RestoreTask(archived.ID()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got, err = svc.GetTaskByID(archived.ID())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.IsArchived() {
+		t.Error("expected task to be restored")
+	}
+	if !slices.Equal(got.Labels(), []string{"backend"}) || !slices.Equal(got.BlockedBy(), []string{blocker.ID()}) {
+		t.Errorf("expected labels and dependencies after restore, got labels %v blocked by %v", got.Labels(), got.BlockedBy())
+	}
+}
+
+func TestArchiveTask_Errors(t *testing.T) {
+	svc := newTestService(t)
+	task := NewTaskComplete("test-aaa", Todo, TypeTask, "task", "", 3, "")
+	if err := svc.CreateTask(task); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := svc.RestoreTask(task.ID()); !errors.Is(err, ErrNotArchived) {
+		t.Errorf("expected ErrNotArchived, got %v", err)
+	}
+	if err := svc.ArchiveTask(task.ID()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := svc.ArchiveTask(task.ID()); !errors.Is(err, ErrArchived) {
+		t.Errorf("expected ErrArchived, got %v", err)
+	}
+	if err := svc.ArchiveTask("test-zzz"); !errors.Is(err, ErrTaskNotFound) {
+		t.Errorf("expected ErrTaskNotFound, got %v", err)
+	}
+}
+
+func TestGetReadyTasks_HidesArchived(t *testing.T) {
+	svc := newTestService(t)
+	task := NewTaskComplete("test-aaa", Todo, TypeTask, "task", "", 3, "")
+	if err := svc.CreateTask(task); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := svc.ArchiveTask(task.ID()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ready, err := svc.GetReadyTasks(false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(ready) != 0 {
+		t.Errorf("expected archived task to be hidden, got %d ready", len(ready))
+	}
+	ready, err = svc.GetReadyTasks(true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(ready) != 1 {
+		t.Errorf("expected archived task with includeArchived, got %d ready", len(ready))
+	}
+}
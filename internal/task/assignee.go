@@ -0,0 +1,117 @@
+package task
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/lucas-tremaroli/pace/internal/storage"
+)
+
+// AgentPrefix marks an assignee or actor as an agent rather than a human,
+// e.g. PACE_ACTOR=agent:planner
+const AgentPrefix = "agent:"
+
+// AssigneeMe stands for the current actor wherever an assignee is given
+const AssigneeMe = "me"
+
+// maxAssigneeLength is the longest assignee name accepted, in characters
+const maxAssigneeLength = 64
+
+// Assignee kinds reported in task JSON
+const (
+	AssigneeHuman = "human"
+	AssigneeAgent = "agent"
+)
+
+// ParseAssignee trims an assignee name and checks it is printable and not
+// too long. An empty name means unassigned, and "me" is the current Actor.
+func ParseAssignee(s string) (string, error) {
+	s = strings.TrimSpace(s)
+	if s == AssigneeMe {
+		return Actor(), nil
+	}
+	if len([]rune(s)) > maxAssigneeLength || strings.ContainsFunc(s, unicode.IsControl) {
+		return "", fmt.Errorf("%w: %q", ErrInvalidAssignee, s)
+	}
+	if strings.TrimSpace(strings.TrimPrefix(s, AgentPrefix)) == "" && s != "" {
+		return "", fmt.Errorf("%w: %q needs a name after %s", ErrInvalidAssignee, s, AgentPrefix)
+	}
+	return s, nil
+}
+
+// IsAgent reports whether an assignee or actor is an agent
+func IsAgent(name string) bool {
+	return strings.HasPrefix(name, AgentPrefix)
+}
+
+// AssigneeKind returns AssigneeAgent or AssigneeHuman, or "" if name is empty
+func AssigneeKind(name string) string {
+	switch {
+	case name == "":
+		return ""
+	case IsAgent(name):
+		return AssigneeAgent
+	default:
+		return AssigneeHuman
+	}
+}
+
+// Initials abbreviates an assignee to two letters: the first letters of
+// the first two words ("Ada Lovelace", "ada.lovelace" -> AL), or the
+// first two letters of a single word ("agent:claude" -> CL)
+func Initials(name string) string {
+	name = strings.TrimPrefix(name, AgentPrefix)
+	words := strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	var initials []rune
+	switch len(words) {
+	case 0:
+		return ""
+	case 1:
+		initials = []rune(words[0])
+		initials = initials[:min(2, len(initials))]
+	default:
+		initials = []rune{[]rune(words[0])[0], []rune(words[1])[0]}
+	}
+	return strings.ToUpper(string(initials))
+}
+
+// SetAssignee assigns a task to a person or agent, or unassigns it with ""
+func (s *Service) SetAssignee(taskID, assignee string) error {
+	assignee, err := ParseAssignee(assignee)
+	if err != nil {
+		return err
+	}
+	return s.Atomic(func() error {
+		return s.updateRecord(taskID, func(r *storage.TaskRecord) {
+			r.Assignee = assignee
+		})
+	})
+}
+
+// AssignedTo returns the unarchived tasks assigned to assignee that are not
+// in a terminal status, by priority
+func (s *Service) AssignedTo(assignee string) ([]Task, error) {
+	tasks, err := s.LoadTasks(false)
+	if err != nil {
+		return nil, err
+	}
+	w, err := s.Workflow()
+	if err != nil {
+		return nil, err
+	}
+
+	var assigned []Task
+	for _, t := range tasks {
+		if t.Assignee() == assignee && !w.IsTerminal(t.Status()) {
+			assigned = append(assigned, t)
+		}
+	}
+	slices.SortStableFunc(assigned, func(a, b Task) int {
+		return a.Priority() - b.Priority()
+	})
+	return assigned, nil
+}
@@ -0,0 +1,98 @@
+package task
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParseAssignee(t *testing.T) {
+	t.Setenv(EnvActor, "agent:claude")
+
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"", ""},
+		{"  ada  ", "ada"},
+		{"me", "agent:claude"},
+		{"agent:planner", "agent:planner"},
+	}
+	for _, tt := range tests {
+		got, err := ParseAssignee(tt.input)
+		if err != nil {
+			t.Errorf("ParseAssignee(%q) unexpected error: %v", tt.input, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseAssignee(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+
+	for _, input := range []string{"agent:", "ada\nlovelace", strings.Repeat("a", maxAssigneeLength+1)} {
+		if _, err := ParseAssignee(input); !errors.Is(err, ErrInvalidAssignee) {
+			t.Errorf("ParseAssignee(%q) expected ErrInvalidAssignee, got %v", input, err)
+		}
+	}
+}
+
+func TestInitials(t *testing.T) {
+	tests := map[string]string{
+		"Ada Lovelace": "AL",
+		"ada.lovelace": "AL",
+		"agent:claude": "CL",
+		"x":            "X",
+		"élodie":       "ÉL",
+		"--":           "",
+	}
+	for name, want := range tests {
+		if got := Initials(name); got != want {
+			t.Errorf("Initials(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestAssignedTo(t *testing.T) {
+	t.Setenv(EnvActor, "agent:claude")
+	svc := newTestService(t)
+
+	mine := NewTaskComplete("test-aaa", Todo, TypeTask, "mine", "", 2, "")
+	mine.SetAssignee("agent:claude")
+	finished := NewTaskComplete("test-bbb", Done, TypeTask, "finished", "", 1, "")
+	finished.SetAssignee("agent:claude")
+	theirs := NewTaskComplete("test-ccc", Todo, TypeTask, "theirs", "", 1, "")
+	theirs.SetAssignee("ada")
+	for _, task := range []Task{mine, finished, theirs} {
+		if err := svc.CreateTask(task); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	// Reassigning keeps the task out of the previous owner's list
+	if err := svc.SetAssignee(theirs.ID(), AssigneeMe); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// Updating other fields keeps the assignee
+	if err := svc.UpdateTask(NewTaskComplete(mine.ID(), InProgress, TypeTask, "mine", "", 2, "")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tasks, err := svc.AssignedTo(Actor())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(tasks) != 2 || tasks[0].ID() != theirs.ID() || tasks[1].ID() != mine.ID() {
+		t.Errorf("expected %s and %s by priority, got %d tasks", theirs.ID(), mine.ID(), len(tasks))
+	}
+	if kind := tasks[0].ToJSON().AssigneeKind; kind != AssigneeAgent {
+		t.Errorf("expected assignee kind %s, got %s", AssigneeAgent, kind)
+	}
+
+	filter, err := ParseFilter("assignee=")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if filter.Matches(tasks[0]) {
+		t.Error("expected an empty assignee filter to match only unassigned tasks")
+	}
+}
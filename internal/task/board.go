@@ -1,6 +1,8 @@
 package task
 
 import (
+	"slices"
+
 	"github.com/charmbracelet/bubbles/help"
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/list"
@@ -11,13 +13,24 @@ import (
 type Board struct {
 	help     help.Model
 	loaded   bool
-	focused  Status
+	focused  int
 	cols     []column
 	quitting bool
 	service  *Service
+	workflow Workflow
+	types    TypeRegistry
+
+	// includeArchived shows archived tasks on the board
+	includeArchived bool
+
+	// view, if set, limits the board to the tasks matching filter, in its
+	// sort order
+	view   *View
+	filter *TaskFilter
 }
 
-func NewBoard() (*Board, error) {
+// NewBoard opens the task board, limited to a saved view if view is set
+func NewBoard(includeArchived bool, view string) (*Board, error) {
 	help := help.New()
 	help.ShowAll = true
 
@@ -26,7 +39,27 @@ func NewBoard() (*Board, error) {
 		return nil, err
 	}
 
-	board := &Board{help: help, focused: Todo, service: service}
+	workflow, err := service.Workflow()
+	if err != nil {
+		service.Close()
+		return nil, err
+	}
+	types, err := service.Types()
+	if err != nil {
+		service.Close()
+		return nil, err
+	}
+
+	board := &Board{help: help, service: service, workflow: workflow, types: types, includeArchived: includeArchived}
+	if view != "" {
+		if board.view, err = service.GetView(view); err == nil {
+			board.filter, err = service.ViewFilter(board.view)
+		}
+		if err != nil {
+			service.Close()
+			return nil, err
+		}
+	}
 	board.initLists()
 	return board, nil
 }
@@ -53,7 +86,10 @@ func (m *Board) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		task := msg.CreateTask()
 		if msg.index == AppendIndex {
 			// Creating new task
-			m.service.CreateTask(task)
+			m.service.BeginOperation("task tui: create")
+			if created, err := m.service.CreateNewTask(task); err == nil {
+				task = created
+			}
 		} else {
 			// Editing existing task - preserve ID and dependencies from original
 			originalTask := m.cols[m.focused].list.Items()[msg.index].(Task)
@@ -67,17 +103,26 @@ func (m *Board) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				task.Priority(),
 				task.Link(),
 			)
-			// Preserve dependencies and labels
+			// Preserve dependencies, labels and what the form does not edit
 			task.SetBlockedBy(originalTask.BlockedBy())
 			task.SetBlocks(originalTask.Blocks())
 			task.SetLabels(originalTask.Labels())
+			task.SetParentID(originalTask.ParentID())
+			task.SetDue(originalTask.Due())
+			task.SetEstimate(originalTask.Estimate())
+			task.SetAssignee(originalTask.Assignee())
+			task.SetFields(originalTask.Fields())
+			task.SetLinks(originalTask.Links())
+			m.service.BeginOperation("task tui: edit")
 			m.service.UpdateTask(task)
 		}
 		return m, m.cols[m.focused].Set(msg.index, task)
 	case moveMsg:
+		m.service.BeginOperation("task tui: move")
 		m.service.UpdateTask(msg.Task)
-		return m, m.cols[m.focused.getNext()].Set(AppendIndex, msg.Task)
+		return m, m.cols[m.nextColumn()].Set(AppendIndex, msg.Task)
 	case deleteMsg:
+		m.service.BeginOperation("task tui: delete")
 		m.service.DeleteTask(msg.Task.ID())
 		return m, nil
 	case tea.KeyMsg:
@@ -90,11 +135,11 @@ func (m *Board) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, tea.Quit
 		case key.Matches(msg, keys.Left):
 			m.cols[m.focused].Blur()
-			m.focused = m.focused.getPrev()
+			m.focused = (m.focused + len(m.cols) - 1) % len(m.cols)
 			m.cols[m.focused].Focus()
 		case key.Matches(msg, keys.Right):
 			m.cols[m.focused].Blur()
-			m.focused = m.focused.getNext()
+			m.focused = m.nextColumn()
 			m.cols[m.focused].Focus()
 		}
 	}
@@ -125,20 +170,27 @@ func (m *Board) View() string {
 	// Add spacing between columns
 	columnGap := lipgloss.NewStyle().Width(2).Render("")
 
-	board := lipgloss.JoinHorizontal(
-		lipgloss.Left,
-		m.cols[Todo].View(),
-		columnGap,
-		m.cols[InProgress].View(),
-		columnGap,
-		m.cols[Done].View(),
-	)
+	var views []string
+	for i, col := range m.cols {
+		if i > 0 {
+			views = append(views, columnGap)
+		}
+		views = append(views, col.View())
+	}
+	board := lipgloss.JoinHorizontal(lipgloss.Left, views...)
 
 	// Style the board with margin to align with help box
 	boardStyle := lipgloss.NewStyle().
 		Margin(1, 2)
 
 	styledBoard := boardStyle.Render(board)
+	if m.view != nil {
+		viewStyle := lipgloss.NewStyle().
+			Foreground(lipgloss.Color("241")).
+			MarginTop(1).
+			MarginLeft(2)
+		styledBoard = lipgloss.JoinVertical(lipgloss.Left, viewStyle.Render("View: "+m.view.Name), styledBoard)
+	}
 
 	// Style the help section
 	helpStyle := lipgloss.NewStyle().
@@ -152,15 +204,20 @@ func (m *Board) View() string {
 	return lipgloss.JoinVertical(lipgloss.Left, styledBoard, styledHelp)
 }
 
+// nextColumn returns the index of the column after the focused one
+func (m *Board) nextColumn() int {
+	return (m.focused + 1) % len(m.cols)
+}
+
+// initLists builds one column per workflow status
 func (b *Board) initLists() {
-	b.cols = []column{
-		newColumn(Todo),
-		newColumn(InProgress),
-		newColumn(Done),
+	b.cols = nil
+	for _, status := range b.workflow.Statuses() {
+		col := newColumn(status, b.workflow, b.types)
+		col.list.Title = columnTitle(status)
+		b.cols = append(b.cols, col)
 	}
-	b.cols[Todo].list.Title = ColumnTitleTodo
-	b.cols[InProgress].list.Title = ColumnTitleInProgress
-	b.cols[Done].list.Title = ColumnTitleDone
+	b.cols[0].Focus()
 
 	b.loadTasksFromDB()
 }
@@ -171,48 +228,45 @@ func (b *Board) loadTasksFromDB() {
 		return
 	}
 
-	tasks, err := b.service.LoadAllTasks()
+	tasks, err := b.service.LoadTasks(b.includeArchived || b.filter != nil && b.filter.SelectsArchived())
 	if err != nil {
 		b.loadDefaultTasks()
 		return
 	}
+	if b.view != nil {
+		tasks = slices.DeleteFunc(tasks, func(t Task) bool { return !b.filter.Matches(t) })
+		SortTasks(tasks, b.view.Sort)
+	}
 
-	var todoItems, inProgressItems, doneItems []list.Item
-
+	// Tasks whose status was removed from the workflow go in the first column
+	items := make([][]list.Item, len(b.cols))
 	for _, task := range tasks {
-		switch task.Status() {
-		case Todo:
-			todoItems = append(todoItems, task)
-		case InProgress:
-			inProgressItems = append(inProgressItems, task)
-		case Done:
-			doneItems = append(doneItems, task)
-		}
+		i := max(b.workflow.index(task.Status()), 0)
+		items[i] = append(items[i], task)
 	}
 
-	b.cols[Todo].list.SetItems(todoItems)
-	b.cols[InProgress].list.SetItems(inProgressItems)
-	b.cols[Done].list.SetItems(doneItems)
+	for i := range b.cols {
+		b.cols[i].list.SetItems(items[i])
+	}
 }
 
 func (b *Board) loadDefaultTasks() {
 	// Generate IDs for demo tasks using the service if available
 	genID := func() string {
 		if b.service != nil {
-			return b.service.GenerateTaskID()
+			if id, err := b.service.GenerateTaskID(); err == nil {
+				return id
+			}
 		}
-		return GenerateID("demo")
+		return GenerateID("demo", IDLength)
 	}
 
-	b.cols[Todo].list.SetItems([]list.Item{
-		NewTask(genID(), Todo, "buy milk", "strawberry milk"),
-		NewTask(genID(), Todo, "eat sushi", "negitoro roll, miso soup, rice"),
-		NewTask(genID(), Todo, "fold laundry", "or wear wrinkly t-shirts"),
-	})
-	b.cols[InProgress].list.SetItems([]list.Item{
-		NewTask(genID(), InProgress, "write code", "don't worry, it's Go"),
-	})
-	b.cols[Done].list.SetItems([]list.Item{
-		NewTask(genID(), Done, "stay cool", "as a cucumber"),
+	first, second, last := &b.cols[0], &b.cols[min(1, len(b.cols)-1)], &b.cols[len(b.cols)-1]
+	first.list.SetItems([]list.Item{
+		NewTask(genID(), first.status, "buy milk", "strawberry milk"),
+		NewTask(genID(), first.status, "eat sushi", "negitoro roll, miso soup, rice"),
+		NewTask(genID(), first.status, "fold laundry", "or wear wrinkly t-shirts"),
 	})
+	second.list.InsertItem(AppendIndex, NewTask(genID(), second.status, "write code", "don't worry, it's Go"))
+	last.list.InsertItem(AppendIndex, NewTask(genID(), last.status, "stay cool", "as a cucumber"))
 }
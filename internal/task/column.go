@@ -15,8 +15,11 @@ import (
 type column struct {
 	focus  bool
 	status Status
+	next   Status // where Enter moves tasks
 	list   list.Model
 	width  int
+	// count is the number of columns on the board, which share its width
+	count int
 }
 
 func (c *column) Focus() {
@@ -31,15 +34,11 @@ func (c *column) Focused() bool {
 	return c.focus
 }
 
-func newColumn(status Status) column {
-	var focus bool
-	if status == Todo {
-		focus = true
-	}
-	delegate := newTaskDelegate()
+func newColumn(status Status, w Workflow, types TypeRegistry) column {
+	delegate := newTaskDelegate(types, w.IsTerminal(status))
 	defaultList := list.New([]list.Item{}, delegate, 0, 0)
 	defaultList.SetShowHelp(false)
-	return column{focus: focus, status: status, list: defaultList}
+	return column{status: status, next: w.Next(status), list: defaultList, count: len(w)}
 }
 
 func (c column) Init() tea.Cmd {
@@ -59,7 +58,7 @@ func (c column) update(msg tea.Msg, board *Board) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
 		c.setSize(msg.Width)
-		c.list.SetSize(msg.Width/Margin, msg.Height/2)
+		c.list.SetSize(c.width, msg.Height/2)
 	case tea.KeyMsg:
 		switch {
 		case key.Matches(msg, keys.Edit):
@@ -85,8 +84,12 @@ func (c column) update(msg tea.Msg, board *Board) (tea.Model, tea.Cmd) {
 		case key.Matches(msg, keys.Open):
 			if len(c.list.VisibleItems()) != 0 {
 				task := c.list.SelectedItem().(Task)
-				if task.Link() != "" {
-					return c, c.OpenLink(task.Link())
+				switch choices := linkChoices(task); len(choices) {
+				case 0:
+				case 1:
+					return c, choices[0].open()
+				default:
+					return NewLinkPicker(task, choices, board), nil
 				}
 			}
 			return c, nil
@@ -126,8 +129,10 @@ func (c *column) Set(i int, t Task) tea.Cmd {
 	return c.list.InsertItem(AppendIndex, t)
 }
 
+// setSize gives each column an equal share of the width, leaving one share
+// for margins and gaps
 func (c *column) setSize(width int) {
-	c.width = width / Margin
+	c.width = width / (c.count + 1)
 }
 
 func (c *column) getStyle() lipgloss.Style {
@@ -153,7 +158,8 @@ type deleteMsg struct {
 	Task
 }
 
-func (c *column) OpenLink(link string) tea.Cmd {
+// openURL opens an http(s) link in the default browser
+func openURL(link string) tea.Cmd {
 	return func() tea.Msg {
 		// Validate and sanitize the link
 		link = strings.TrimSpace(link)
@@ -182,22 +188,27 @@ func (c *column) OpenLink(link string) tea.Cmd {
 			return nil
 		}
 
-		// Use OS-specific command to open URL in default browser
-		var cmd *exec.Cmd
-		switch runtime.GOOS {
-		case "darwin":
-			cmd = exec.Command("open", link)
-		case "linux":
-			cmd = exec.Command("xdg-open", link)
-		default:
-			// Fallback: try xdg-open (common on Unix-like systems)
-			cmd = exec.Command("xdg-open", link)
-		}
-		_ = cmd.Start()
+		systemOpen(link)
 		return nil
 	}
 }
 
+// systemOpen opens a URL or file with the OS default application
+func systemOpen(target string) {
+	// Use the OS-specific command for the default application
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", target)
+	case "linux":
+		cmd = exec.Command("xdg-open", target)
+	default:
+		// Fallback: try xdg-open (common on Unix-like systems)
+		cmd = exec.Command("xdg-open", target)
+	}
+	_ = cmd.Start()
+}
+
 func (c *column) MoveToNext() tea.Cmd {
 	var task Task
 	var ok bool
@@ -205,7 +216,7 @@ func (c *column) MoveToNext() tea.Cmd {
 		return nil
 	}
 	c.list.RemoveItem(c.list.Index())
-	task.status = c.status.getNext()
+	task.status = c.next
 
 	var cmd tea.Cmd
 	c.list, cmd = c.list.Update(nil)
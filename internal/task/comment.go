@@ -0,0 +1,94 @@
+package task
+
+import (
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/lucas-tremaroli/pace/internal/storage"
+)
+
+// Comment is a single entry in a task's progress log
+type Comment = storage.CommentRecord
+
+// AddComment appends a comment by the current actor to a task
+func (s *Service) AddComment(taskID, body string) (*Comment, error) {
+	var comment *Comment
+	err := s.Atomic(func() error {
+		var err error
+		comment, err = s.addComment(taskID, body)
+		return err
+	})
+	return comment, err
+}
+
+func (s *Service) addComment(taskID, body string) (*Comment, error) {
+	if strings.TrimSpace(body) == "" {
+		return nil, ErrEmptyComment
+	}
+	exists, err := s.db.TaskExists(taskID)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", ErrTaskNotFound, taskID)
+	}
+
+	done, err := s.track(taskID)
+	if err != nil {
+		return nil, err
+	}
+	comment := Comment{
+		TaskID:    taskID,
+		Author:    Actor(),
+		Body:      body,
+		CreatedAt: formatTimestamp(now()),
+	}
+	comment.ID, err = s.db.AddComment(comment)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.recordEvent(taskID, EventCommentAdded, "comment", "", body); err != nil {
+		return nil, err
+	}
+	return &comment, done()
+}
+
+// ListComments returns the comments on a task, oldest first
+func (s *Service) ListComments(taskID string) ([]Comment, error) {
+	return s.db.GetComments(taskID)
+}
+
+// DeleteComment removes a comment and returns it
+func (s *Service) DeleteComment(commentID int64) (*Comment, error) {
+	var comment *Comment
+	err := s.Atomic(func() error {
+		var err error
+		comment, err = s.deleteComment(commentID)
+		return err
+	})
+	return comment, err
+}
+
+func (s *Service) deleteComment(commentID int64) (*Comment, error) {
+	comment, err := s.db.GetComment(commentID)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("%w: %s", ErrCommentNotFound, strconv.FormatInt(commentID, 10))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	done, err := s.track(comment.TaskID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.db.DeleteComment(commentID); err != nil {
+		return nil, err
+	}
+	if err := s.recordEvent(comment.TaskID, EventCommentDeleted, "comment", comment.Body, ""); err != nil {
+		return nil, err
+	}
+	return comment, done()
+}
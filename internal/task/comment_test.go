@@ -0,0 +1,97 @@
+package task
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestComments_AddListDelete(t *testing.T) {
+	svc := newTestService(t)
+	t.Setenv(EnvActor, "agent-1")
+
+	task := NewTaskComplete("test-aaa", Todo, TypeTask, "task", "original description", 3, "")
+	if err := svc.CreateTask(task); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	first, err := svc.AddComment(task.ID(), "tried X, failed because Y")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if first.Author != "agent-1" || first.CreatedAt == "" {
+		t.Errorf("expected author and timestamp, got %+v", first)
+	}
+	if _, err := svc.AddComment(task.ID(), "tried Z, it worked"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got, err := svc.GetTaskByID(task.ID())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got.Comments()) != 2 || got.Comments()[0].Body != "tried X, failed because Y" {
+		t.Errorf("expected comments in order, got %+v", got.Comments())
+	}
+	if got.Description() != "original description" {
+		t.Errorf("expected description to be untouched, got %q", got.Description())
+	}
+
+	if _, err := svc.DeleteComment(first.ID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	comments, err := svc.ListComments(task.ID())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(comments) != 1 || comments[0].Body != "tried Z, it worked" {
+		t.Errorf("expected one remaining comment, got %+v", comments)
+	}
+
+	if _, err := svc.DeleteComment(first.ID); !errors.Is(err, ErrCommentNotFound) {
+		t.Errorf("expected ErrCommentNotFound, got %v", err)
+	}
+}
+
+func TestAddComment_Errors(t *testing.T) {
+	svc := newTestService(t)
+	if _, err := svc.AddComment("test-zzz", "hello"); !errors.Is(err, ErrTaskNotFound) {
+		t.Errorf("expected ErrTaskNotFound, got %v", err)
+	}
+
+	task := NewTaskComplete("test-aaa", Todo, TypeTask, "task", "", 3, "")
+	if err := svc.CreateTask(task); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := svc.AddComment(task.ID(), "   "); !errors.Is(err, ErrEmptyComment) {
+		t.Errorf("expected ErrEmptyComment, got %v", err)
+	}
+}
+
+func TestUndo_DeleteRestoresComments(t *testing.T) {
+	svc := newTestService(t)
+
+	task := NewTaskComplete("test-aaa", Todo, TypeTask, "task", "", 3, "")
+	if err := svc.CreateTask(task); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	comment, err := svc.AddComment(task.ID(), "progress note")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	svc.BeginOperation("delete")
+	if err := svc.DeleteTask(task.ID()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := svc.Undo(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	comments, err := svc.ListComments(task.ID())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(comments) != 1 || comments[0].ID != comment.ID || comments[0].Body != "progress note" {
+		t.Errorf("expected comment to be restored, got %+v", comments)
+	}
+}
@@ -0,0 +1,153 @@
+package task
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/lucas-tremaroli/pace/internal/storage"
+)
+
+// Environment used to run TestConcurrentWorker as a child process
+const (
+	envWorkerDB = "PACE_TEST_WORKER_DB"
+	envWorkerID = "PACE_TEST_WORKER_ID"
+)
+
+const (
+	concurrentWorkers    = 4
+	concurrentIterations = 25
+	counterTaskID        = "test-counter"
+)
+
+// TestConcurrentProcesses runs several processes that read-modify-write the
+// same task, create tasks and add labels at the same time, while this
+// process keeps reading. Every update must survive.
+func TestConcurrentProcesses(t *testing.T) {
+	if testing.Short() {
+		t.Skip("spawns child processes")
+	}
+
+	dbPath := filepath.Join(t.TempDir(), "tasks.db")
+	db, err := storage.NewDBWithPath(dbPath)
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	svc := NewServiceWithDB(db, "test")
+	defer svc.Close()
+
+	if err := svc.CreateTask(NewTaskComplete(counterTaskID, Todo, TypeTask, "counter", "0", 3, "")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Keep reading while the workers write, like a TUI refreshing the board
+	stop := make(chan struct{})
+	readErr := make(chan error, 1)
+	go func() {
+		defer close(readErr)
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			if _, err := svc.LoadAllTasks(); err != nil {
+				readErr <- err
+				return
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	outputs := make([][]byte, concurrentWorkers)
+	errs := make([]error, concurrentWorkers)
+	for w := range concurrentWorkers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			cmd := exec.Command(os.Args[0], "-test.run=^TestConcurrentWorker$", "-test.count=1")
+			cmd.Env = append(os.Environ(), envWorkerDB+"="+dbPath, envWorkerID+"="+strconv.Itoa(w))
+			outputs[w], errs[w] = cmd.CombinedOutput()
+		}()
+	}
+	wg.Wait()
+	close(stop)
+
+	for w, err := range errs {
+		if err != nil {
+			t.Fatalf("worker %d failed: %v\n%s", w, err, outputs[w])
+		}
+	}
+	if err := <-readErr; err != nil {
+		t.Fatalf("concurrent read failed: %v", err)
+	}
+
+	want := concurrentWorkers * concurrentIterations
+	counter, err := svc.GetTaskByID(counterTaskID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if counter.Description() != strconv.Itoa(want) {
+		t.Errorf("expected counter %d, got %s (lost updates)", want, counter.Description())
+	}
+	if len(counter.Labels()) != want {
+		t.Errorf("expected %d labels, got %d", want, len(counter.Labels()))
+	}
+
+	tasks, err := svc.LoadAllTasks()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(tasks) != want+1 {
+		t.Errorf("expected %d tasks, got %d", want+1, len(tasks))
+	}
+}
+
+// TestConcurrentWorker is the body of a child process started by
+// TestConcurrentProcesses; it is skipped when run directly
+func TestConcurrentWorker(t *testing.T) {
+	dbPath := os.Getenv(envWorkerDB)
+	if dbPath == "" {
+		t.Skip("only runs as a child of TestConcurrentProcesses")
+	}
+	worker := os.Getenv(envWorkerID)
+
+	db, err := storage.NewDBWithPath(dbPath)
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	svc := NewServiceWithDB(db, "test")
+	defer svc.Close()
+
+	for i := range concurrentIterations {
+		svc.BeginOperation("worker " + worker)
+
+		err := svc.Atomic(func() error {
+			counter, err := svc.GetTaskByID(counterTaskID)
+			if err != nil {
+				return err
+			}
+			n, err := strconv.Atoi(counter.Description())
+			if err != nil {
+				return err
+			}
+			updated := NewTaskComplete(counterTaskID, counter.Status(), counter.Type(), counter.Title(), strconv.Itoa(n+1), counter.Priority(), counter.Link())
+			return svc.UpdateTask(updated)
+		})
+		if err != nil {
+			t.Fatalf("increment %d failed: %v", i, err)
+		}
+
+		id := fmt.Sprintf("w%s-%d", worker, i)
+		if err := svc.CreateTask(NewTaskComplete(id, Todo, TypeTask, "from worker", "", 3, "")); err != nil {
+			t.Fatalf("create %s failed: %v", id, err)
+		}
+		if err := svc.AddLabel(counterTaskID, id); err != nil {
+			t.Fatalf("label %s failed: %v", id, err)
+		}
+	}
+}
@@ -0,0 +1,174 @@
+package task
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// CycleError is returned when a dependency would make a task block itself,
+// directly or through other tasks. Cycle lists the tasks in blocking order,
+// starting and ending with the same task.
+type CycleError struct {
+	Cycle []string `json:"cycle"`
+}
+
+func (e *CycleError) Error() string {
+	return fmt.Sprintf("%v: %s", ErrDependencyCycle, strings.Join(e.Cycle, " -> "))
+}
+
+func (e *CycleError) Unwrap() error {
+	return ErrDependencyCycle
+}
+
+// ErrorData includes the cycle path in the JSON error response
+func (e *CycleError) ErrorData() any {
+	return e
+}
+
+// CyclesError reports the dependency cycles found by CheckDependencies
+type CyclesError struct {
+	Cycles [][]string `json:"cycles"`
+	Count  int        `json:"count"`
+}
+
+func (e *CyclesError) Error() string {
+	paths := make([]string, len(e.Cycles))
+	for i, c := range e.Cycles {
+		paths[i] = strings.Join(c, " -> ")
+	}
+	return fmt.Sprintf("found %d %v(s): %s", e.Count, ErrDependencyCycle, strings.Join(paths, "; "))
+}
+
+func (e *CyclesError) Unwrap() error {
+	return ErrDependencyCycle
+}
+
+// ErrorData lists the cycles in the JSON error response
+func (e *CyclesError) ErrorData() any {
+	return e
+}
+
+// DependencyCycle returns the cycle that adding "blocker blocks blocked"
+// to a graph of blocks edges would create, or nil if it creates none
+func DependencyCycle(blocks map[string][]string, blockerID, blockedID string) []string {
+	if blockerID == blockedID {
+		return []string{blockerID, blockedID}
+	}
+	path := blockingPath(blocks, blockedID, blockerID, nil)
+	if path == nil {
+		return nil
+	}
+	return append([]string{blockerID}, path...)
+}
+
+// blockingPath returns the shortest chain of blocks edges from one task to
+// another, only passing through tasks in within if it is not nil
+func blockingPath(blocks map[string][]string, from, to string, within map[string]bool) []string {
+	prev := map[string]string{from: ""}
+	queue := []string{from}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		next := slices.Clone(blocks[id])
+		slices.Sort(next)
+		for _, n := range next {
+			if within != nil && !within[n] {
+				continue
+			}
+			if n == to {
+				path := []string{to}
+				for p := id; p != ""; p = prev[p] {
+					path = append(path, p)
+				}
+				slices.Reverse(path)
+				return path
+			}
+			if _, seen := prev[n]; !seen {
+				prev[n] = id
+				queue = append(queue, n)
+			}
+		}
+	}
+	return nil
+}
+
+// FindCycles returns one cycle for each group of tasks that block each
+// other, starting from the lowest ID in the group, sorted by that ID
+func FindCycles(blocks map[string][]string) [][]string {
+	var cycles [][]string
+	for _, group := range stronglyConnected(blocks) {
+		start := slices.Min(group)
+		within := make(map[string]bool, len(group))
+		for _, id := range group {
+			within[id] = true
+		}
+		if len(group) == 1 && !slices.Contains(blocks[start], start) {
+			continue
+		}
+		cycles = append(cycles, blockingPath(blocks, start, start, within))
+	}
+	slices.SortFunc(cycles, func(a, b []string) int {
+		return strings.Compare(a[0], b[0])
+	})
+	return cycles
+}
+
+// stronglyConnected returns the strongly connected components of the graph
+// using Tarjan's algorithm
+func stronglyConnected(blocks map[string][]string) [][]string {
+	index := make(map[string]int)
+	low := make(map[string]int)
+	onStack := make(map[string]bool)
+	var stack []string
+	var groups [][]string
+
+	var visit func(id string)
+	visit = func(id string) {
+		index[id] = len(index)
+		low[id] = index[id]
+		stack = append(stack, id)
+		onStack[id] = true
+		for _, n := range blocks[id] {
+			if _, seen := index[n]; !seen {
+				visit(n)
+				low[id] = min(low[id], low[n])
+			} else if onStack[n] {
+				low[id] = min(low[id], index[n])
+			}
+		}
+		if low[id] == index[id] {
+			var group []string
+			for {
+				n := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				onStack[n] = false
+				group = append(group, n)
+				if n == id {
+					break
+				}
+			}
+			groups = append(groups, group)
+		}
+	}
+	for _, id := range slices.Sorted(maps.Keys(blocks)) {
+		if _, seen := index[id]; !seen {
+			visit(id)
+		}
+	}
+	return groups
+}
+
+// CheckDependencies returns a *CyclesError listing the dependency cycles
+// among all tasks, or nil if there are none
+func (s *Service) CheckDependencies() error {
+	_, blocks, err := s.db.GetAllDependencies()
+	if err != nil {
+		return err
+	}
+	if cycles := FindCycles(blocks); len(cycles) > 0 {
+		return &CyclesError{Cycles: cycles, Count: len(cycles)}
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package task
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestAddDependency_RejectsCycles(t *testing.T) {
+	svc := newTestService(t)
+	for _, id := range []string{"test-aaa", "test-bbb", "test-ccc"} {
+		if err := svc.CreateTask(NewTaskComplete(id, Todo, TypeTask, id, "", 3, "")); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if err := svc.AddDependency("test-aaa", "test-bbb"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := svc.AddDependency("test-bbb", "test-ccc"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		blocker, blocked string
+		want             []string
+	}{
+		{"test-ccc", "test-aaa", []string{"test-ccc", "test-aaa", "test-bbb", "test-ccc"}},
+		{"test-bbb", "test-aaa", []string{"test-bbb", "test-aaa", "test-bbb"}},
+		{"test-aaa", "test-aaa", []string{"test-aaa", "test-aaa"}},
+	}
+	for _, tt := range tests {
+		err := svc.AddDependency(tt.blocker, tt.blocked)
+		var cycleErr *CycleError
+		if !errors.As(err, &cycleErr) || !errors.Is(err, ErrDependencyCycle) {
+			t.Errorf("AddDependency(%s, %s) expected a CycleError, got %v", tt.blocker, tt.blocked, err)
+			continue
+		}
+		if !slices.Equal(cycleErr.Cycle, tt.want) {
+			t.Errorf("AddDependency(%s, %s) cycle = %v, want %v", tt.blocker, tt.blocked, cycleErr.Cycle, tt.want)
+		}
+	}
+
+	// A shortcut along the existing direction is not a cycle
+	if err := svc.AddDependency("test-aaa", "test-ccc"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := svc.CheckDependencies(); err != nil {
+		t.Errorf("expected no cycles, got %v", err)
+	}
+}
+
+func TestFindCycles(t *testing.T) {
+	blocks := map[string][]string{
+		"d": {"e"},
+		"e": {"c", "d"},
+		"a": {"b"},
+		"b": {"c"},
+		"c": {"a"},
+		"f": {"f"},
+		"g": {"a"},
+	}
+	want := [][]string{{"a", "b", "c", "a"}, {"d", "e", "d"}, {"f", "f"}}
+	got := FindCycles(blocks)
+	if !slices.EqualFunc(got, want, slices.Equal) {
+		t.Errorf("FindCycles() = %v, want %v", got, want)
+	}
+}
+
+func TestCheckDependencies_ReportsExistingCycles(t *testing.T) {
+	svc := newTestService(t)
+	for _, id := range []string{"test-aaa", "test-bbb"} {
+		if err := svc.CreateTask(NewTaskComplete(id, Todo, TypeTask, id, "", 3, "")); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	// Stores from before cycle checks can already contain cycles
+	if err := svc.db.AddDependency("test-aaa", "test-bbb"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := svc.db.AddDependency("test-bbb", "test-aaa"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var cyclesErr *CyclesError
+	if err := svc.CheckDependencies(); !errors.As(err, &cyclesErr) {
+		t.Fatalf("expected a CyclesError, got %v", err)
+	}
+	if cyclesErr.Count != 1 || !slices.Equal(cyclesErr.Cycles[0], []string{"test-aaa", "test-bbb", "test-aaa"}) {
+		t.Errorf("unexpected cycles: %+v", cyclesErr)
+	}
+}
@@ -3,6 +3,7 @@ package task
 import (
 	"fmt"
 	"io"
+	"time"
 
 	"github.com/charmbracelet/bubbles/list"
 	tea "github.com/charmbracelet/bubbletea"
@@ -12,13 +13,16 @@ import (
 // taskDelegate renders tasks with dependency indicators
 type taskDelegate struct {
 	baseDelegate list.DefaultDelegate
+	types        TypeRegistry
+	// terminal is set for columns of done tasks, which are never overdue
+	terminal bool
 }
 
-func newTaskDelegate() taskDelegate {
+func newTaskDelegate(types TypeRegistry, terminal bool) taskDelegate {
 	d := list.NewDefaultDelegate()
 	d.SetHeight(1)
 	d.ShowDescription = false
-	return taskDelegate{baseDelegate: d}
+	return taskDelegate{baseDelegate: d, types: types, terminal: terminal}
 }
 
 func (d taskDelegate) Height() int {
@@ -58,11 +62,36 @@ func (d taskDelegate) Render(w io.Writer, m list.Model, index int, item list.Ite
 		indicators += fmt.Sprintf(" [%d]", len(task.blocks))
 	}
 
+	// Due date, highlighted when overdue or due soon
+	var dueStr string
+	dueStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("243"))
+	if !task.due.IsZero() {
+		dueStr = " " + task.due.Format("Jan 2")
+		if !d.terminal {
+			switch task.DueState(time.Now()) {
+			case Overdue:
+				dueStyle = dueStyle.Foreground(lipgloss.Color("196")).Bold(true)
+			case DueSoon:
+				dueStyle = dueStyle.Foreground(lipgloss.Color("214"))
+			}
+		}
+	}
+
+	// Owner initials, cyan for agents and pink for humans
+	var ownerStr string
+	ownerStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("176"))
+	if task.assignee != "" {
+		ownerStr = " @" + Initials(task.assignee)
+		if IsAgent(task.assignee) {
+			ownerStyle = ownerStyle.Foreground(lipgloss.Color("81"))
+		}
+	}
+
 	// Type prefix
-	typePrefix := fmt.Sprintf("[%s] ", task.taskType.Symbol())
+	typePrefix := fmt.Sprintf("[%s] ", d.types.Symbol(task.taskType))
 
 	// Styles
-	hasLink := task.link != ""
+	hasLink := task.link != "" || len(task.links) > 0
 	normalStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("255")).Underline(hasLink)
 	cursorStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("170")).Bold(true)
 	titleStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("170")).Bold(true).Underline(hasLink)
@@ -70,6 +99,9 @@ func (d taskDelegate) Render(w io.Writer, m list.Model, index int, item list.Ite
 	indicatorStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("208"))
 	labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("39")) // Cyan for labels
 	typeStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("245")) // Gray for type
+	if color := d.types.Color(task.taskType); color != "" {
+		typeStyle = typeStyle.Foreground(lipgloss.Color(color))
+	}
 
 	isSelected := index == m.Index()
 	isCursor := isSelected
@@ -81,13 +113,13 @@ func (d taskDelegate) Render(w io.Writer, m list.Model, index int, item list.Ite
 	if isCursor {
 		cursor := "> "
 		if isBlocked {
-			rendered = cursorStyle.Render(cursor) + typeStyle.Render(typePrefix) + blockedStyle.Render(title) + labelStyle.Render(labelStr) + indicatorStyle.Render(indicators)		} else {
-			rendered = cursorStyle.Render(cursor) + typeStyle.Render(typePrefix) + titleStyle.Render(title) + labelStyle.Render(labelStr) + indicatorStyle.Render(indicators)		}
+			rendered = cursorStyle.Render(cursor) + typeStyle.Render(typePrefix) + blockedStyle.Render(title) + labelStyle.Render(labelStr) + indicatorStyle.Render(indicators) + dueStyle.Render(dueStr) + ownerStyle.Render(ownerStr)		} else {
+			rendered = cursorStyle.Render(cursor) + typeStyle.Render(typePrefix) + titleStyle.Render(title) + labelStyle.Render(labelStr) + indicatorStyle.Render(indicators) + dueStyle.Render(dueStr) + ownerStyle.Render(ownerStr)		}
 	} else {
 		cursor := "  "
 		if isBlocked {
-			rendered = cursor + typeStyle.Render(typePrefix) + blockedStyle.Render(title) + labelStyle.Render(labelStr) + indicatorStyle.Render(indicators)		} else {
-			rendered = cursor + typeStyle.Render(typePrefix) + normalStyle.Render(title) + labelStyle.Render(labelStr) + indicatorStyle.Render(indicators)		}
+			rendered = cursor + typeStyle.Render(typePrefix) + blockedStyle.Render(title) + labelStyle.Render(labelStr) + indicatorStyle.Render(indicators) + dueStyle.Render(dueStr) + ownerStyle.Render(ownerStr)		} else {
+			rendered = cursor + typeStyle.Render(typePrefix) + normalStyle.Render(title) + labelStyle.Render(labelStr) + indicatorStyle.Render(indicators) + dueStyle.Render(dueStr) + ownerStyle.Render(ownerStr)		}
 	}
 
 	fmt.Fprint(w, rendered)
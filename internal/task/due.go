@@ -0,0 +1,142 @@
+package task
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lucas-tremaroli/pace/internal/storage"
+)
+
+// dueSoonDays is how many days ahead a due date counts as due soon
+const dueSoonDays = 2
+
+// DueState describes how close a task is to its due date
+type DueState int
+
+const (
+	DueNone DueState = iota // No due date
+	DueLater
+	DueSoon // Due today or within dueSoonDays
+	Overdue
+)
+
+// ParseDueDate parses a due date relative to today. Accepts a date
+// (2006-01-02), today or tomorrow, an offset such as +3d or +2w, or a
+// weekday (friday, fri), meaning the next one after today.
+func ParseDueDate(s string) (time.Time, error) {
+	return parseDueDate(s, time.Now())
+}
+
+func parseDueDate(s string, now time.Time) (time.Time, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	today := startOfDay(now)
+
+	if t, err := time.ParseInLocation(time.DateOnly, s, time.Local); err == nil {
+		return t, nil
+	}
+	switch s {
+	case "today":
+		return today, nil
+	case "tomorrow":
+		return today.AddDate(0, 0, 1), nil
+	}
+	if offset, ok := strings.CutPrefix(s, "+"); ok && len(offset) > 1 {
+		n, err := strconv.Atoi(offset[:len(offset)-1])
+		if err == nil && n >= 0 {
+			switch offset[len(offset)-1] {
+			case 'd':
+				return today.AddDate(0, 0, n), nil
+			case 'w':
+				return today.AddDate(0, 0, 7*n), nil
+			}
+		}
+	}
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		name := strings.ToLower(d.String())
+		if s == name || s == name[:3] {
+			days := (int(d)-int(today.Weekday())+6)%7 + 1
+			return today.AddDate(0, 0, days), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid due date: %s (use YYYY-MM-DD, today, tomorrow, +3d, +2w, or a weekday)", s)
+}
+
+// startOfDay returns midnight local time on the day of t
+func startOfDay(t time.Time) time.Time {
+	y, m, d := t.In(time.Local).Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.Local)
+}
+
+// FormatDate formats a due date for storage and output. Zero dates
+// format as an empty string.
+func FormatDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(time.DateOnly)
+}
+
+// parseDate parses a stored due date. Empty or malformed values yield the
+// zero time.
+func parseDate(s string) time.Time {
+	t, err := time.ParseInLocation(time.DateOnly, s, time.Local)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
+// DueState reports whether the task is overdue or due soon as of now. It
+// does not consider the status; done tasks are never reported as overdue
+// by the service.
+func (t Task) DueState(now time.Time) DueState {
+	if t.due.IsZero() {
+		return DueNone
+	}
+	today := startOfDay(now)
+	switch {
+	case t.due.Before(today):
+		return Overdue
+	case !t.due.After(today.AddDate(0, 0, dueSoonDays)):
+		return DueSoon
+	default:
+		return DueLater
+	}
+}
+
+// SetDue sets or, with a zero time, clears the due date of a task
+func (s *Service) SetDue(taskID string, due time.Time) error {
+	return s.Atomic(func() error {
+		return s.updateRecord(taskID, func(r *storage.TaskRecord) {
+			r.DueDate = FormatDate(due)
+		})
+	})
+}
+
+// GetOverdueTasks returns unarchived tasks that are past their due date and
+// not in a terminal status, most overdue first
+func (s *Service) GetOverdueTasks() ([]Task, error) {
+	tasks, err := s.LoadTasks(false)
+	if err != nil {
+		return nil, err
+	}
+	w, err := s.Workflow()
+	if err != nil {
+		return nil, err
+	}
+
+	at := time.Now()
+	var overdue []Task
+	for _, t := range tasks {
+		if t.DueState(at) == Overdue && !w.IsTerminal(t.Status()) {
+			overdue = append(overdue, t)
+		}
+	}
+	slices.SortStableFunc(overdue, func(a, b Task) int {
+		return a.Due().Compare(b.Due())
+	})
+	return overdue, nil
+}
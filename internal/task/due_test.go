@@ -0,0 +1,117 @@
+package task
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDueDate(t *testing.T) {
+	// A Wednesday
+	now := time.Date(2026, 10, 14, 15, 30, 0, 0, time.Local)
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"2026-11-01", "2026-11-01"},
+		{"today", "2026-10-14"},
+		{"Tomorrow", "2026-10-15"},
+		{"+3d", "2026-10-17"},
+		{"+0d", "2026-10-14"},
+		{"+2w", "2026-10-28"},
+		{"friday", "2026-10-16"},
+		{"fri", "2026-10-16"},
+		{"wednesday", "2026-10-21"},
+		{"mon", "2026-10-19"},
+	}
+	for _, tt := range tests {
+		got, err := parseDueDate(tt.input, now)
+		if err != nil {
+			t.Errorf("parseDueDate(%q) unexpected error: %v", tt.input, err)
+			continue
+		}
+		if FormatDate(got) != tt.want {
+			t.Errorf("parseDueDate(%q) = %s, want %s", tt.input, FormatDate(got), tt.want)
+		}
+	}
+
+	for _, input := range []string{"", "next week", "+3", "+-1d", "+3m", "2026-13-01", "fr"} {
+		if _, err := parseDueDate(input, now); err == nil {
+			t.Errorf("parseDueDate(%q) expected error, got nil", input)
+		}
+	}
+}
+
+func TestDueState(t *testing.T) {
+	now := time.Date(2026, 10, 14, 15, 30, 0, 0, time.Local)
+	tests := []struct {
+		due  string
+		want DueState
+	}{
+		{"", DueNone},
+		{"2026-10-13", Overdue},
+		{"2026-10-14", DueSoon},
+		{"2026-10-16", DueSoon},
+		{"2026-10-17", DueLater},
+	}
+	for _, tt := range tests {
+		task := NewTaskComplete("test-aaa", Todo, TypeTask, "task", "", 3, "")
+		task.SetDue(parseDate(tt.due))
+		if got := task.DueState(now); got != tt.want {
+			t.Errorf("DueState with due %q = %d, want %d", tt.due, got, tt.want)
+		}
+	}
+}
+
+func TestDueDates(t *testing.T) {
+	svc := newTestService(t)
+	yesterday := startOfDay(time.Now()).AddDate(0, 0, -1)
+	nextWeek := startOfDay(time.Now()).AddDate(0, 0, 7)
+
+	late := NewTaskComplete("test-aaa", Todo, TypeTask, "late", "", 3, "")
+	late.SetDue(yesterday)
+	finished := NewTaskComplete("test-bbb", Done, TypeTask, "finished", "", 3, "")
+	finished.SetDue(yesterday)
+	upcoming := NewTaskComplete("test-ccc", Todo, TypeTask, "upcoming", "", 3, "")
+	for _, task := range []Task{late, finished, upcoming} {
+		if err := svc.CreateTask(task); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if err := svc.SetDue(upcoming.ID(), nextWeek); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Updating other fields keeps the due date
+	if err := svc.UpdateTask(NewTaskComplete(upcoming.ID(), InProgress, TypeTask, "upcoming", "", 2, "")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got, err := svc.GetTaskByID(upcoming.ID())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !got.Due().Equal(nextWeek) {
+		t.Errorf("expected due date %v to be kept, got %v", nextWeek, got.Due())
+	}
+
+	// Done tasks are never overdue
+	overdue, err := svc.GetOverdueTasks()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(overdue) != 1 || overdue[0].ID() != late.ID() {
+		t.Errorf("expected only %s to be overdue, got %d tasks", late.ID(), len(overdue))
+	}
+
+	// Clearing the due date is recorded in the history
+	if err := svc.SetDue(late.ID(), time.Time{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	events, err := svc.History(late.ID())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	last := events[len(events)-1]
+	if last.Field != "due" || last.OldValue != FormatDate(yesterday) || last.NewValue != "" {
+		t.Errorf("expected a due change event, got %+v", last)
+	}
+}
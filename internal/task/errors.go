@@ -4,7 +4,37 @@ import "errors"
 
 // Error definitions for task validation and operations
 var (
-	ErrEmptyTitle    = errors.New("task title cannot be empty")
-	ErrInvalidStatus = errors.New("invalid task status")
-	ErrInvalidLink   = errors.New("invalid link: must be a valid URL (e.g. https://example.com)")
+	ErrEmptyTitle      = errors.New("task title cannot be empty")
+	ErrInvalidStatus   = errors.New("invalid task status")
+	ErrInvalidType     = errors.New("invalid task type")
+	ErrDuplicateType   = errors.New("task type already exists")
+	ErrBuiltinType     = errors.New("built-in task types cannot be removed")
+	ErrTypeInUse       = errors.New("task type is in use")
+	ErrInvalidLink     = errors.New("invalid link: must be a valid URL (e.g. https://example.com)")
+	ErrEmptyID         = errors.New("task ID cannot be empty")
+	ErrDuplicateID     = errors.New("task ID already exists")
+	ErrTaskNotFound    = errors.New("task not found")
+	ErrArchived        = errors.New("task is already archived")
+	ErrNotArchived     = errors.New("task is not archived")
+	ErrEmptyComment    = errors.New("comment cannot be empty")
+	ErrCommentNotFound = errors.New("comment not found")
+	ErrParentCycle     = errors.New("task cannot be its own ancestor")
+	ErrDependencyCycle = errors.New("dependency cycle")
+	ErrInvalidEstimate = errors.New("invalid estimate: must be a non-negative number")
+	ErrInvalidAssignee = errors.New("invalid assignee")
+	ErrInvalidField    = errors.New("invalid custom field")
+
+	ErrInvalidLinkTarget = errors.New("invalid link: use an http(s) URL or a repo-relative path such as internal/task/service.go:120-160")
+	ErrInvalidLinkKind   = errors.New("invalid link kind")
+	ErrDuplicateLink     = errors.New("task already has this link")
+	ErrLinkNotFound      = errors.New("link not found")
+
+	ErrInvalidRelation  = errors.New("invalid relation")
+	ErrRelationNotFound = errors.New("relation not found")
+
+	ErrInvalidSearch = errors.New("invalid search query")
+	ErrInvalidFilter = errors.New("invalid filter")
+
+	ErrInvalidView  = errors.New("invalid view")
+	ErrViewNotFound = errors.New("view not found")
 )
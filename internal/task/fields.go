@@ -0,0 +1,257 @@
+package task
+
+import (
+	"database/sql"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lucas-tremaroli/pace/internal/storage"
+)
+
+// FieldType is the type of a custom field value
+type FieldType string
+
+// Custom field types
+const (
+	FieldString FieldType = "string"
+	FieldNumber FieldType = "number"
+	FieldDate   FieldType = "date"
+	FieldBool   FieldType = "bool"
+)
+
+// FieldTypes lists the valid custom field types
+var FieldTypes = []FieldType{FieldString, FieldNumber, FieldDate, FieldBool}
+
+// Field is a typed custom field on a task. Value is the canonical text form:
+// numbers without trailing zeros, dates as YYYY-MM-DD, bools as true/false.
+type Field struct {
+	Key   string    `json:"key"`
+	Type  FieldType `json:"type"`
+	Value string    `json:"value"`
+}
+
+// ParseField parses "key=value" or "key:type=value". Without a type, it is
+// inferred from the value: true/false is a bool, a number is a number, a
+// YYYY-MM-DD date is a date, and anything else is a string. An empty value
+// means the field should be removed.
+func ParseField(s string) (Field, error) {
+	spec, value, ok := strings.Cut(s, "=")
+	if !ok {
+		return Field{}, fmt.Errorf("%w: %s (expected key=value or key:type=value)", ErrInvalidField, s)
+	}
+	key, typeName, typed := strings.Cut(strings.TrimSpace(spec), ":")
+	if !isName(key) {
+		return Field{}, fmt.Errorf("%w: key %q (use lowercase letters, digits, - and _)", ErrInvalidField, key)
+	}
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return Field{Key: key}, nil
+	}
+
+	fieldType := inferFieldType(value)
+	if typed {
+		fieldType = FieldType(typeName)
+	}
+	return newField(key, fieldType, value)
+}
+
+// newField checks value against the field type and stores it canonically
+func newField(key string, t FieldType, value string) (Field, error) {
+	canonical, err := canonicalFieldValue(t, value)
+	if err != nil {
+		return Field{}, fmt.Errorf("%w: %s: %v", ErrInvalidField, key, err)
+	}
+	return Field{Key: key, Type: t, Value: canonical}, nil
+}
+
+// FieldFromJSON builds a field from a decoded JSON value, as used in bulk
+// input. Numbers and bools keep their JSON type; strings are dates if they
+// look like one and strings otherwise.
+func FieldFromJSON(key string, v any) (Field, error) {
+	if !isName(key) {
+		return Field{}, fmt.Errorf("%w: key %q (use lowercase letters, digits, - and _)", ErrInvalidField, key)
+	}
+	switch v := v.(type) {
+	case float64:
+		return newField(key, FieldNumber, strconv.FormatFloat(v, 'f', -1, 64))
+	case bool:
+		return newField(key, FieldBool, strconv.FormatBool(v))
+	case string:
+		if _, err := time.Parse(time.DateOnly, v); err == nil {
+			return newField(key, FieldDate, v)
+		}
+		return newField(key, FieldString, v)
+	default:
+		return Field{}, fmt.Errorf("%w: %s: use a string, number or bool", ErrInvalidField, key)
+	}
+}
+
+func inferFieldType(value string) FieldType {
+	if value == "true" || value == "false" {
+		return FieldBool
+	}
+	if n, err := strconv.ParseFloat(value, 64); err == nil && !math.IsNaN(n) && !math.IsInf(n, 0) {
+		return FieldNumber
+	}
+	if _, err := time.Parse(time.DateOnly, value); err == nil {
+		return FieldDate
+	}
+	return FieldString
+}
+
+// canonicalFieldValue parses value as type t and formats it canonically
+func canonicalFieldValue(t FieldType, value string) (string, error) {
+	switch t {
+	case FieldString:
+		return value, nil
+	case FieldNumber:
+		n, err := strconv.ParseFloat(value, 64)
+		if err != nil || math.IsNaN(n) || math.IsInf(n, 0) {
+			return "", fmt.Errorf("%q is not a number", value)
+		}
+		return strconv.FormatFloat(n, 'f', -1, 64), nil
+	case FieldDate:
+		d, err := time.Parse(time.DateOnly, value)
+		if err != nil {
+			return "", fmt.Errorf("%q is not a date (use YYYY-MM-DD)", value)
+		}
+		return d.Format(time.DateOnly), nil
+	case FieldBool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return "", fmt.Errorf("%q is not a bool (use true or false)", value)
+		}
+		return strconv.FormatBool(b), nil
+	default:
+		return "", fmt.Errorf("unknown type %q (valid: string, number, date, bool)", t)
+	}
+}
+
+// JSONValue returns the value as its JSON type: a number for number
+// fields, a bool for bool fields, and a string otherwise
+func (f Field) JSONValue() any {
+	switch f.Type {
+	case FieldNumber:
+		if n, err := strconv.ParseFloat(f.Value, 64); err == nil {
+			return n
+		}
+	case FieldBool:
+		if b, err := strconv.ParseBool(f.Value); err == nil {
+			return b
+		}
+	}
+	return f.Value
+}
+
+// Matches reports whether the field equals value, read as the field's type
+func (f Field) Matches(value string) bool {
+	canonical, err := canonicalFieldValue(f.Type, strings.TrimSpace(value))
+	return err == nil && canonical == f.Value
+}
+
+func toFieldRecord(f Field) storage.FieldRecord {
+	return storage.FieldRecord{Key: f.Key, Type: string(f.Type), Value: f.Value}
+}
+
+func fromFieldRecords(records []storage.FieldRecord) []Field {
+	var fields []Field
+	for _, r := range records {
+		fields = append(fields, Field{Key: r.Key, Type: FieldType(r.Type), Value: r.Value})
+	}
+	return fields
+}
+
+// Fields returns the task's custom fields, by key
+func (t Task) Fields() []Field {
+	return t.fields
+}
+
+// Field returns a custom field of the task
+func (t Task) Field(key string) (Field, bool) {
+	i := slices.IndexFunc(t.fields, func(f Field) bool { return f.Key == key })
+	if i < 0 {
+		return Field{}, false
+	}
+	return t.fields[i], true
+}
+
+// SetFields sets the task's custom fields
+func (t *Task) SetFields(fields []Field) {
+	t.fields = fields
+}
+
+// fieldsJSON returns the custom fields as a JSON object, or nil if there
+// are none
+func fieldsJSON(fields []Field) map[string]any {
+	if len(fields) == 0 {
+		return nil
+	}
+	m := make(map[string]any, len(fields))
+	for _, f := range fields {
+		m[f.Key] = f.JSONValue()
+	}
+	return m
+}
+
+// SetField sets a custom field on a task, or removes it if the value is
+// empty
+func (s *Service) SetField(taskID string, f Field) error {
+	return s.Atomic(func() error { return s.setField(taskID, f) })
+}
+
+func (s *Service) setField(taskID string, f Field) error {
+	if f.Value != "" {
+		var err error
+		if f, err = newField(f.Key, f.Type, f.Value); err != nil {
+			return err
+		}
+	}
+	record, err := s.db.GetTaskByID(taskID)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("%w: %s", ErrTaskNotFound, taskID)
+	}
+	if err != nil {
+		return err
+	}
+	records, err := s.db.GetTaskFields(taskID)
+	if err != nil {
+		return err
+	}
+	var old string
+	for _, r := range records {
+		if r.Key == f.Key {
+			if r.Value == f.Value && r.Type == string(f.Type) {
+				return nil
+			}
+			old = r.Value
+		}
+	}
+	if old == "" && f.Value == "" {
+		return nil
+	}
+
+	done, err := s.track(taskID)
+	if err != nil {
+		return err
+	}
+	if f.Value == "" {
+		err = s.db.RemoveTaskField(taskID, f.Key)
+	} else {
+		err = s.db.SetTaskField(taskID, toFieldRecord(f))
+	}
+	if err != nil {
+		return err
+	}
+	record.UpdatedAt = formatTimestamp(now())
+	if err := s.db.UpdateTask(*record); err != nil {
+		return err
+	}
+	if err := s.recordEvent(taskID, EventUpdated, "field."+f.Key, old, f.Value); err != nil {
+		return err
+	}
+	return done()
+}
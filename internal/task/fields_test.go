@@ -0,0 +1,136 @@
+package task
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseField(t *testing.T) {
+	tests := []struct {
+		input string
+		want  Field
+	}{
+		{"component=auth", Field{"component", FieldString, "auth"}},
+		{"points=3.50", Field{"points", FieldNumber, "3.5"}},
+		{"customer=true", Field{"customer", FieldBool, "true"}},
+		{"launch=2026-11-01", Field{"launch", FieldDate, "2026-11-01"}},
+		{"version:string=2", Field{"version", FieldString, "2"}},
+		{"flag:bool=1", Field{"flag", FieldBool, "true"}},
+		{"nan=NaN", Field{"nan", FieldString, "NaN"}},
+		{"component=", Field{Key: "component"}},
+	}
+	for _, tt := range tests {
+		got, err := ParseField(tt.input)
+		if err != nil {
+			t.Errorf("ParseField(%q) unexpected error: %v", tt.input, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseField(%q) = %+v, want %+v", tt.input, got, tt.want)
+		}
+	}
+
+	for _, input := range []string{"component", "Component=auth", "points:number=many", "launch:date=soon", "x:color=red"} {
+		if _, err := ParseField(input); !errors.Is(err, ErrInvalidField) {
+			t.Errorf("ParseField(%q) expected ErrInvalidField, got %v", input, err)
+		}
+	}
+}
+
+func TestSetField(t *testing.T) {
+	svc := newTestService(t)
+
+	task := NewTaskComplete("test-aaa", Todo, TypeTask, "task", "", 3, "")
+	task.SetFields([]Field{{"component", FieldString, "auth"}})
+	if err := svc.CreateTask(task); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	other := NewTaskComplete("test-bbb", Todo, TypeTask, "other", "", 3, "")
+	if err := svc.CreateTask(other); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Backdate the task so the update below shows in updated_at
+	record, err := svc.db.GetTaskByID(task.ID())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	record.UpdatedAt = "2020-01-01T00:00:00Z"
+	if err := svc.db.UpdateTask(*record); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	points, _ := ParseField("points=3")
+	if err := svc.SetField(task.ID(), points); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got, err := svc.GetTaskByID(task.ID())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	fields := got.ToJSON().Fields
+	if fields["component"] != "auth" || fields["points"] != 3.0 {
+		t.Errorf("unexpected fields: %v", fields)
+	}
+	if got.UpdatedAt().Year() == 2020 {
+		t.Errorf("expected setting a field to bump updated_at, got %v", got.UpdatedAt())
+	}
+
+	tasks, err := svc.LoadTasks(false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, tt := range []struct {
+		filter string
+		want   string
+	}{
+		{"field.component=auth", task.ID()},
+		{"field.points=3.0", task.ID()},
+		{"field.component=", other.ID()},
+	} {
+		filter, err := ParseFilter(tt.filter)
+		if err != nil {
+			t.Fatalf("ParseFilter(%q) unexpected error: %v", tt.filter, err)
+		}
+		var matched []string
+		for _, task := range tasks {
+			if filter.Matches(task) {
+				matched = append(matched, task.ID())
+			}
+		}
+		if len(matched) != 1 || matched[0] != tt.want {
+			t.Errorf("filter %q matched %v, want [%s]", tt.filter, matched, tt.want)
+		}
+	}
+
+	// Removing a field can be undone
+	svc.BeginOperation("remove field")
+	if err := svc.SetField(task.ID(), Field{Key: "component"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, _ := svc.GetTaskByID(task.ID()); len(got.Fields()) != 1 {
+		t.Errorf("expected 1 field after removal, got %v", got.Fields())
+	}
+	if _, err := svc.Undo(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got, err = svc.GetTaskByID(task.ID())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if f, ok := got.Field("component"); !ok || f.Value != "auth" {
+		t.Errorf("expected component to be restored, got %v", got.Fields())
+	}
+
+	if err := svc.SetField("test-zzz", points); !errors.Is(err, ErrTaskNotFound) {
+		t.Errorf("expected ErrTaskNotFound, got %v", err)
+	}
+}
+
+func TestMergeFilters_DuplicateField(t *testing.T) {
+	a, _ := ParseFilter("field.component=auth")
+	b, _ := ParseFilter("field.component=billing")
+	if _, err := MergeFilters([]*TaskFilter{a, b}); err == nil {
+		t.Error("expected an error for a duplicate field filter")
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // TaskFilter represents criteria for filtering tasks
@@ -11,21 +12,65 @@ type TaskFilter struct {
 	Status   *Status
 	Type     *TaskType
 	Priority *int
-	Labels   []string // Multiple labels use AND semantics (task must have all)
+	Labels   []string   // Multiple labels use AND semantics (task must have all)
+	Since    *time.Time // Task was last updated at or after this time
+	Until    *time.Time // Task was last updated at or before this time
+	Archived *bool      // Unset matches both archived and active tasks
+	Assignee *string    // An empty assignee matches unassigned tasks
+
+	DueBefore *time.Time // Task is due before this date
+	DueAfter  *time.Time // Task is due after this date
+
+	// Fields maps custom field keys to the value they must have; an empty
+	// value matches tasks without the field
+	Fields map[string]string
+
+	// Exprs are query expressions the task must also match, for conditions
+	// the fields above cannot express (OR, NOT, in, ranges)
+	Exprs []Matcher
 }
 
-// ParseFilter parses a filter string in the format "key=value"
+// ParseFilter parses a filter query (see ParseQuery). A single "key=value",
+// "due<date" or "due>date" comparison sets the matching field of the filter;
+// anything else is kept as an expression in Exprs.
 func ParseFilter(s string) (*TaskFilter, error) {
-	parts := strings.SplitN(s, "=", 2)
-	if len(parts) != 2 {
-		return nil, fmt.Errorf("invalid filter format: %s (expected key=value)", s)
+	expr, err := ParseQuery(s)
+	if err != nil {
+		return nil, err
+	}
+	if f, ok := expr.(*TaskFilter); ok {
+		return f, nil
 	}
+	return &TaskFilter{Exprs: []Matcher{expr}}, nil
+}
 
-	key := strings.TrimSpace(parts[0])
-	value := strings.TrimSpace(parts[1])
+// ParseFilters parses several filter queries into one filter that requires
+// all of them, see MergeFilters
+func ParseFilters(queries []string) (*TaskFilter, error) {
+	var filters []*TaskFilter
+	for _, q := range queries {
+		f, err := ParseFilter(q)
+		if err != nil {
+			return nil, err
+		}
+		filters = append(filters, f)
+	}
+	return MergeFilters(filters)
+}
 
+// parseFilterTerm parses a single "key=value" comparison, or "field.<key>"
+// for custom fields
+func parseFilterTerm(key, value string) (*TaskFilter, error) {
 	filter := &TaskFilter{}
 
+	if fieldKey, ok := strings.CutPrefix(key, "field."); ok {
+		if !isName(fieldKey) {
+			return nil, fmt.Errorf("%w: key %q (use lowercase letters, digits, - and _)", ErrInvalidField, fieldKey)
+		}
+		filter.Fields = map[string]string{fieldKey: value}
+		return filter, nil
+	}
+
 	switch key {
 	case "status":
 		status, err := ParseStatus(value)
@@ -50,8 +95,20 @@ func ParseFilter(s string) (*TaskFilter, error) {
 		filter.Priority = &priority
 	case "label":
 		filter.Labels = []string{value}
+	case "assignee":
+		assignee, err := ParseAssignee(value)
+		if err != nil {
+			return nil, err
+		}
+		filter.Assignee = &assignee
+	case "archived":
+		archived, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid archived value: %s (expected true or false)", value)
+		}
+		filter.Archived = &archived
 	default:
-		return nil, fmt.Errorf("unknown filter key: %s (valid: status, type, priority, label)", key)
+		return nil, fmt.Errorf("unknown filter key: %s (valid: %s)", key, FilterKeys)
 	}
 
 	return filter, nil
@@ -74,9 +131,53 @@ func (f *TaskFilter) Matches(t Task) bool {
 			return false
 		}
 	}
+	if f.Since != nil && t.UpdatedAt().Before(*f.Since) {
+		return false
+	}
+	if f.Until != nil && t.UpdatedAt().After(*f.Until) {
+		return false
+	}
+	if f.Archived != nil && t.IsArchived() != *f.Archived {
+		return false
+	}
+	if f.Assignee != nil && t.Assignee() != *f.Assignee {
+		return false
+	}
+	// Tasks without a due date match neither bound
+	if f.DueBefore != nil && (t.Due().IsZero() || !t.Due().Before(*f.DueBefore)) {
+		return false
+	}
+	if f.DueAfter != nil && (t.Due().IsZero() || !t.Due().After(*f.DueAfter)) {
+		return false
+	}
+	for key, value := range f.Fields {
+		field, ok := t.Field(key)
+		if value == "" {
+			if ok {
+				return false
+			}
+		} else if !ok || !field.Matches(value) {
+			return false
+		}
+	}
+	for _, e := range f.Exprs {
+		if !e.Matches(t) {
+			return false
+		}
+	}
 	return true
 }
 
+// SelectsArchived reports whether the filter tests the archived flag
+// anywhere, in which case archived tasks must be loaded for it to match
+func (f *TaskFilter) SelectsArchived() bool {
+	found := false
+	walkFilters(f, func(leaf *TaskFilter) {
+		found = found || leaf.Archived != nil
+	})
+	return found
+}
+
 // MergeFilters combines multiple filters into one that requires all conditions.
 // Returns an error if duplicate status, type, or priority filters are specified.
 // Multiple label filters are allowed and use AND semantics (task must have all labels).
@@ -101,8 +202,65 @@ func MergeFilters(filters []*TaskFilter) (*TaskFilter, error) {
 			}
 			merged.Priority = f.Priority
 		}
+		if f.Since != nil {
+			if merged.Since != nil {
+				return nil, fmt.Errorf("duplicate filter: since specified multiple times")
+			}
+			merged.Since = f.Since
+		}
+		if f.Until != nil {
+			if merged.Until != nil {
+				return nil, fmt.Errorf("duplicate filter: until specified multiple times")
+			}
+			merged.Until = f.Until
+		}
+		if f.Archived != nil {
+			if merged.Archived != nil {
+				return nil, fmt.Errorf("duplicate filter: archived specified multiple times")
+			}
+			merged.Archived = f.Archived
+		}
+		if f.Assignee != nil {
+			if merged.Assignee != nil {
+				return nil, fmt.Errorf("duplicate filter: assignee specified multiple times")
+			}
+			merged.Assignee = f.Assignee
+		}
+		if f.DueBefore != nil {
+			if merged.DueBefore != nil {
+				return nil, fmt.Errorf("duplicate filter: due< specified multiple times")
+			}
+			merged.DueBefore = f.DueBefore
+		}
+		if f.DueAfter != nil {
+			if merged.DueAfter != nil {
+				return nil, fmt.Errorf("duplicate filter: due> specified multiple times")
+			}
+			merged.DueAfter = f.DueAfter
+		}
+		for key, value := range f.Fields {
+			if _, ok := merged.Fields[key]; ok {
+				return nil, fmt.Errorf("duplicate filter: field.%s specified multiple times", key)
+			}
+			if merged.Fields == nil {
+				merged.Fields = map[string]string{}
+			}
+			merged.Fields[key] = value
+		}
 		// Labels can be specified multiple times (AND semantics)
 		merged.Labels = append(merged.Labels, f.Labels...)
+		merged.Exprs = append(merged.Exprs, f.Exprs...)
 	}
 	return merged, nil
 }
+
+// AndFilters combines filters into one that matches tasks matching all of
+// them. Unlike MergeFilters, the filters may test the same keys, so a
+// saved view can be narrowed by a filter on a key it already tests.
+func AndFilters(filters ...*TaskFilter) *TaskFilter {
+	combined := &TaskFilter{}
+	for _, f := range filters {
+		combined.Exprs = append(combined.Exprs, f)
+	}
+	return combined
+}
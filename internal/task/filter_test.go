@@ -14,7 +14,8 @@ func TestParseFilter_Status(t *testing.T) {
 		{"todo", "status=todo", Todo, false},
 		{"in-progress", "status=in-progress", InProgress, false},
 		{"done", "status=done", Done, false},
-		{"invalid status", "status=invalid", 0, true},
+		{"custom status", "status=review", "review", false},
+		{"invalid status", "status=In Review", "", true},
 	}
 
 	for _, tt := range tests {
@@ -53,7 +54,8 @@ func TestParseFilter_Type(t *testing.T) {
 		{"feature", "type=feature", TypeFeature, false},
 		{"chore", "type=chore", TypeChore, false},
 		{"docs", "type=docs", TypeDocs, false},
-		{"invalid type", "type=invalid", 0, true},
+		{"custom", "type=spike", "spike", false},
+		{"invalid type", "type=Big Bug", "", true},
 	}
 
 	for _, tt := range tests {
@@ -151,6 +153,41 @@ func TestParseFilter_InvalidFormat(t *testing.T) {
 	}
 }
 
+func TestParseFilter_Due(t *testing.T) {
+	before, err := ParseFilter("due<2026-11-01")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	after, err := ParseFilter("due>2026-10-01")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	merged, err := MergeFilters([]*TaskFilter{before, after})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for due, want := range map[string]bool{
+		"":           false,
+		"2026-10-01": false,
+		"2026-10-15": true,
+		"2026-11-01": false,
+	} {
+		task := NewTaskComplete("test-aaa", Todo, TypeTask, "task", "", 3, "")
+		task.SetDue(parseDate(due))
+		if got := merged.Matches(task); got != want {
+			t.Errorf("due %q: Matches() = %v, want %v", due, got, want)
+		}
+	}
+
+	if _, err := ParseFilter("due<someday"); err == nil {
+		t.Error("ParseFilter(due<someday) expected error, got nil")
+	}
+	if _, err := MergeFilters([]*TaskFilter{before, before}); err == nil {
+		t.Error("MergeFilters() expected error for duplicate due<, got nil")
+	}
+}
+
 func TestTaskFilter_Matches(t *testing.T) {
 	// Create test tasks
 	todoTask := NewTaskComplete("t1", Todo, TypeFeature, "Todo Feature", "", 1, "")
@@ -334,6 +371,19 @@ func TestMergeFilters_MultipleLabelsAllowed(t *testing.T) {
 	}
 }
 
+func TestAndFilters_SameKey(t *testing.T) {
+	view, _ := ParseFilter("priority<=2")
+	filter, _ := ParseFilter("priority=2")
+
+	combined := AndFilters(view, filter)
+	for priority, want := range map[int]bool{1: false, 2: true, 3: false} {
+		task := NewTaskComplete("test-aaa", Todo, TypeTask, "task", "", priority, "")
+		if got := combined.Matches(task); got != want {
+			t.Errorf("AndFilters() Matches(priority %d) = %v, want %v", priority, got, want)
+		}
+	}
+}
+
 // Helper functions for creating pointers
 func ptr(s Status) *Status {
 	return &s
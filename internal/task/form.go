@@ -2,6 +2,7 @@ package task
 
 import (
 	"fmt"
+	"slices"
 
 	"github.com/charmbracelet/bubbles/help"
 	"github.com/charmbracelet/bubbles/key"
@@ -34,6 +35,7 @@ type Form struct {
 	board       *Board
 	focused     formField
 	isEdit      bool
+	id          string // ID of the task being edited
 }
 
 func NewForm(title, description string, board *Board) *Form {
@@ -70,15 +72,15 @@ func NewFormWithTask(t Task, board *Board) *Form {
 	form.priority = t.Priority()
 	form.link.SetValue(t.Link())
 	form.isEdit = true
+	form.id = t.ID()
 	return form
 }
 
+// CreateTask returns the task the form describes. An edited task keeps its
+// ID; a new one has none until the board creates it under a new ID, so
+// editing never uses up an ID.
 func (f Form) CreateTask() Task {
-	id := ""
-	if f.board != nil && f.board.service != nil {
-		id = f.board.service.GenerateTaskID()
-	}
-	return NewTaskComplete(id, f.col.status, f.taskType, f.title.Value(), f.description.Value(), f.priority, f.link.Value())
+	return NewTaskComplete(f.id, f.col.status, f.taskType, f.title.Value(), f.description.Value(), f.priority, f.link.Value())
 }
 
 func (f Form) Init() tea.Cmd {
@@ -169,15 +171,27 @@ func (f *Form) focusCurrentField() tea.Cmd {
 	return nil
 }
 
+// typeOptions returns the types the form offers, in registry order
+func (f Form) typeOptions() []TaskType {
+	if f.board != nil && f.board.types != nil {
+		return f.board.types.Types()
+	}
+	return BuiltinTypes.Types()
+}
+
 func (f Form) nextType() TaskType {
-	return (f.taskType + 1) % 5
+	types := f.typeOptions()
+	i := slices.Index(types, f.taskType)
+	return types[(i+1)%len(types)]
 }
 
 func (f Form) prevType() TaskType {
-	if f.taskType == 0 {
-		return 4
+	types := f.typeOptions()
+	i := slices.Index(types, f.taskType)
+	if i <= 0 {
+		return types[len(types)-1]
 	}
-	return f.taskType - 1
+	return types[i-1]
 }
 
 func (f Form) nextPriority() int {
@@ -317,24 +331,16 @@ func (f Form) View() string {
 }
 
 func (f Form) renderTypeOptions(normalStyle, selectedStyle lipgloss.Style) string {
-	types := []struct {
-		t    TaskType
-		name string
-	}{
-		{TypeTask, "task"},
-		{TypeBug, "bug"},
-		{TypeFeature, "feature"},
-		{TypeChore, "chore"},
-		{TypeDocs, "docs"},
-	}
-
 	var parts []string
-	for _, opt := range types {
+	for i, t := range f.typeOptions() {
+		if i > 0 {
+			parts = append(parts, " ")
+		}
 		style := normalStyle
-		if opt.t == f.taskType {
+		if t == f.taskType {
 			style = selectedStyle
 		}
-		parts = append(parts, style.Render(opt.name))
+		parts = append(parts, style.Render(t.String()))
 	}
 
 	arrows := ""
@@ -344,7 +350,7 @@ func (f Form) renderTypeOptions(normalStyle, selectedStyle lipgloss.Style) strin
 		arrows = "     "
 	}
 
-	return fmt.Sprintf("[%s]%s", lipgloss.JoinHorizontal(lipgloss.Left, parts[0], " ", parts[1], " ", parts[2], " ", parts[3], " ", parts[4]), arrows)
+	return fmt.Sprintf("[%s]%s", lipgloss.JoinHorizontal(lipgloss.Left, parts...), arrows)
 }
 
 func (f Form) renderPriorityOptions(normalStyle, selectedStyle lipgloss.Style) string {
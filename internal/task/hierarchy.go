@@ -0,0 +1,123 @@
+package task
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// Progress is the roll-up of a parent task's direct subtasks. Archived
+// subtasks are not counted.
+type Progress struct {
+	Done    int `json:"done"`
+	Total   int `json:"total"`
+	Percent int `json:"percent"`
+}
+
+// addChild counts a subtask towards the progress. Subtasks in a terminal
+// status are done.
+func (p *Progress) addChild(terminal, archived bool) {
+	if archived {
+		return
+	}
+	p.Total++
+	if terminal {
+		p.Done++
+	}
+	p.Percent = p.Done * 100 / p.Total
+}
+
+// linkHierarchy fills in the children and progress of every task in the
+// slice from the parent IDs of the others
+func linkHierarchy(tasks []Task, w Workflow) {
+	index := make(map[string]int, len(tasks))
+	for i, t := range tasks {
+		index[t.ID()] = i
+	}
+	for _, t := range tasks {
+		i, ok := index[t.parentID]
+		if t.parentID == "" || !ok {
+			continue
+		}
+		parent := &tasks[i]
+		parent.children = append(parent.children, t.ID())
+		if parent.progress == nil {
+			parent.progress = &Progress{}
+		}
+		parent.progress.addChild(w.IsTerminal(t.Status()), t.IsArchived())
+	}
+}
+
+// SetParent makes a task a subtask of parentID, or a top-level task if
+// parentID is empty. A task cannot become a subtask of itself or of any of
+// its own subtasks.
+func (s *Service) SetParent(taskID, parentID string) error {
+	return s.Atomic(func() error { return s.setParent(taskID, parentID) })
+}
+
+func (s *Service) setParent(taskID, parentID string) error {
+	record, err := s.db.GetTaskByID(taskID)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("%w: %s", ErrTaskNotFound, taskID)
+	}
+	if err != nil {
+		return err
+	}
+	if record.ParentID == parentID {
+		return nil
+	}
+	if parentID != "" {
+		if err := s.checkParent(taskID, parentID); err != nil {
+			return err
+		}
+	}
+
+	done, err := s.track(taskID)
+	if err != nil {
+		return err
+	}
+	before := *record
+	record.ParentID = parentID
+	record.UpdatedAt = formatTimestamp(now())
+	if err := s.db.UpdateTask(*record); err != nil {
+		return err
+	}
+	if err := s.recordFieldChanges(before, *record); err != nil {
+		return err
+	}
+	return done()
+}
+
+// checkParent verifies that parentID exists and is not taskID or one of
+// its subtasks
+func (s *Service) checkParent(taskID, parentID string) error {
+	for id := parentID; id != ""; {
+		if id == taskID {
+			return fmt.Errorf("%w: %s is a subtask of %s", ErrParentCycle, parentID, taskID)
+		}
+		record, err := s.db.GetTaskByID(id)
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("%w: parent %s", ErrTaskNotFound, id)
+		}
+		if err != nil {
+			return err
+		}
+		id = record.ParentID
+	}
+	return nil
+}
+
+// Children returns the direct subtasks of a task, leaving out archived
+// ones unless includeArchived is set
+func (s *Service) Children(parentID string, includeArchived bool) ([]Task, error) {
+	tasks, err := s.LoadTasks(includeArchived)
+	if err != nil {
+		return nil, err
+	}
+	var children []Task
+	for _, t := range tasks {
+		if t.ParentID() == parentID {
+			children = append(children, t)
+		}
+	}
+	return children, nil
+}
@@ -0,0 +1,132 @@
+package task
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+// createHierarchy creates an epic with three subtasks, one of them done
+func createHierarchy(t *testing.T, svc *Service) (epic string, subtasks []string) {
+	t.Helper()
+	epicTask := NewTaskComplete("test-epc", Todo, TypeFeature, "epic", "", 2, "")
+	if err := svc.CreateTask(epicTask); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for i, id := range []string{"test-s01", "test-s02", "test-s03"} {
+		status := Todo
+		if i == 0 {
+			status = Done
+		}
+		sub := NewTaskComplete(id, status, TypeTask, "subtask", "", 3, "")
+		sub.SetParentID(epicTask.ID())
+		if err := svc.CreateTask(sub); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		subtasks = append(subtasks, id)
+	}
+	return epicTask.ID(), subtasks
+}
+
+func TestHierarchy_Progress(t *testing.T) {
+	svc := newTestService(t)
+	epic, subtasks := createHierarchy(t, svc)
+
+	got, err := svc.GetTaskByID(epic)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !slices.Equal(got.Children(), subtasks) {
+		t.Errorf("expected children %v, got %v", subtasks, got.Children())
+	}
+	want := Progress{Done: 1, Total: 3, Percent: 33}
+	if got.Progress() == nil || *got.Progress() != want {
+		t.Errorf("expected progress %+v, got %+v", want, got.Progress())
+	}
+
+	// Archived subtasks do not count, and LoadAllTasks links the same way
+	if err := svc.ArchiveTask(subtasks[2]); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	tasks, err := svc.LoadAllTasks()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, task := range tasks {
+		if task.ID() != epic {
+			continue
+		}
+		want := Progress{Done: 1, Total: 2, Percent: 50}
+		if task.Progress() == nil || *task.Progress() != want {
+			t.Errorf("expected progress %+v, got %+v", want, task.Progress())
+		}
+	}
+
+	children, err := svc.Children(epic, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(children) != 2 {
+		t.Errorf("expected 2 unarchived children, got %d", len(children))
+	}
+}
+
+func TestSetParent_RejectsCycles(t *testing.T) {
+	svc := newTestService(t)
+	epic, subtasks := createHierarchy(t, svc)
+
+	if err := svc.SetParent(subtasks[1], subtasks[0]); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, parent := range []string{epic, subtasks[0], subtasks[1]} {
+		if err := svc.SetParent(epic, parent); !errors.Is(err, ErrParentCycle) {
+			t.Errorf("setting parent of %s to %s: expected ErrParentCycle, got %v", epic, parent, err)
+		}
+	}
+	if err := svc.SetParent(epic, "test-zzz"); !errors.Is(err, ErrTaskNotFound) {
+		t.Errorf("expected ErrTaskNotFound for a missing parent, got %v", err)
+	}
+
+	// Clearing the parent makes the task top-level again
+	if err := svc.SetParent(subtasks[1], ""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got, err := svc.GetTaskByID(subtasks[1])
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.ParentID() != "" {
+		t.Errorf("expected no parent, got %s", got.ParentID())
+	}
+}
+
+func TestDeleteTask_OrphansChildrenAndUndoRestores(t *testing.T) {
+	svc := newTestService(t)
+	epic, subtasks := createHierarchy(t, svc)
+
+	svc.BeginOperation("task delete")
+	if err := svc.DeleteTask(epic); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, id := range subtasks {
+		got, err := svc.GetTaskByID(id)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got.ParentID() != "" {
+			t.Errorf("expected %s to become top-level, got parent %s", id, got.ParentID())
+		}
+	}
+
+	if _, err := svc.Undo(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got, err := svc.GetTaskByID(epic)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !slices.Equal(got.Children(), subtasks) {
+		t.Errorf("expected undo to restore children %v, got %v", subtasks, got.Children())
+	}
+}
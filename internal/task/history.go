@@ -0,0 +1,105 @@
+package task
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/lucas-tremaroli/pace/internal/storage"
+)
+
+// Event actions recorded in the task history
+const (
+	EventCreated           = "created"
+	EventUpdated           = "updated"
+	EventDeleted           = "deleted"
+	EventLabelAdded        = "label_added"
+	EventLabelRemoved      = "label_removed"
+	EventDependencyAdded   = "dependency_added"
+	EventDependencyRemoved = "dependency_removed"
+	EventUndone            = "undone"
+	EventRedone            = "redone"
+	EventArchived          = "archived"
+	EventRestored          = "restored"
+	EventCommentAdded      = "comment_added"
+	EventCommentDeleted    = "comment_deleted"
+	EventLinkAdded         = "link_added"
+	EventLinkRemoved       = "link_removed"
+	EventRelationAdded     = "relation_added"
+	EventRelationRemoved   = "relation_removed"
+)
+
+// Event is a single entry in the task history
+type Event = storage.EventRecord
+
+// recordEvent appends an entry to the task history
+func (s *Service) recordEvent(taskID, action, field, oldValue, newValue string) error {
+	err := s.db.AddEvent(storage.EventRecord{
+		TaskID:    taskID,
+		Action:    action,
+		Field:     field,
+		OldValue:  oldValue,
+		NewValue:  newValue,
+		Actor:     Actor(),
+		CreatedAt: formatTimestamp(now()),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record history: %w", err)
+	}
+	return nil
+}
+
+// recordFieldChanges records one update event per field that differs
+func (s *Service) recordFieldChanges(before, after storage.TaskRecord) error {
+	types, err := s.Types()
+	if err != nil {
+		return err
+	}
+	for _, c := range diffRecords(before, after, types) {
+		if err := s.recordEvent(after.ID, EventUpdated, c.field, c.oldValue, c.newValue); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fieldChange is a single field that differs between two task records
+type fieldChange struct {
+	field    string
+	oldValue string
+	newValue string
+}
+
+// diffRecords lists user-visible fields that differ between two records.
+// Timestamps are maintained automatically and are not reported.
+func diffRecords(before, after storage.TaskRecord, types TypeRegistry) []fieldChange {
+	var changes []fieldChange
+	add := func(field, oldValue, newValue string) {
+		if oldValue != newValue {
+			changes = append(changes, fieldChange{field, oldValue, newValue})
+		}
+	}
+	add("title", before.Title, after.Title)
+	add("description", before.Description, after.Description)
+	add("status", Status(before.Status).String(), Status(after.Status).String())
+	add("type", types.byCode(before.TaskType).String(), types.byCode(after.TaskType).String())
+	add("priority", strconv.Itoa(before.Priority), strconv.Itoa(after.Priority))
+	add("link", before.Link, after.Link)
+	add("parent", before.ParentID, after.ParentID)
+	add("due", before.DueDate, after.DueDate)
+	add("estimate", formatEstimate(before.Estimate), formatEstimate(after.Estimate))
+	add("assignee", before.Assignee, after.Assignee)
+	return changes
+}
+
+// History returns every recorded change to a task, oldest first.
+// History is kept after a task is deleted.
+func (s *Service) History(taskID string) ([]Event, error) {
+	return s.db.GetEvents(taskID)
+}
+
+// EventsSince returns changes to any task at or after the given time, oldest
+// first. A limit of 0 returns all of them; otherwise the most recent limit.
+func (s *Service) EventsSince(since time.Time, limit int) ([]Event, error) {
+	return s.db.GetEventsSince(formatTimestamp(since), limit)
+}
@@ -0,0 +1,101 @@
+package task
+
+import (
+	"testing"
+)
+
+func TestHistory_RecordsChanges(t *testing.T) {
+	t.Setenv(EnvActor, "agent-1")
+	svc := newTestService(t)
+
+	task := NewTaskComplete(newTestID(t, svc), Todo, TypeTask, "original", "", 3, "")
+	if err := svc.CreateTask(task); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	task = NewTaskComplete(task.ID(), InProgress, TypeTask, "renamed", "", 3, "")
+	if err := svc.UpdateTask(task); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := svc.AddLabel(task.ID(), "auth"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// Adding the same label again is not a change
+	if err := svc.AddLabel(task.ID(), "auth"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := svc.RemoveLabel(task.ID(), "auth"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := svc.DeleteTask(task.ID()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	events, err := svc.History(task.ID())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []struct {
+		action, field, oldValue, newValue string
+	}{
+		{EventCreated, "", "", "original"},
+		{EventUpdated, "title", "original", "renamed"},
+		{EventUpdated, "status", "todo", "in-progress"},
+		{EventLabelAdded, "label", "", "auth"},
+		{EventLabelRemoved, "label", "auth", ""},
+		{EventDeleted, "", "renamed", ""},
+	}
+	if len(events) != len(want) {
+		t.Fatalf("expected %d events, got %d: %+v", len(want), len(events), events)
+	}
+	for i, w := range want {
+		e := events[i]
+		if e.Action != w.action || e.Field != w.field || e.OldValue != w.oldValue || e.NewValue != w.newValue {
+			t.Errorf("event %d = %+v, want %+v", i, e, w)
+		}
+		if e.Actor != "agent-1" {
+			t.Errorf("event %d actor = %q, want agent-1", i, e.Actor)
+		}
+	}
+}
+
+func TestHistory_Dependencies(t *testing.T) {
+	svc := newTestService(t)
+
+	a := NewTaskComplete("test-a", Todo, TypeTask, "a", "", 3, "")
+	b := NewTaskComplete("test-b", Todo, TypeTask, "b", "", 3, "")
+	for _, task := range []Task{a, b} {
+		if err := svc.CreateTask(task); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if err := svc.AddDependency("test-a", "test-b"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := svc.RemoveDependency("test-a", "test-b"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	events, err := svc.History("test-b")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(events) != 3 {
+		t.Fatalf("expected 3 events for blocked task, got %d: %+v", len(events), events)
+	}
+	if events[1].Action != EventDependencyAdded || events[1].NewValue != "test-a" {
+		t.Errorf("unexpected dependency event: %+v", events[1])
+	}
+	if events[2].Action != EventDependencyRemoved || events[2].OldValue != "test-a" {
+		t.Errorf("unexpected dependency event: %+v", events[2])
+	}
+
+	all, err := svc.EventsSince(a.CreatedAt(), 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(all) != 6 {
+		t.Errorf("expected 6 events in store, got %d", len(all))
+	}
+}
@@ -4,8 +4,10 @@ import (
 	"crypto/rand"
 	"database/sql"
 	"encoding/hex"
+	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 
 	"github.com/lucas-tremaroli/pace/internal/storage"
@@ -14,23 +16,156 @@ import (
 const (
 	// DefaultPrefix is used when no prefix is configured
 	DefaultPrefix = "task"
-	// IDLength is the number of random hex characters after the prefix
+	// IDLength is the default number of random hex characters after the prefix
 	IDLength = 3
+	// MaxIDLength is the longest random suffix id_length may grow to
+	MaxIDLength = 12
 	// ConfigKeyPrefix is the config key for the ID prefix
 	ConfigKeyPrefix = "id_prefix"
+	// ConfigKeyIDLength is the config key for the random suffix length
+	ConfigKeyIDLength = "id_length"
+	// ConfigKeyIDScheme is the config key for the ID scheme
+	ConfigKeyIDScheme = "id_scheme"
+	// ConfigKeyIDSequence is the config key for the last sequential ID issued
+	ConfigKeyIDSequence = "id_sequence"
 )
 
-// GenerateID creates a new short hash ID like "prefix-a1b"
-func GenerateID(prefix string) string {
-	bytes := make([]byte, 2) // 2 bytes = 4 hex chars, we'll use 3
+// ID schemes
+const (
+	// IDSchemeRandom produces IDs like "prefix-a1b"
+	IDSchemeRandom = "random"
+	// IDSchemeSequential produces IDs like "prefix-42"
+	IDSchemeSequential = "sequential"
+)
+
+// idAttempts is how many random IDs are tried before the length is grown
+const idAttempts = 8
+
+// GenerateID creates a new short hash ID like "prefix-a1b" with length
+// random hex characters
+func GenerateID(prefix string, length int) string {
+	bytes := make([]byte, (length+1)/2)
 	if _, err := rand.Read(bytes); err != nil {
 		// Fallback - this should never happen in practice
 		panic("failed to generate random bytes: " + err.Error())
 	}
-	hash := hex.EncodeToString(bytes)[:IDLength]
+	hash := hex.EncodeToString(bytes)[:length]
 	return prefix + "-" + hash
 }
 
+// NextID returns an unused task ID according to the configured scheme.
+// Random IDs are checked for collisions and retried; when the ID space for
+// the current length is half full, or keeps colliding, id_length grows by
+// one. Sequential IDs advance id_sequence, skipping any that are taken.
+func NextID(db *storage.DB, prefix string) (string, error) {
+	scheme, err := configValue(db, ConfigKeyIDScheme, IDSchemeRandom)
+	if err != nil {
+		return "", err
+	}
+
+	switch scheme {
+	case IDSchemeRandom:
+		return nextRandomID(db, prefix)
+	case IDSchemeSequential:
+		return nextSequentialID(db, prefix)
+	default:
+		return "", fmt.Errorf("invalid %s %q: must be %s or %s", ConfigKeyIDScheme, scheme, IDSchemeRandom, IDSchemeSequential)
+	}
+}
+
+// nextRandomID returns an unused random ID, growing id_length as needed
+func nextRandomID(db *storage.DB, prefix string) (string, error) {
+	length, err := configInt(db, ConfigKeyIDLength, IDLength)
+	if err != nil {
+		return "", err
+	}
+	if length < 1 || length > MaxIDLength {
+		return "", fmt.Errorf("invalid %s %d: must be between 1 and %d", ConfigKeyIDLength, length, MaxIDLength)
+	}
+
+	for ; length <= MaxIDLength; length++ {
+		// Keep the space at most half full so collisions stay rare
+		used, err := db.CountTaskIDs(prefix+"-", len(prefix)+1+length)
+		if err != nil {
+			return "", err
+		}
+		if length < MaxIDLength && used >= idSpace(length)/2 {
+			if err := db.SetConfig(ConfigKeyIDLength, strconv.Itoa(length+1)); err != nil {
+				return "", err
+			}
+			continue
+		}
+
+		for range idAttempts {
+			id := GenerateID(prefix, length)
+			exists, err := db.TaskExists(id)
+			if err != nil {
+				return "", err
+			}
+			if !exists {
+				return id, nil
+			}
+		}
+
+		if length < MaxIDLength {
+			if err := db.SetConfig(ConfigKeyIDLength, strconv.Itoa(length+1)); err != nil {
+				return "", err
+			}
+		}
+	}
+	return "", fmt.Errorf("failed to generate a unique task ID after %d attempts", idAttempts)
+}
+
+// idSpace returns the number of distinct random suffixes of a length
+func idSpace(length int) int {
+	return 1 << (4 * length)
+}
+
+// nextSequentialID advances id_sequence and returns the first unused ID
+func nextSequentialID(db *storage.DB, prefix string) (string, error) {
+	seq, err := configInt(db, ConfigKeyIDSequence, 0)
+	if err != nil {
+		return "", err
+	}
+
+	for {
+		seq++
+		id := prefix + "-" + strconv.Itoa(seq)
+		exists, err := db.TaskExists(id)
+		if err != nil {
+			return "", err
+		}
+		if !exists {
+			if err := db.SetConfig(ConfigKeyIDSequence, strconv.Itoa(seq)); err != nil {
+				return "", err
+			}
+			return id, nil
+		}
+	}
+}
+
+// configValue returns a config value, or def if it is not set
+func configValue(db *storage.DB, key, def string) (string, error) {
+	value, err := db.GetConfig(key)
+	if err == sql.ErrNoRows || (err == nil && value == "") {
+		return def, nil
+	}
+	return value, err
+}
+
+// configInt returns an integer config value, or def if it is not set
+func configInt(db *storage.DB, key string, def int) (int, error) {
+	value, err := configValue(db, key, strconv.Itoa(def))
+	if err != nil {
+		return 0, err
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s %q: must be a number", key, value)
+	}
+	return n, nil
+}
+
 // GetOrInitPrefix returns the configured prefix, initializing it if needed
 func GetOrInitPrefix(db *storage.DB) (string, error) {
 	// Try to get existing prefix
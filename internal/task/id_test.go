@@ -0,0 +1,165 @@
+package task
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/lucas-tremaroli/pace/internal/storage"
+)
+
+func TestGenerateID_Length(t *testing.T) {
+	for _, length := range []int{1, 3, 4, 7} {
+		id := GenerateID("pace", length)
+		if len(id) != len("pace-")+length {
+			t.Errorf("expected %d random characters, got %q", length, id)
+		}
+	}
+}
+
+func TestNextID_GrowsWhenSpaceFills(t *testing.T) {
+	svc := newTestService(t)
+	if err := svc.db.SetConfig(ConfigKeyIDLength, "1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// 16 one-character IDs exist, so the length must grow before half are used
+	seen := make(map[string]bool)
+	for i := 0; i < 20; i++ {
+		id := newTestID(t, svc)
+		if seen[id] {
+			t.Fatalf("duplicate ID %s", id)
+		}
+		seen[id] = true
+		if err := svc.CreateTask(NewTaskComplete(id, Todo, TypeTask, "task", "", 3, "")); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	length, err := svc.db.GetConfig(ConfigKeyIDLength)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if length != "2" {
+		t.Errorf("expected id_length to grow to 2, got %s", length)
+	}
+}
+
+func TestNextID_Sequential(t *testing.T) {
+	svc := newTestService(t)
+	if err := svc.db.SetConfig(ConfigKeyIDScheme, IDSchemeSequential); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// An existing task with the next number is skipped
+	if err := svc.CreateTask(NewTaskComplete("test-2", Todo, TypeTask, "taken", "", 3, "")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var ids []string
+	for i := 0; i < 3; i++ {
+		id := newTestID(t, svc)
+		if err := svc.CreateTask(NewTaskComplete(id, Todo, TypeTask, "task", "", 3, "")); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		ids = append(ids, id)
+	}
+
+	if got := strings.Join(ids, ","); got != "test-1,test-3,test-4" {
+		t.Errorf("expected test-1,test-3,test-4, got %s", got)
+	}
+}
+
+func TestNextID_InvalidScheme(t *testing.T) {
+	svc := newTestService(t)
+	if err := svc.db.SetConfig(ConfigKeyIDScheme, "uuid"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := svc.GenerateTaskID(); err == nil {
+		t.Error("expected error for unknown id_scheme")
+	}
+}
+
+func TestCreateNewTask_ConcurrentWriters(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tasks.db")
+	var services []*Service
+	for range 2 {
+		db, err := storage.NewDBWithPath(path)
+		if err != nil {
+			t.Fatalf("failed to open database: %v", err)
+		}
+		svc := NewServiceWithDB(db, "test")
+		t.Cleanup(func() { svc.Close() })
+		services = append(services, svc)
+	}
+	if err := services[0].db.SetConfig(ConfigKeyIDScheme, IDSchemeSequential); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Two processes creating tasks at once never pick the same ID
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	seen := make(map[string]bool)
+	for _, svc := range services {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 10 {
+				created, err := svc.CreateNewTask(NewTaskComplete("", Todo, TypeTask, "task", "", 3, ""))
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+					return
+				}
+				mu.Lock()
+				if seen[created.ID()] {
+					t.Errorf("duplicate ID %s", created.ID())
+				}
+				seen[created.ID()] = true
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	if len(seen) != 20 {
+		t.Errorf("expected 20 tasks, got %d", len(seen))
+	}
+}
+
+func TestCreateTask_DuplicateID(t *testing.T) {
+	svc := newTestService(t)
+	task := NewTaskComplete("test-abc", Todo, TypeTask, "first", "", 3, "")
+	if err := svc.CreateTask(task); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	err := svc.CreateTask(NewTaskComplete("test-abc", Todo, TypeTask, "second", "", 3, ""))
+	if !errors.Is(err, ErrDuplicateID) {
+		t.Errorf("expected ErrDuplicateID, got %v", err)
+	}
+}
+
+func TestFormCreateTask_EditKeepsID(t *testing.T) {
+	svc := newTestService(t)
+	if err := svc.db.SetConfig(ConfigKeyIDScheme, IDSchemeSequential); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	board := &Board{service: svc}
+
+	form := NewForm("task", "", board)
+	form.col.status = Todo
+	created, err := svc.CreateNewTask(form.CreateTask())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Editing keeps the task's ID and does not advance the sequence
+	for i := 0; i < 2; i++ {
+		if got := NewFormWithTask(created, board).CreateTask().ID(); got != created.ID() {
+			t.Errorf("expected edited task to keep %s, got %s", created.ID(), got)
+		}
+	}
+	if id := newTestID(t, svc); created.ID() != "test-1" || id != "test-2" {
+		t.Errorf("expected test-1 then test-2, got %s then %s", created.ID(), id)
+	}
+}
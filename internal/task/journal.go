@@ -0,0 +1,371 @@
+package task
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/lucas-tremaroli/pace/internal/storage"
+)
+
+// journalLimit is the number of operations kept for undo
+const journalLimit = 100
+
+// Errors returned by undo and redo
+var (
+	ErrNothingToUndo = errors.New("nothing to undo")
+	ErrNothingToRedo = errors.New("nothing to redo")
+)
+
+// ConflictError is returned when a task changed after the operation being
+// undone or redone, so restoring it would discard someone else's work
+type ConflictError struct {
+	OperationID int64
+	TaskID      string
+}
+
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("task %s was changed after operation %d; refusing to overwrite it", e.TaskID, e.OperationID)
+}
+
+// Operation describes an undone or redone journal entry
+type Operation struct {
+	storage.OperationRecord
+	Tasks []string `json:"tasks"`
+}
+
+// snapshot is the complete state of a task as stored in the journal
+type snapshot struct {
+	Task      storage.TaskRecord       `json:"task"`
+	Labels    []string                 `json:"labels"`
+	BlockedBy []string                 `json:"blocked_by"`
+	Blocks    []string                 `json:"blocks"`
+	Comments  []Comment                `json:"comments,omitempty"`
+	Fields    []storage.FieldRecord    `json:"fields,omitempty"`
+	Links     []Link                   `json:"links,omitempty"`
+	Relations []storage.RelationRecord `json:"relations,omitempty"`
+}
+
+// BeginOperation starts a new undoable operation. Every mutation made
+// through the service until the next call is undone or redone as a unit.
+// Mutations made before any call are not journaled.
+func (s *Service) BeginOperation(command string) {
+	s.opCommand = command
+	s.opID = 0
+	s.opTracked = make(map[string]bool)
+}
+
+// track records the state of tasks before they are changed by the current
+// operation, and returns a function that records their state afterwards
+func (s *Service) track(taskIDs ...string) (func() error, error) {
+	if s.opCommand == "" {
+		return func() error { return nil }, nil
+	}
+
+	if s.opID == 0 {
+		id, err := s.db.CreateOperation(s.opCommand, Actor(), formatTimestamp(now()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to record operation: %w", err)
+		}
+		if err := s.db.PruneOperations(journalLimit); err != nil {
+			return nil, fmt.Errorf("failed to record operation: %w", err)
+		}
+		s.opID = id
+	}
+
+	for _, id := range taskIDs {
+		if s.opTracked[id] {
+			continue
+		}
+		before, err := s.snapshot(id)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.db.AddOperationTask(s.opID, id, before); err != nil {
+			return nil, fmt.Errorf("failed to record operation: %w", err)
+		}
+		s.opTracked[id] = true
+	}
+
+	return func() error {
+		for _, id := range taskIDs {
+			after, err := s.snapshot(id)
+			if err != nil {
+				return err
+			}
+			if err := s.db.SetOperationTaskAfter(s.opID, id, after); err != nil {
+				return fmt.Errorf("failed to record operation: %w", err)
+			}
+		}
+		return nil
+	}, nil
+}
+
+// snapshot serializes the current state of a task, or returns an empty
+// string if the task does not exist
+func (s *Service) snapshot(taskID string) (string, error) {
+	record, err := s.db.GetTaskByID(taskID)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	labels, err := s.db.GetLabels(taskID)
+	if err != nil {
+		return "", err
+	}
+	blockedBy, err := s.db.GetBlockers(taskID)
+	if err != nil {
+		return "", err
+	}
+	blocks, err := s.db.GetBlocking(taskID)
+	if err != nil {
+		return "", err
+	}
+	comments, err := s.db.GetComments(taskID)
+	if err != nil {
+		return "", err
+	}
+	fields, err := s.db.GetTaskFields(taskID)
+	if err != nil {
+		return "", err
+	}
+	links, err := s.db.GetLinks(taskID)
+	if err != nil {
+		return "", err
+	}
+	relations, err := s.db.GetRelations(taskID)
+	if err != nil {
+		return "", err
+	}
+	slices.Sort(blockedBy)
+	slices.Sort(blocks)
+
+	data, err := json.Marshal(snapshot{Task: *record, Labels: labels, BlockedBy: blockedBy, Blocks: blocks, Comments: comments, Fields: fields, Links: links, Relations: relations})
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// Undo reverts the most recent operation
+func (s *Service) Undo() (*Operation, error) {
+	op, err := s.db.LatestDoneOperation()
+	if err == sql.ErrNoRows {
+		return nil, ErrNothingToUndo
+	}
+	if err != nil {
+		return nil, err
+	}
+	var result *Operation
+	err = s.Atomic(func() error {
+		result, err = s.replay(op, true)
+		return err
+	})
+	return result, err
+}
+
+// Redo reapplies the most recently undone operation
+func (s *Service) Redo() (*Operation, error) {
+	op, err := s.db.EarliestUndoneOperation()
+	if err == sql.ErrNoRows {
+		return nil, ErrNothingToRedo
+	}
+	if err != nil {
+		return nil, err
+	}
+	var result *Operation
+	err = s.Atomic(func() error {
+		result, err = s.replay(op, false)
+		return err
+	})
+	return result, err
+}
+
+// replay restores every task touched by an operation to its state before
+// (undo) or after (redo) the operation
+func (s *Service) replay(op *storage.OperationRecord, undo bool) (*Operation, error) {
+	records, err := s.db.GetOperationTasks(op.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Refuse if anything changed since, so later work is never clobbered
+	for _, r := range records {
+		expected := r.After
+		if !undo {
+			expected = r.Before
+		}
+		current, err := s.snapshot(r.TaskID)
+		if err != nil {
+			return nil, err
+		}
+		if current != expected {
+			return nil, &ConflictError{OperationID: op.ID, TaskID: r.TaskID}
+		}
+	}
+
+	targets := make(map[string]*snapshot)
+	for _, r := range records {
+		data := r.Before
+		if !undo {
+			data = r.After
+		}
+		if data == "" {
+			targets[r.TaskID] = nil
+			continue
+		}
+		var snap snapshot
+		if err := json.Unmarshal([]byte(data), &snap); err != nil {
+			return nil, fmt.Errorf("corrupt journal entry for task %s: %w", r.TaskID, err)
+		}
+		targets[r.TaskID] = &snap
+	}
+
+	// Restore task rows first so relationships can refer to any of them
+	for _, r := range records {
+		if err := s.restoreTask(r.TaskID, targets[r.TaskID]); err != nil {
+			return nil, err
+		}
+	}
+	for _, r := range records {
+		if snap := targets[r.TaskID]; snap != nil {
+			if err := s.restoreRelations(r.TaskID, snap); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	state, action := storage.OperationUndone, EventUndone
+	if !undo {
+		state, action = storage.OperationDone, EventRedone
+	}
+	if err := s.db.SetOperationState(op.ID, state); err != nil {
+		return nil, err
+	}
+
+	result := &Operation{OperationRecord: *op}
+	result.State = state
+	for _, r := range records {
+		result.Tasks = append(result.Tasks, r.TaskID)
+		if err := s.recordEvent(r.TaskID, action, "", "", op.Command); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// restoreTask makes the task row match a snapshot, deleting it if the
+// snapshot is nil
+func (s *Service) restoreTask(taskID string, snap *snapshot) error {
+	_, err := s.db.GetTaskByID(taskID)
+	exists := err == nil
+	if err != nil && err != sql.ErrNoRows {
+		return err
+	}
+
+	if snap == nil {
+		if !exists {
+			return nil
+		}
+		if err := s.db.RemoveAllDependencies(taskID); err != nil {
+			return err
+		}
+		if err := s.db.RemoveAllLabels(taskID); err != nil {
+			return err
+		}
+		if err := s.db.RemoveAllComments(taskID); err != nil {
+			return err
+		}
+		if err := s.db.RemoveAllTaskFields(taskID); err != nil {
+			return err
+		}
+		if err := s.db.RemoveAllLinks(taskID); err != nil {
+			return err
+		}
+		if err := s.db.RemoveAllRelations(taskID); err != nil {
+			return err
+		}
+		return s.db.DeleteTask(taskID)
+	}
+
+	// A task whose type was removed since the snapshot comes back as a
+	// plain task rather than with a code no type has
+	types, err := s.Types()
+	if err != nil {
+		return err
+	}
+	snap.Task.TaskType, _ = types.code(types.byCode(snap.Task.TaskType))
+
+	if exists {
+		return s.db.UpdateTask(snap.Task)
+	}
+	return s.db.CreateTask(snap.Task)
+}
+
+// restoreRelations makes a task's labels, dependencies, relations,
+// comments, custom fields and links match a snapshot
+func (s *Service) restoreRelations(taskID string, snap *snapshot) error {
+	if err := s.db.RemoveAllRelations(taskID); err != nil {
+		return err
+	}
+	for _, r := range snap.Relations {
+		if err := s.db.AddRelation(r); err != nil {
+			return err
+		}
+	}
+
+	if err := s.db.RemoveAllLinks(taskID); err != nil {
+		return err
+	}
+	for _, link := range snap.Links {
+		if _, err := s.db.AddLink(link); err != nil {
+			return err
+		}
+	}
+
+	if err := s.db.RemoveAllTaskFields(taskID); err != nil {
+		return err
+	}
+	for _, f := range snap.Fields {
+		if err := s.db.SetTaskField(taskID, f); err != nil {
+			return err
+		}
+	}
+
+	if err := s.db.RemoveAllComments(taskID); err != nil {
+		return err
+	}
+	for _, comment := range snap.Comments {
+		if _, err := s.db.AddComment(comment); err != nil {
+			return err
+		}
+	}
+
+	if err := s.db.RemoveAllLabels(taskID); err != nil {
+		return err
+	}
+	for _, label := range snap.Labels {
+		if err := s.db.AddLabel(taskID, label); err != nil {
+			return err
+		}
+	}
+
+	if err := s.db.RemoveAllDependencies(taskID); err != nil {
+		return err
+	}
+	for _, blockerID := range snap.BlockedBy {
+		if err := s.db.AddDependency(blockerID, taskID); err != nil {
+			return err
+		}
+	}
+	for _, blockedID := range snap.Blocks {
+		if err := s.db.AddDependency(taskID, blockedID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+package task
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestUndo_RestoresDeletedTask(t *testing.T) {
+	svc := newTestService(t)
+
+	svc.BeginOperation("setup")
+	for _, id := range []string{"test-a", "test-b"} {
+		if err := svc.CreateTask(NewTaskComplete(id, Todo, TypeBug, id, "", 2, "")); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if err := svc.AddDependency("test-a", "test-b"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := svc.AddLabel("test-a", "sprint-1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	svc.BeginOperation("task delete")
+	if err := svc.DeleteTask("test-a"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	op, err := svc.Undo()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if op.Command != "task delete" {
+		t.Errorf("expected to undo 'task delete', got %q", op.Command)
+	}
+
+	restored, err := svc.GetTaskByID("test-a")
+	if err != nil {
+		t.Fatalf("expected task to be restored: %v", err)
+	}
+	if restored.Type() != TypeBug || restored.Priority() != 2 {
+		t.Errorf("restored task lost fields: %+v", restored.ToJSON())
+	}
+	if !slices.Equal(restored.Labels(), []string{"sprint-1"}) {
+		t.Errorf("expected labels to be restored, got %v", restored.Labels())
+	}
+	if !slices.Equal(restored.Blocks(), []string{"test-b"}) {
+		t.Errorf("expected dependency to be restored, got %v", restored.Blocks())
+	}
+
+	if _, err := svc.Redo(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := svc.GetTaskByID("test-a"); err == nil {
+		t.Error("expected task to be deleted again after redo")
+	}
+	b, _ := svc.GetTaskByID("test-b")
+	if len(b.BlockedBy()) != 0 {
+		t.Errorf("expected dependency to be removed after redo, got %v", b.BlockedBy())
+	}
+}
+
+func TestUndo_WholeOperation(t *testing.T) {
+	svc := newTestService(t)
+
+	svc.BeginOperation("task create --bulk")
+	for _, id := range []string{"test-a", "test-b", "test-c"} {
+		if err := svc.CreateTask(NewTaskComplete(id, Todo, TypeTask, id, "", 3, "")); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	op, err := svc.Undo()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(op.Tasks) != 3 {
+		t.Errorf("expected 3 tasks in operation, got %v", op.Tasks)
+	}
+	tasks, _ := svc.LoadAllTasks()
+	if len(tasks) != 0 {
+		t.Errorf("expected all created tasks to be removed, got %d", len(tasks))
+	}
+
+	if _, err := svc.Undo(); !errors.Is(err, ErrNothingToUndo) {
+		t.Errorf("expected ErrNothingToUndo, got %v", err)
+	}
+}
+
+func TestUndo_Conflict(t *testing.T) {
+	svc := newTestService(t)
+
+	svc.BeginOperation("task create")
+	if err := svc.CreateTask(NewTaskComplete("test-a", Todo, TypeTask, "a", "", 3, "")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// An unjournaled change, e.g. from an older pace
+	svc.BeginOperation("")
+	if err := svc.AddLabel("test-a", "late"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var conflict *ConflictError
+	if _, err := svc.Undo(); !errors.As(err, &conflict) {
+		t.Fatalf("expected ConflictError, got %v", err)
+	}
+	if conflict.TaskID != "test-a" {
+		t.Errorf("expected conflict on test-a, got %s", conflict.TaskID)
+	}
+}
+
+func TestRedo_DiscardedByNewOperation(t *testing.T) {
+	svc := newTestService(t)
+
+	svc.BeginOperation("task create")
+	if err := svc.CreateTask(NewTaskComplete("test-a", Todo, TypeTask, "a", "", 3, "")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := svc.Undo(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	svc.BeginOperation("task create")
+	if err := svc.CreateTask(NewTaskComplete("test-b", Todo, TypeTask, "b", "", 3, "")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := svc.Redo(); !errors.Is(err, ErrNothingToRedo) {
+		t.Errorf("expected ErrNothingToRedo, got %v", err)
+	}
+}
@@ -0,0 +1,234 @@
+package task
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/lucas-tremaroli/pace/internal/storage"
+)
+
+// Link kinds. Code references get LinkCode unless another kind is given.
+const (
+	LinkPR     = "pr"
+	LinkIssue  = "issue"
+	LinkDoc    = "doc"
+	LinkDesign = "design"
+	LinkCode   = "code"
+)
+
+// LinkKinds lists the valid link kinds
+var LinkKinds = []string{LinkPR, LinkIssue, LinkDoc, LinkDesign, LinkCode}
+
+// Link is a typed link on a task: an http(s) URL or a CodeRef
+type Link = storage.LinkRecord
+
+// lineRange matches the :120 or :120-160 suffix of a code reference
+var lineRange = regexp.MustCompile(`:(\d+)(?:-(\d+))?$`)
+
+// CodeRef is a reference to a file in the repository, optionally to a line
+// or a range of lines, written as path, path:120 or path:120-160
+type CodeRef struct {
+	Path  string
+	Start int
+	End   int
+}
+
+// ParseCodeRef parses a repo-relative code reference. The path is cleaned
+// and must stay inside the repository.
+func ParseCodeRef(s string) (CodeRef, error) {
+	var ref CodeRef
+	p := strings.TrimSpace(s)
+	if m := lineRange.FindStringSubmatch(p); m != nil {
+		p = strings.TrimSuffix(p, m[0])
+		ref.Start, _ = strconv.Atoi(m[1])
+		ref.End = ref.Start
+		if m[2] != "" {
+			ref.End, _ = strconv.Atoi(m[2])
+		}
+		if ref.Start < 1 || ref.End < ref.Start {
+			return CodeRef{}, fmt.Errorf("%w: bad line range in %q", ErrInvalidLinkTarget, s)
+		}
+	}
+
+	p = filepath.ToSlash(p)
+	if p == "" || path.IsAbs(p) || filepath.IsAbs(p) || strings.ContainsFunc(p, unicode.IsControl) {
+		return CodeRef{}, fmt.Errorf("%w: %q", ErrInvalidLinkTarget, s)
+	}
+	p = path.Clean(p)
+	if p == "." || p == ".." || strings.HasPrefix(p, "../") {
+		return CodeRef{}, fmt.Errorf("%w: %q is outside the repository", ErrInvalidLinkTarget, s)
+	}
+	ref.Path = p
+	return ref, nil
+}
+
+// String formats the reference as path, path:N or path:N-M
+func (r CodeRef) String() string {
+	switch {
+	case r.Start == 0:
+		return r.Path
+	case r.End == r.Start:
+		return fmt.Sprintf("%s:%d", r.Path, r.Start)
+	default:
+		return fmt.Sprintf("%s:%d-%d", r.Path, r.Start, r.End)
+	}
+}
+
+// File returns the referenced file on disk. Paths are relative to the
+// project root (the directory holding .pace/), or to the working directory
+// when pace uses global storage.
+func (r CodeRef) File() (string, error) {
+	wd, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+	if dir := storage.FindExistingProjectDir(wd); dir != "" {
+		wd = filepath.Dir(dir)
+	}
+	return filepath.Join(wd, filepath.FromSlash(r.Path)), nil
+}
+
+// IsURL reports whether a link target is a URL rather than a code reference
+func IsURL(target string) bool {
+	return strings.Contains(target, "://")
+}
+
+// NewLink checks a link target and kind and returns the link to add.
+// Targets with a scheme must be http(s) URLs; anything else is read as a
+// code reference. Without a kind, URLs to pull requests and issues are
+// detected, other URLs are docs, and code references are code.
+func NewLink(target, kind, title string) (Link, error) {
+	target = strings.TrimSpace(target)
+	if IsURL(target) {
+		if err := ValidateLink(target); err != nil {
+			return Link{}, fmt.Errorf("%w: %q", ErrInvalidLinkTarget, target)
+		}
+	} else {
+		ref, err := ParseCodeRef(target)
+		if err != nil {
+			return Link{}, err
+		}
+		target = ref.String()
+	}
+
+	kind = strings.ToLower(strings.TrimSpace(kind))
+	if kind == "" {
+		kind = inferLinkKind(target)
+	}
+	if !slices.Contains(LinkKinds, kind) {
+		return Link{}, fmt.Errorf("%w: %q (valid: %s)", ErrInvalidLinkKind, kind, strings.Join(LinkKinds, ", "))
+	}
+	return Link{Kind: kind, Title: strings.TrimSpace(title), Target: target}, nil
+}
+
+func inferLinkKind(target string) string {
+	switch {
+	case !IsURL(target):
+		return LinkCode
+	case strings.Contains(target, "/pull/") || strings.Contains(target, "/merge_requests/"):
+		return LinkPR
+	case strings.Contains(target, "/issues/"):
+		return LinkIssue
+	default:
+		return LinkDoc
+	}
+}
+
+// Links returns the task's typed links, in the order they were added
+func (t Task) Links() []Link {
+	return t.links
+}
+
+// SetLinks sets the task's typed links
+func (t *Task) SetLinks(links []Link) {
+	t.links = links
+}
+
+// AddLink adds a URL or code reference to a task, see NewLink
+func (s *Service) AddLink(taskID, target, kind, title string) (*Link, error) {
+	link, err := NewLink(target, kind, title)
+	if err != nil {
+		return nil, err
+	}
+	err = s.Atomic(func() error { return s.addLink(taskID, &link) })
+	if err != nil {
+		return nil, err
+	}
+	return &link, nil
+}
+
+func (s *Service) addLink(taskID string, link *Link) error {
+	exists, err := s.db.TaskExists(taskID)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("%w: %s", ErrTaskNotFound, taskID)
+	}
+	links, err := s.db.GetLinks(taskID)
+	if err != nil {
+		return err
+	}
+	if slices.ContainsFunc(links, func(l Link) bool { return l.Target == link.Target }) {
+		return fmt.Errorf("%w: %s", ErrDuplicateLink, link.Target)
+	}
+
+	done, err := s.track(taskID)
+	if err != nil {
+		return err
+	}
+	link.TaskID = taskID
+	if link.ID, err = s.db.AddLink(*link); err != nil {
+		return err
+	}
+	if err := s.recordEvent(taskID, EventLinkAdded, "link", "", link.Target); err != nil {
+		return err
+	}
+	return done()
+}
+
+// ListLinks returns the links of a task, in the order they were added
+func (s *Service) ListLinks(taskID string) ([]Link, error) {
+	return s.db.GetLinks(taskID)
+}
+
+// RemoveLink removes a link and returns it
+func (s *Service) RemoveLink(linkID int64) (*Link, error) {
+	var link *Link
+	err := s.Atomic(func() error {
+		var err error
+		link, err = s.removeLink(linkID)
+		return err
+	})
+	return link, err
+}
+
+func (s *Service) removeLink(linkID int64) (*Link, error) {
+	link, err := s.db.GetLink(linkID)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("%w: %s", ErrLinkNotFound, strconv.FormatInt(linkID, 10))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	done, err := s.track(link.TaskID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.db.DeleteLink(linkID); err != nil {
+		return nil, err
+	}
+	if err := s.recordEvent(link.TaskID, EventLinkRemoved, "link", link.Target, ""); err != nil {
+		return nil, err
+	}
+	return link, done()
+}
@@ -0,0 +1,115 @@
+package task
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseCodeRef(t *testing.T) {
+	tests := []struct {
+		input string
+		want  CodeRef
+	}{
+		{"internal/task/service.go", CodeRef{Path: "internal/task/service.go"}},
+		{"internal/task/service.go:120", CodeRef{"internal/task/service.go", 120, 120}},
+		{"./internal//task/service.go:120-160", CodeRef{"internal/task/service.go", 120, 160}},
+		{"README.md", CodeRef{Path: "README.md"}},
+	}
+	for _, tt := range tests {
+		got, err := ParseCodeRef(tt.input)
+		if err != nil {
+			t.Errorf("ParseCodeRef(%q) unexpected error: %v", tt.input, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseCodeRef(%q) = %+v, want %+v", tt.input, got, tt.want)
+		}
+	}
+
+	for _, input := range []string{"", "/etc/passwd", "../secrets.txt", "a/../../b", "main.go:0", "main.go:20-10"} {
+		if _, err := ParseCodeRef(input); !errors.Is(err, ErrInvalidLinkTarget) {
+			t.Errorf("ParseCodeRef(%q) expected ErrInvalidLinkTarget, got %v", input, err)
+		}
+	}
+}
+
+func TestNewLink(t *testing.T) {
+	tests := []struct {
+		target, kind string
+		wantKind     string
+	}{
+		{"https://github.com/org/repo/pull/42", "", LinkPR},
+		{"https://github.com/org/repo/issues/7", "", LinkIssue},
+		{"https://example.com/spec", "", LinkDoc},
+		{"https://figma.com/file/abc", "Design", LinkDesign},
+		{"cmd/task/link.go:10", "", LinkCode},
+		{"docs/adr/001.md", "doc", LinkDoc},
+	}
+	for _, tt := range tests {
+		link, err := NewLink(tt.target, tt.kind, "")
+		if err != nil {
+			t.Errorf("NewLink(%q, %q) unexpected error: %v", tt.target, tt.kind, err)
+			continue
+		}
+		if link.Kind != tt.wantKind {
+			t.Errorf("NewLink(%q, %q) kind = %q, want %q", tt.target, tt.kind, link.Kind, tt.wantKind)
+		}
+	}
+
+	if _, err := NewLink("ftp://example.com/file", "", ""); !errors.Is(err, ErrInvalidLinkTarget) {
+		t.Errorf("expected ErrInvalidLinkTarget, got %v", err)
+	}
+	if _, err := NewLink("https://example.com", "video", ""); !errors.Is(err, ErrInvalidLinkKind) {
+		t.Errorf("expected ErrInvalidLinkKind, got %v", err)
+	}
+}
+
+func TestLinks(t *testing.T) {
+	svc := newTestService(t)
+
+	task := NewTaskComplete("test-aaa", Todo, TypeTask, "task", "", 3, "https://example.com")
+	if err := svc.CreateTask(task); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	pr, err := svc.AddLink(task.ID(), "https://github.com/org/repo/pull/42", "", "Fix")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := svc.AddLink(task.ID(), "internal/task/link.go:1-20", "", ""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := svc.AddLink(task.ID(), pr.Target, LinkDoc, ""); !errors.Is(err, ErrDuplicateLink) {
+		t.Errorf("expected ErrDuplicateLink, got %v", err)
+	}
+	if _, err := svc.AddLink("test-zzz", pr.Target, "", ""); !errors.Is(err, ErrTaskNotFound) {
+		t.Errorf("expected ErrTaskNotFound, got %v", err)
+	}
+
+	got, err := svc.GetTaskByID(task.ID())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	choices := linkChoices(*got)
+	if len(choices) != 3 || choices[0].target != "https://example.com" || choices[1].kind != LinkPR || choices[2].kind != LinkCode {
+		t.Errorf("unexpected link choices: %+v", choices)
+	}
+
+	// Removing a link can be undone
+	svc.BeginOperation("remove link")
+	if _, err := svc.RemoveLink(pr.ID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := svc.RemoveLink(pr.ID); !errors.Is(err, ErrLinkNotFound) {
+		t.Errorf("expected ErrLinkNotFound, got %v", err)
+	}
+	if _, err := svc.Undo(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	links, err := svc.ListLinks(task.ID())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(links) != 2 || links[0].ID != pr.ID || links[0].Title != "Fix" {
+		t.Errorf("expected the pull request link to be restored, got %+v", links)
+	}
+}
@@ -0,0 +1,187 @@
+package task
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// linkChoice is something the open key can open: the task's URL or one of
+// its typed links
+type linkChoice struct {
+	kind   string
+	title  string
+	target string
+}
+
+// linkChoices lists what can be opened for a task, its URL first
+func linkChoices(t Task) []linkChoice {
+	var choices []linkChoice
+	if t.link != "" {
+		choices = append(choices, linkChoice{kind: "url", target: t.link})
+	}
+	for _, l := range t.links {
+		choices = append(choices, linkChoice{kind: l.Kind, title: l.Title, target: l.Target})
+	}
+	return choices
+}
+
+// open opens a URL in the browser, and a code reference in $EDITOR at its
+// first line, or with the default application if $EDITOR is not set
+func (c linkChoice) open() tea.Cmd {
+	if IsURL(c.target) {
+		return openURL(c.target)
+	}
+	ref, err := ParseCodeRef(c.target)
+	if err != nil {
+		return nil
+	}
+	file, err := ref.File()
+	if err != nil {
+		return nil
+	}
+	editor := strings.Fields(os.Getenv("EDITOR"))
+	if len(editor) == 0 {
+		return func() tea.Msg {
+			systemOpen(file)
+			return nil
+		}
+	}
+	args := editor[1:]
+	if ref.Start > 0 {
+		args = append(args, "+"+strconv.Itoa(ref.Start))
+	}
+	args = append(args, file)
+	return tea.ExecProcess(exec.Command(editor[0], args...), func(error) tea.Msg { return nil })
+}
+
+type linkPickerKeyMap struct {
+	Up   key.Binding
+	Down key.Binding
+	Open key.Binding
+	Back key.Binding
+}
+
+func (k linkPickerKeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Up, k.Down, k.Open, k.Back}
+}
+
+func (k linkPickerKeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{{k.Up, k.Down}, {k.Open, k.Back}}
+}
+
+var linkPickerKeys = linkPickerKeyMap{
+	Up: key.NewBinding(
+		key.WithKeys("up", "k"),
+		key.WithHelp("↑/k", "up"),
+	),
+	Down: key.NewBinding(
+		key.WithKeys("down", "j"),
+		key.WithHelp("↓/j", "down"),
+	),
+	Open: key.NewBinding(
+		key.WithKeys("enter", "o"),
+		key.WithHelp("enter/o", "open"),
+	),
+	Back: key.NewBinding(
+		key.WithKeys("esc", "q"),
+		key.WithHelp("esc", "close"),
+	),
+}
+
+// LinkPicker lets the user choose which of a task's links to open
+type LinkPicker struct {
+	help    help.Model
+	task    Task
+	choices []linkChoice
+	cursor  int
+	board   *Board
+}
+
+func NewLinkPicker(task Task, choices []linkChoice, board *Board) LinkPicker {
+	return LinkPicker{
+		help:    help.New(),
+		task:    task,
+		choices: choices,
+		board:   board,
+	}
+}
+
+func (p LinkPicker) Init() tea.Cmd {
+	return nil
+}
+
+func (p LinkPicker) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return p, nil
+	}
+	switch {
+	case key.Matches(keyMsg, linkPickerKeys.Up):
+		p.cursor = (p.cursor + len(p.choices) - 1) % len(p.choices)
+	case key.Matches(keyMsg, linkPickerKeys.Down):
+		p.cursor = (p.cursor + 1) % len(p.choices)
+	case key.Matches(keyMsg, linkPickerKeys.Open):
+		return p.back(p.choices[p.cursor].open())
+	case key.Matches(keyMsg, linkPickerKeys.Back):
+		return p.back(nil)
+	default:
+		// 1-9 open a link directly
+		if n, err := strconv.Atoi(keyMsg.String()); err == nil && n >= 1 && n <= len(p.choices) {
+			return p.back(p.choices[n-1].open())
+		}
+	}
+	return p, nil
+}
+
+// back returns to the board, running cmd
+func (p LinkPicker) back(cmd tea.Cmd) (tea.Model, tea.Cmd) {
+	if p.board == nil {
+		return p, cmd
+	}
+	model, boardCmd := p.board.Update(nil)
+	return model, tea.Batch(cmd, boardCmd)
+}
+
+func (p LinkPicker) View() string {
+	headerStyle := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(lipgloss.Color("205")).
+		MarginBottom(1)
+	kindStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("39"))
+	targetStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("243"))
+	cursorStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("170")).Bold(true)
+	boxStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color("62")).
+		Padding(1, 2).
+		Margin(1, 2)
+
+	lines := []string{headerStyle.Render("Open link: " + p.task.Title())}
+	for i, c := range p.choices {
+		prefix := "  "
+		title := c.title
+		if i == p.cursor {
+			prefix = cursorStyle.Render("> ")
+			title = cursorStyle.Render(title)
+		}
+		line := fmt.Sprintf("%s%d. %s", prefix, i+1, kindStyle.Render("["+c.kind+"]"))
+		if title != "" {
+			line += " " + title
+		}
+		lines = append(lines, line+" "+targetStyle.Render(c.target))
+	}
+
+	return lipgloss.JoinVertical(
+		lipgloss.Left,
+		boxStyle.Render(lipgloss.JoinVertical(lipgloss.Left, lines...)),
+		lipgloss.NewStyle().MarginLeft(2).Render(p.help.View(linkPickerKeys)),
+	)
+}
@@ -0,0 +1,113 @@
+package task
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+
+	"github.com/lucas-tremaroli/pace/internal/storage"
+)
+
+const (
+	// ConfigKeyEstimateUnit is the config key for the unit estimates are
+	// given in, such as points or hours
+	ConfigKeyEstimateUnit = "estimate_unit"
+	// DefaultEstimateUnit is used when no estimate unit is configured
+	DefaultEstimateUnit = "points"
+)
+
+// ValidateEstimate checks that an estimate is a non-negative number. An
+// estimate of 0 means the task is not estimated.
+func ValidateEstimate(e float64) error {
+	if e < 0 || math.IsNaN(e) || math.IsInf(e, 0) {
+		return fmt.Errorf("%w: %v", ErrInvalidEstimate, e)
+	}
+	return nil
+}
+
+// formatEstimate formats an estimate for the history, "" if unestimated
+func formatEstimate(e float64) string {
+	if e == 0 {
+		return ""
+	}
+	return strconv.FormatFloat(e, 'f', -1, 64)
+}
+
+// EstimateUnit returns the unit estimates are given in
+func (s *Service) EstimateUnit() (string, error) {
+	return configValue(s.db, ConfigKeyEstimateUnit, DefaultEstimateUnit)
+}
+
+// SetEstimate sets or, with 0, clears the estimate of a task
+func (s *Service) SetEstimate(taskID string, estimate float64) error {
+	if err := ValidateEstimate(estimate); err != nil {
+		return err
+	}
+	return s.Atomic(func() error {
+		return s.updateRecord(taskID, func(r *storage.TaskRecord) {
+			r.Estimate = estimate
+		})
+	})
+}
+
+// PlanItem is a ready task considered for a plan
+type PlanItem struct {
+	ID       string  `json:"id"`
+	Title    string  `json:"title"`
+	Priority int     `json:"priority"`
+	Estimate float64 `json:"estimate"`
+	// Reason explains why a skipped task was left out of the plan
+	Reason string `json:"reason,omitempty"`
+}
+
+// Plan is a slice of ready work that fits a capacity
+type Plan struct {
+	Capacity  float64    `json:"capacity"`
+	Used      float64    `json:"used"`
+	Remaining float64    `json:"remaining"`
+	Unit      string     `json:"unit"`
+	Selected  []PlanItem `json:"selected"`
+	Skipped   []PlanItem `json:"skipped"`
+}
+
+// Plan picks ready tasks by priority, keeping the ready order for equal
+// priorities, until capacity is used. Tasks without an estimate, or too big
+// for what is left, are skipped with the reason.
+func (s *Service) Plan(capacity float64) (*Plan, error) {
+	if capacity <= 0 || math.IsNaN(capacity) || math.IsInf(capacity, 0) {
+		return nil, fmt.Errorf("capacity must be a positive number, got %v", capacity)
+	}
+	unit, err := s.EstimateUnit()
+	if err != nil {
+		return nil, err
+	}
+	ready, err := s.GetReadyTasks(false)
+	if err != nil {
+		return nil, err
+	}
+	slices.SortStableFunc(ready, func(a, b Task) int {
+		return a.Priority() - b.Priority()
+	})
+
+	plan := &Plan{Capacity: capacity, Unit: unit, Selected: []PlanItem{}, Skipped: []PlanItem{}}
+	for _, t := range ready {
+		item := PlanItem{ID: t.ID(), Title: t.Title(), Priority: t.Priority(), Estimate: t.Estimate()}
+		left := capacity - plan.Used
+		switch {
+		case t.Estimate() == 0:
+			item.Reason = "no estimate"
+		case t.Estimate() > capacity:
+			item.Reason = fmt.Sprintf("estimate %s exceeds the capacity of %s %s", formatEstimate(t.Estimate()), formatEstimate(capacity), unit)
+		case t.Estimate() > left:
+			item.Reason = fmt.Sprintf("estimate %s exceeds the %s %s left", formatEstimate(t.Estimate()), strconv.FormatFloat(left, 'f', -1, 64), unit)
+		default:
+			plan.Used += t.Estimate()
+			plan.Selected = append(plan.Selected, item)
+			continue
+		}
+		plan.Skipped = append(plan.Skipped, item)
+	}
+	plan.Remaining = capacity - plan.Used
+	return plan, nil
+}
@@ -0,0 +1,104 @@
+package task
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestPlan(t *testing.T) {
+	svc := newTestService(t)
+
+	tasks := []struct {
+		id       string
+		priority int
+		estimate float64
+	}{
+		{"test-aaa", 1, 3},
+		{"test-bbb", 1, 0},
+		{"test-ccc", 2, 4},
+		{"test-ddd", 2, 2},
+		{"test-eee", 3, 1},
+		{"test-fff", 4, 20},
+	}
+	for _, tt := range tasks {
+		task := NewTaskComplete(tt.id, Todo, TypeTask, tt.id, "", tt.priority, "")
+		task.SetEstimate(tt.estimate)
+		if err := svc.CreateTask(task); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	// Blocked tasks are not ready and are not considered
+	blocked := NewTaskComplete("test-ggg", Todo, TypeTask, "blocked", "", 1, "")
+	blocked.SetEstimate(1)
+	if err := svc.CreateTask(blocked); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := svc.AddDependency("test-ccc", blocked.ID()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	plan, err := svc.Plan(8)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var selected []string
+	for _, item := range plan.Selected {
+		selected = append(selected, item.ID)
+	}
+	want := []string{"test-aaa", "test-ccc", "test-eee"}
+	if len(selected) != len(want) || selected[0] != want[0] || selected[1] != want[1] || selected[2] != want[2] {
+		t.Errorf("expected selected %v, got %v", want, selected)
+	}
+	if plan.Used != 8 || plan.Remaining != 0 || plan.Unit != DefaultEstimateUnit {
+		t.Errorf("unexpected totals: %+v", plan)
+	}
+
+	reasons := map[string]string{}
+	for _, item := range plan.Skipped {
+		reasons[item.ID] = item.Reason
+	}
+	if len(reasons) != 3 || reasons["test-bbb"] != "no estimate" || reasons["test-ddd"] == "" || reasons["test-fff"] == "" {
+		t.Errorf("unexpected skipped tasks: %+v", plan.Skipped)
+	}
+
+	for _, capacity := range []float64{0, -1, math.NaN()} {
+		if _, err := svc.Plan(capacity); err == nil {
+			t.Errorf("Plan(%v) expected error, got nil", capacity)
+		}
+	}
+}
+
+func TestSetEstimate(t *testing.T) {
+	svc := newTestService(t)
+	task := NewTaskComplete("test-aaa", Todo, TypeTask, "task", "", 3, "")
+	task.SetEstimate(2)
+	if err := svc.CreateTask(task); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Updating other fields keeps the estimate
+	if err := svc.UpdateTask(NewTaskComplete(task.ID(), InProgress, TypeTask, "task", "", 3, "")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := svc.SetEstimate(task.ID(), 0.5); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got, err := svc.GetTaskByID(task.ID())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Estimate() != 0.5 {
+		t.Errorf("expected estimate 0.5, got %v", got.Estimate())
+	}
+
+	if err := svc.SetEstimate(task.ID(), -1); !errors.Is(err, ErrInvalidEstimate) {
+		t.Errorf("expected ErrInvalidEstimate, got %v", err)
+	}
+	invalid := NewTaskComplete("test-bbb", Todo, TypeTask, "task", "", 3, "")
+	invalid.SetEstimate(math.Inf(1))
+	if err := svc.CreateTask(invalid); !errors.Is(err, ErrInvalidEstimate) {
+		t.Errorf("expected ErrInvalidEstimate, got %v", err)
+	}
+}
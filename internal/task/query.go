@@ -0,0 +1,495 @@
+package task
+
+import (
+	"cmp"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// FilterKeys lists the keys a filter query can compare, for help and error
+// messages
+const FilterKeys = "status, type, priority, label, assignee, archived, due, created, updated, estimate, field.<key>"
+
+// Matcher is a condition on a task: a TaskFilter or a query expression
+type Matcher interface {
+	Matches(t Task) bool
+}
+
+// andExpr matches tasks that match every operand
+type andExpr []Matcher
+
+func (e andExpr) Matches(t Task) bool {
+	for _, m := range e {
+		if !m.Matches(t) {
+			return false
+		}
+	}
+	return true
+}
+
+// orExpr matches tasks that match any operand
+type orExpr []Matcher
+
+func (e orExpr) Matches(t Task) bool {
+	for _, m := range e {
+		if m.Matches(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// notExpr matches tasks that do not match its operand
+type notExpr struct {
+	Matcher
+}
+
+func (e notExpr) Matches(t Task) bool {
+	return !e.Matcher.Matches(t)
+}
+
+// compareExpr compares a task attribute with a value. compare returns the
+// sign of attribute minus value, and false if the task has no such
+// attribute, which matches only an "=" comparison with an empty value.
+type compareExpr struct {
+	op      string
+	empty   bool
+	compare func(t Task) (int, bool)
+}
+
+func (e compareExpr) Matches(t Task) bool {
+	c, ok := e.compare(t)
+	if e.empty {
+		return !ok
+	}
+	if !ok {
+		return false
+	}
+	switch e.op {
+	case "<":
+		return c < 0
+	case "<=":
+		return c <= 0
+	case ">":
+		return c > 0
+	case ">=":
+		return c >= 0
+	default:
+		return c == 0
+	}
+}
+
+// walkFilters calls fn for every TaskFilter in a matcher, including the
+// ones nested in expressions
+func walkFilters(m Matcher, fn func(*TaskFilter)) {
+	switch m := m.(type) {
+	case *TaskFilter:
+		fn(m)
+		for _, e := range m.Exprs {
+			walkFilters(e, fn)
+		}
+	case andExpr:
+		for _, e := range m {
+			walkFilters(e, fn)
+		}
+	case orExpr:
+		for _, e := range m {
+			walkFilters(e, fn)
+		}
+	case notExpr:
+		walkFilters(m.Matcher, fn)
+	}
+}
+
+// ParseQuery parses a filter query into a matcher. A query is one or more
+// comparisons of a key with a value:
+//
+//	key=value  key!=value  key<value  key<=value  key>value  key>=value
+//	key in (a, b, c)  key not in (a, b, c)
+//
+// combined with AND (or just a space), OR and NOT, and grouped with
+// parentheses. AND binds tighter than OR. Values containing spaces, commas
+// or parentheses must be in double or single quotes. "key=" with no value
+// matches tasks without one (no labels, assignee, due date, estimate or
+// custom field).
+//
+// Keys are status, type, priority, label, assignee, archived, due, created,
+// updated, estimate and field.<key>. Status, type, label, assignee and
+// archived only support =, != and in; created and updated only support
+// ranges. Dates take the same forms as --due and --since.
+func ParseQuery(s string) (Matcher, error) {
+	p := &queryParser{s: s}
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpace()
+	if p.pos < len(p.s) {
+		return nil, p.errorf("unexpected %q", p.s[p.pos:])
+	}
+	return expr, nil
+}
+
+// queryParser is a recursive descent parser over a filter query
+type queryParser struct {
+	s   string
+	pos int
+}
+
+func (p *queryParser) errorf(format string, args ...any) error {
+	return fmt.Errorf("%w: %s at position %d in %q", ErrInvalidFilter, fmt.Sprintf(format, args...), p.pos+1, p.s)
+}
+
+func (p *queryParser) skipSpace() {
+	for p.pos < len(p.s) && unicode.IsSpace(rune(p.s[p.pos])) {
+		p.pos++
+	}
+}
+
+// peek returns the next byte after any space, or 0 at the end of the query
+func (p *queryParser) peek() byte {
+	p.skipSpace()
+	if p.pos == len(p.s) {
+		return 0
+	}
+	return p.s[p.pos]
+}
+
+// keyword consumes the next word if it is kw, in any case
+func (p *queryParser) keyword(kw string) bool {
+	p.skipSpace()
+	end := p.pos
+	for end < len(p.s) && isKeyChar(p.s[end]) {
+		end++
+	}
+	if !strings.EqualFold(p.s[p.pos:end], kw) {
+		return false
+	}
+	p.pos = end
+	return true
+}
+
+// atKeyword reports whether the next word is one of the query keywords,
+// without consuming it
+func (p *queryParser) atKeyword() bool {
+	start := p.pos
+	defer func() { p.pos = start }()
+	return p.keyword("and") || p.keyword("or") || p.keyword("not")
+}
+
+func isKeyChar(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_' || c == '-' || c == '.'
+}
+
+func (p *queryParser) parseOr() (Matcher, error) {
+	var terms orExpr
+	for {
+		term, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		terms = append(terms, term)
+		if !p.keyword("or") {
+			break
+		}
+	}
+	if len(terms) == 1 {
+		return terms[0], nil
+	}
+	return terms, nil
+}
+
+func (p *queryParser) parseAnd() (Matcher, error) {
+	var terms andExpr
+	for {
+		term, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		terms = append(terms, term)
+
+		// AND is optional between terms
+		if c := p.peek(); c == 0 || c == ')' {
+			break
+		}
+		start := p.pos
+		if p.keyword("or") {
+			p.pos = start
+			break
+		}
+		p.keyword("and")
+	}
+	if len(terms) == 1 {
+		return terms[0], nil
+	}
+	return terms, nil
+}
+
+func (p *queryParser) parseUnary() (Matcher, error) {
+	if p.keyword("not") {
+		expr, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return notExpr{expr}, nil
+	}
+	if p.peek() == '(' {
+		p.pos++
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek() != ')' {
+			return nil, p.errorf("expected )")
+		}
+		p.pos++
+		return expr, nil
+	}
+	return p.parseComparison()
+}
+
+func (p *queryParser) parseComparison() (Matcher, error) {
+	p.skipSpace()
+	start := p.pos
+	for p.pos < len(p.s) && isKeyChar(p.s[p.pos]) {
+		p.pos++
+	}
+	key := p.s[start:p.pos]
+	if key == "" {
+		if p.pos == len(p.s) {
+			return nil, p.errorf("expected a comparison such as status=todo")
+		}
+		return nil, p.errorf("unexpected %q", p.s[p.pos:p.pos+1])
+	}
+
+	negated := p.keyword("not")
+	if p.keyword("in") {
+		values, err := p.parseList()
+		if err != nil {
+			return nil, err
+		}
+		var terms orExpr
+		for _, value := range values {
+			term, err := compileComparison(key, "=", value)
+			if err != nil {
+				return nil, err
+			}
+			terms = append(terms, term)
+		}
+		if negated {
+			return notExpr{terms}, nil
+		}
+		return terms, nil
+	}
+	if negated {
+		return nil, p.errorf("expected in after not")
+	}
+
+	p.skipSpace()
+	var op string
+	for _, candidate := range []string{"!=", "<=", ">=", "=", "<", ">"} {
+		if strings.HasPrefix(p.s[p.pos:], candidate) {
+			op = candidate
+			break
+		}
+	}
+	if op == "" {
+		return nil, p.errorf("expected an operator (=, !=, <, <=, >, >=, in) after %s", key)
+	}
+	p.pos += len(op)
+
+	// A missing value ("assignee=") means the task has none
+	var value string
+	if c := p.peek(); c != 0 && c != ')' && !p.atKeyword() {
+		var err error
+		if value, err = p.parseValue(); err != nil {
+			return nil, err
+		}
+	}
+
+	if op == "!=" {
+		term, err := compileComparison(key, "=", value)
+		if err != nil {
+			return nil, err
+		}
+		return notExpr{term}, nil
+	}
+	return compileComparison(key, op, value)
+}
+
+// parseList parses the parenthesized, comma separated values of an in
+// comparison
+func (p *queryParser) parseList() ([]string, error) {
+	if p.peek() != '(' {
+		return nil, p.errorf("expected ( after in")
+	}
+	p.pos++
+	var values []string
+	for {
+		p.skipSpace()
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+		switch p.peek() {
+		case ',':
+			p.pos++
+		case ')':
+			p.pos++
+			return values, nil
+		default:
+			return nil, p.errorf("expected , or ) in list")
+		}
+	}
+}
+
+// parseValue parses a quoted value, or a bare one up to the next space,
+// comma or parenthesis
+func (p *queryParser) parseValue() (string, error) {
+	if p.pos < len(p.s) && (p.s[p.pos] == '"' || p.s[p.pos] == '\'') {
+		quote := p.s[p.pos]
+		end := strings.IndexByte(p.s[p.pos+1:], quote)
+		if end < 0 {
+			return "", p.errorf("unterminated quote")
+		}
+		value := p.s[p.pos+1 : p.pos+1+end]
+		p.pos += end + 2
+		return value, nil
+	}
+	start := p.pos
+	for p.pos < len(p.s) && !unicode.IsSpace(rune(p.s[p.pos])) && !strings.ContainsRune("(),", rune(p.s[p.pos])) {
+		p.pos++
+	}
+	return p.s[start:p.pos], nil
+}
+
+// compileComparison turns a single comparison into a matcher. Equality on
+// the keys TaskFilter has fields for, and due< and due>, become a
+// TaskFilter; the rest become a compareExpr.
+func compileComparison(key, op, value string) (Matcher, error) {
+	value = strings.TrimSpace(value)
+	fieldKey, isField := strings.CutPrefix(key, "field.")
+
+	switch {
+	case key == "label" && op == "=" && value == "":
+		return compareExpr{op: op, empty: true, compare: func(t Task) (int, bool) {
+			return 0, len(t.Labels()) > 0
+		}}, nil
+	case key == "due" && op == "<":
+		due, err := ParseDueDate(value)
+		if err != nil {
+			return nil, err
+		}
+		return &TaskFilter{DueBefore: &due}, nil
+	case key == "due" && op == ">":
+		due, err := ParseDueDate(value)
+		if err != nil {
+			return nil, err
+		}
+		return &TaskFilter{DueAfter: &due}, nil
+	case op == "=" && (isField || key == "status" || key == "type" || key == "priority" ||
+		key == "label" || key == "assignee" || key == "archived"):
+		return parseFilterTerm(key, value)
+	}
+
+	if op != "=" && value == "" {
+		return nil, fmt.Errorf("%w: %s%s needs a value", ErrInvalidFilter, key, op)
+	}
+	e := compareExpr{op: op, empty: op == "=" && value == ""}
+	switch {
+	case key == "priority":
+		priority, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid priority: %s", value)
+		}
+		if priority < 1 || priority > 4 {
+			return nil, fmt.Errorf("priority must be 1-4, got %d", priority)
+		}
+		e.compare = func(t Task) (int, bool) {
+			return cmp.Compare(t.Priority(), priority), true
+		}
+	case key == "estimate":
+		e.compare = func(t Task) (int, bool) {
+			return 0, t.Estimate() > 0
+		}
+		if !e.empty {
+			estimate, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %s", ErrInvalidEstimate, value)
+			}
+			if err := ValidateEstimate(estimate); err != nil {
+				return nil, err
+			}
+			e.compare = func(t Task) (int, bool) {
+				return cmp.Compare(t.Estimate(), estimate), t.Estimate() > 0
+			}
+		}
+	case key == "due":
+		e.compare = func(t Task) (int, bool) {
+			return 0, !t.Due().IsZero()
+		}
+		if !e.empty {
+			due, err := ParseDueDate(value)
+			if err != nil {
+				return nil, err
+			}
+			e.compare = func(t Task) (int, bool) {
+				return t.Due().Compare(due), !t.Due().IsZero()
+			}
+		}
+	case (key == "created" || key == "updated") && op != "=":
+		// A date compared as the upper end of a range covers the whole day
+		parse := ParseTimeBound
+		if op == "<=" || op == ">" {
+			parse = ParseTimeUntil
+		}
+		bound, err := parse(value)
+		if err != nil {
+			return nil, err
+		}
+		e.compare = func(t Task) (int, bool) {
+			at := t.CreatedAt()
+			if key == "updated" {
+				at = t.UpdatedAt()
+			}
+			return at.Compare(bound), !at.IsZero()
+		}
+	case isField:
+		if !isName(fieldKey) {
+			return nil, fmt.Errorf("%w: key %q (use lowercase letters, digits, - and _)", ErrInvalidField, fieldKey)
+		}
+		e.compare = func(t Task) (int, bool) {
+			f, ok := t.Field(fieldKey)
+			if !ok {
+				return 0, false
+			}
+			return compareField(f, value)
+		}
+	case key == "created" || key == "updated":
+		return nil, fmt.Errorf("%w: %s only supports <, <=, > and >=", ErrInvalidFilter, key)
+	case key == "status" || key == "type" || key == "label" || key == "assignee" || key == "archived":
+		return nil, fmt.Errorf("%w: %s only supports =, != and in", ErrInvalidFilter, key)
+	default:
+		return nil, fmt.Errorf("unknown filter key: %s (valid: %s)", key, FilterKeys)
+	}
+	return e, nil
+}
+
+// compareField orders a custom field against a value read as the field's
+// type: numbers numerically, and dates and strings as text. A value that is
+// not valid for the field's type does not compare.
+func compareField(f Field, value string) (int, bool) {
+	canonical, err := canonicalFieldValue(f.Type, value)
+	if err != nil {
+		return 0, false
+	}
+	if f.Type == FieldNumber {
+		a, _ := strconv.ParseFloat(f.Value, 64)
+		b, _ := strconv.ParseFloat(canonical, 64)
+		return cmp.Compare(a, b), true
+	}
+	return strings.Compare(f.Value, canonical), true
+}
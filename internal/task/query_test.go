@@ -0,0 +1,129 @@
+package task
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestParseQuery(t *testing.T) {
+	auth := NewTaskComplete("t1", Todo, TypeBug, "auth bug", "", 1, "")
+	auth.SetLabels([]string{"auth"})
+	auth.SetDue(parseDate("2026-10-20"))
+	auth.SetEstimate(2)
+	auth.SetFields([]Field{{"points", FieldNumber, "8"}})
+	auth.updatedAt = time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	api := NewTaskComplete("t2", Done, TypeFeature, "api feature", "", 2, "")
+	api.SetLabels([]string{"api", "backend"})
+	api.SetAssignee("ada")
+	api.SetFields([]Field{{"points", FieldNumber, "3"}})
+	api.updatedAt = time.Date(2026, 10, 10, 12, 0, 0, 0, time.UTC)
+
+	chore := NewTaskComplete("t3", InProgress, TypeChore, "cleanup", "", 4, "")
+	chore.SetDue(parseDate("2026-11-15"))
+	chore.updatedAt = time.Date(2026, 10, 15, 12, 0, 0, 0, time.UTC)
+
+	tasks := []Task{auth, api, chore}
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"priority<=2 AND (label=auth OR label=api) AND NOT status=done", []string{"t1"}},
+		{"priority<=2 (label=auth or label=api)", []string{"t1", "t2"}},
+		{"label=auth OR label=api AND status=done", []string{"t1", "t2"}},
+		{"NOT (label=auth OR label=api)", []string{"t3"}},
+		{"status != done", []string{"t1", "t3"}},
+		{"type in (bug, chore)", []string{"t1", "t3"}},
+		{"type not in (bug,chore)", []string{"t2"}},
+		{"priority>2", []string{"t3"}},
+		{"label=", []string{"t3"}},
+		{"label!=backend", []string{"t1", "t3"}},
+		{"assignee=", []string{"t1", "t3"}},
+		{"assignee= AND status=todo", []string{"t1"}},
+		{"assignee in ('ada', \"bob\")", []string{"t2"}},
+		{"due<2026-11-01", []string{"t1"}},
+		{"due>=2026-10-20", []string{"t1", "t3"}},
+		{"due=2026-11-15", []string{"t3"}},
+		{"due=", []string{"t2"}},
+		{"due!=", []string{"t1", "t3"}},
+		{"estimate>=2", []string{"t1"}},
+		{"estimate=", []string{"t2", "t3"}},
+		{"field.points>5", []string{"t1"}},
+		{"field.points<=3", []string{"t2"}},
+		{"field.points=", []string{"t3"}},
+		{"updated>=2026-10-10", []string{"t2", "t3"}},
+		{"updated<2026-10-10 OR created<2026-01-01", []string{"t1"}},
+	}
+	for _, tt := range tests {
+		m, err := ParseQuery(tt.query)
+		if err != nil {
+			t.Errorf("ParseQuery(%q) unexpected error: %v", tt.query, err)
+			continue
+		}
+		var got []string
+		for _, task := range tasks {
+			if m.Matches(task) {
+				got = append(got, task.ID())
+			}
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("ParseQuery(%q) matched %v, want %v", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestParseQuery_Errors(t *testing.T) {
+	for _, query := range []string{
+		"",
+		"status",
+		"(status=todo",
+		"status=todo)",
+		"label in (a, b",
+		"label not (a)",
+		"status<todo",
+		"created=2026-01-01",
+		"priority<",
+		"priority>=9",
+		"assignee='ada",
+		"AND status=todo",
+		"colour=red",
+	} {
+		if _, err := ParseQuery(query); err == nil {
+			t.Errorf("ParseQuery(%q) expected error, got nil", query)
+		}
+	}
+
+	_, err := ParseQuery("status=todo OR")
+	if !errors.Is(err, ErrInvalidFilter) {
+		t.Errorf("expected ErrInvalidFilter, got %v", err)
+	}
+}
+
+func TestParseFilter_Query(t *testing.T) {
+	// A single equality keeps the simple form, so MergeFilters can merge it
+	f, err := ParseFilter("status = todo")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if f.Status == nil || *f.Status != Todo || len(f.Exprs) != 0 {
+		t.Errorf("expected a simple status filter, got %+v", f)
+	}
+
+	// Repeating a key is fine inside an expression
+	a, _ := ParseFilter("status=todo OR status=review")
+	b, _ := ParseFilter("archived=false OR archived=true")
+	merged, err := MergeFilters([]*TaskFilter{f, a, b})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(merged.Exprs) != 2 || !merged.SelectsArchived() {
+		t.Errorf("expected 2 expressions selecting archived tasks, got %+v", merged)
+	}
+
+	svc := newTestService(t)
+	if err := svc.ValidateFilter(merged); !errors.Is(err, ErrInvalidStatus) {
+		t.Errorf("expected the nested status to be validated, got %v", err)
+	}
+}
@@ -0,0 +1,235 @@
+package task
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/lucas-tremaroli/pace/internal/storage"
+)
+
+// Relation kinds, read as "task <kind> other task". Unlike dependencies,
+// relations never affect readiness.
+const (
+	RelRelatesTo   = "relates-to"
+	RelDuplicateOf = "duplicate-of"
+	RelSupersedes  = "supersedes"
+	RelCausedBy    = "caused-by"
+)
+
+// RelationKinds lists the valid relation kinds
+var RelationKinds = []string{RelRelatesTo, RelDuplicateOf, RelSupersedes, RelCausedBy}
+
+// inverseRelations names each kind as seen from the other task
+var inverseRelations = map[string]string{
+	RelRelatesTo:   RelRelatesTo,
+	RelDuplicateOf: "duplicated-by",
+	RelSupersedes:  "superseded-by",
+	RelCausedBy:    "causes",
+}
+
+// Relation is a relationship as seen from one task, e.g. a task that
+// another duplicates has {Kind: "duplicated-by", ID: <the duplicate>}
+type Relation struct {
+	Kind string `json:"kind"`
+	ID   string `json:"id"`
+}
+
+// ParseRelationKind parses a relation kind. The inverse names
+// (duplicated-by, superseded-by, causes) are accepted too, with swapped
+// set: "a duplicated-by b" is stored as "b duplicate-of a".
+func ParseRelationKind(s string) (kind string, swapped bool, err error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if slices.Contains(RelationKinds, s) {
+		return s, false, nil
+	}
+	for kind, inverse := range inverseRelations {
+		if s == inverse {
+			return kind, true, nil
+		}
+	}
+	return "", false, fmt.Errorf("%w: %q (valid: %s)", ErrInvalidRelation, s, strings.Join(RelationKinds, ", "))
+}
+
+// relationsFor returns the relations of a task from the records it is on
+// either side of
+func relationsFor(taskID string, records []storage.RelationRecord) []Relation {
+	var relations []Relation
+	for _, r := range records {
+		if r.TaskID == taskID {
+			relations = append(relations, Relation{Kind: r.Kind, ID: r.RelatedID})
+		} else {
+			relations = append(relations, Relation{Kind: inverseRelations[r.Kind], ID: r.TaskID})
+		}
+	}
+	return relations
+}
+
+// Relations returns the task's non-blocking relations. Only tasks loaded
+// individually have their relations loaded.
+func (t Task) Relations() []Relation {
+	return t.relations
+}
+
+// SetRelations sets the task's non-blocking relations
+func (t *Task) SetRelations(relations []Relation) {
+	t.relations = relations
+}
+
+// ListRelations returns the relations of a task, as seen from it
+func (s *Service) ListRelations(taskID string) ([]Relation, error) {
+	records, err := s.db.GetRelations(taskID)
+	if err != nil {
+		return nil, err
+	}
+	return relationsFor(taskID, records), nil
+}
+
+// AddRelation records that taskID <kind> relatedID, see ParseRelationKind
+func (s *Service) AddRelation(taskID, kind, relatedID string) error {
+	r, err := newRelation(taskID, kind, relatedID)
+	if err != nil {
+		return err
+	}
+	return s.Atomic(func() error { return s.addRelation(r) })
+}
+
+func newRelation(taskID, kind, relatedID string) (storage.RelationRecord, error) {
+	kind, swapped, err := ParseRelationKind(kind)
+	if err != nil {
+		return storage.RelationRecord{}, err
+	}
+	if swapped {
+		taskID, relatedID = relatedID, taskID
+	}
+	if taskID == relatedID {
+		return storage.RelationRecord{}, fmt.Errorf("%w: %s cannot be related to itself", ErrInvalidRelation, taskID)
+	}
+	return storage.RelationRecord{TaskID: taskID, Kind: kind, RelatedID: relatedID}, nil
+}
+
+// findRelation returns the stored record matching r, which for relates-to
+// may be recorded in either direction
+func (s *Service) findRelation(r storage.RelationRecord) (*storage.RelationRecord, error) {
+	records, err := s.db.GetRelations(r.TaskID)
+	if err != nil {
+		return nil, err
+	}
+	for _, existing := range records {
+		if existing == r {
+			return &existing, nil
+		}
+		reverse := storage.RelationRecord{TaskID: r.RelatedID, Kind: r.Kind, RelatedID: r.TaskID}
+		if r.Kind == RelRelatesTo && existing == reverse {
+			return &existing, nil
+		}
+	}
+	return nil, nil
+}
+
+func (s *Service) addRelation(r storage.RelationRecord) error {
+	for _, id := range []string{r.TaskID, r.RelatedID} {
+		exists, err := s.db.TaskExists(id)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return fmt.Errorf("%w: %s", ErrTaskNotFound, id)
+		}
+	}
+	existing, err := s.findRelation(r)
+	if err != nil || existing != nil {
+		return err
+	}
+
+	done, err := s.track(r.TaskID, r.RelatedID)
+	if err != nil {
+		return err
+	}
+	if err := s.db.AddRelation(r); err != nil {
+		return err
+	}
+	if err := s.recordEvent(r.TaskID, EventRelationAdded, "relation", "", r.Kind+" "+r.RelatedID); err != nil {
+		return err
+	}
+	if err := s.recordEvent(r.RelatedID, EventRelationAdded, "relation", "", inverseRelations[r.Kind]+" "+r.TaskID); err != nil {
+		return err
+	}
+	return done()
+}
+
+// RemoveRelation removes the relation taskID <kind> relatedID
+func (s *Service) RemoveRelation(taskID, kind, relatedID string) error {
+	r, err := newRelation(taskID, kind, relatedID)
+	if err != nil {
+		return err
+	}
+	return s.Atomic(func() error { return s.removeRelation(r) })
+}
+
+func (s *Service) removeRelation(r storage.RelationRecord) error {
+	existing, err := s.findRelation(r)
+	if err != nil {
+		return err
+	}
+	if existing == nil {
+		return fmt.Errorf("%w: %s %s %s", ErrRelationNotFound, r.TaskID, r.Kind, r.RelatedID)
+	}
+
+	done, err := s.track(existing.TaskID, existing.RelatedID)
+	if err != nil {
+		return err
+	}
+	if err := s.db.RemoveRelation(*existing); err != nil {
+		return err
+	}
+	if err := s.recordEvent(existing.TaskID, EventRelationRemoved, "relation", existing.Kind+" "+existing.RelatedID, ""); err != nil {
+		return err
+	}
+	if err := s.recordEvent(existing.RelatedID, EventRelationRemoved, "relation", inverseRelations[existing.Kind]+" "+existing.TaskID, ""); err != nil {
+		return err
+	}
+	return done()
+}
+
+// MarkDuplicate records taskID as a duplicate of originalID, moves its
+// labels onto the original, and closes it by moving it to the first
+// terminal status of the workflow
+func (s *Service) MarkDuplicate(taskID, originalID string) error {
+	r, err := newRelation(taskID, RelDuplicateOf, originalID)
+	if err != nil {
+		return err
+	}
+	return s.Atomic(func() error {
+		if err := s.addRelation(r); err != nil {
+			return err
+		}
+
+		labels, err := s.db.GetLabels(taskID)
+		if err != nil {
+			return err
+		}
+		for _, label := range labels {
+			if err := s.addLabel(originalID, label); err != nil {
+				return err
+			}
+			if err := s.removeLabel(taskID, label); err != nil {
+				return err
+			}
+		}
+
+		w, err := s.Workflow()
+		if err != nil {
+			return err
+		}
+		task, err := s.GetTaskByID(taskID)
+		if err != nil {
+			return err
+		}
+		if w.IsTerminal(task.Status()) {
+			return nil
+		}
+		task.status = w.Closed()
+		return s.updateTask(*task)
+	})
+}
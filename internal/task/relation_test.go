@@ -0,0 +1,104 @@
+package task
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestRelations(t *testing.T) {
+	svc := newTestService(t)
+	for _, id := range []string{"test-aaa", "test-bbb", "test-ccc"} {
+		if err := svc.CreateTask(NewTaskComplete(id, Todo, TypeTask, id, "", 3, "")); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if err := svc.AddRelation("test-aaa", RelRelatesTo, "test-bbb"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// relates-to is symmetric, so the reverse is the same relation
+	if err := svc.AddRelation("test-bbb", RelRelatesTo, "test-aaa"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// Inverse names are stored the other way round
+	if err := svc.AddRelation("test-bbb", "caused-by", "test-ccc"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := svc.AddRelation("test-ccc", "superseded-by", "test-aaa"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got, err := svc.GetTaskByID("test-bbb")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []Relation{{RelRelatesTo, "test-aaa"}, {RelCausedBy, "test-ccc"}}
+	if !slices.Equal(got.Relations(), want) {
+		t.Errorf("expected relations %v, got %v", want, got.Relations())
+	}
+	relations, err := svc.ListRelations("test-ccc")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want = []Relation{{"causes", "test-bbb"}, {"superseded-by", "test-aaa"}}
+	if !slices.Equal(relations, want) {
+		t.Errorf("expected relations %v, got %v", want, relations)
+	}
+
+	// Relations never block
+	ready, err := svc.GetReadyTasks(false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(ready) != 3 {
+		t.Errorf("expected all 3 tasks to be ready, got %d", len(ready))
+	}
+
+	if err := svc.RemoveRelation("test-bbb", RelRelatesTo, "test-aaa"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := svc.RemoveRelation("test-aaa", RelRelatesTo, "test-bbb"); !errors.Is(err, ErrRelationNotFound) {
+		t.Errorf("expected ErrRelationNotFound, got %v", err)
+	}
+	if err := svc.AddRelation("test-aaa", RelDuplicateOf, "test-aaa"); !errors.Is(err, ErrInvalidRelation) {
+		t.Errorf("expected ErrInvalidRelation, got %v", err)
+	}
+	if err := svc.AddRelation("test-aaa", "blocks", "test-bbb"); !errors.Is(err, ErrInvalidRelation) {
+		t.Errorf("expected ErrInvalidRelation, got %v", err)
+	}
+}
+
+func TestMarkDuplicate(t *testing.T) {
+	svc := newTestService(t)
+	for _, id := range []string{"test-aaa", "test-bbb"} {
+		if err := svc.CreateTask(NewTaskComplete(id, Todo, TypeTask, id, "", 3, "")); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if err := svc.AddLabel("test-bbb", "auth"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	svc.BeginOperation("mark duplicate")
+	if err := svc.MarkDuplicate("test-bbb", "test-aaa"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	original, _ := svc.GetTaskByID("test-aaa")
+	duplicate, _ := svc.GetTaskByID("test-bbb")
+	if !original.HasLabel("auth") || duplicate.HasLabel("auth") {
+		t.Errorf("expected the label to move to the original, got %v and %v", original.Labels(), duplicate.Labels())
+	}
+	if duplicate.Status() != Done || duplicate.CompletedAt().IsZero() {
+		t.Errorf("expected the duplicate to be closed, got %s", duplicate.Status())
+	}
+
+	// The whole change is undone together
+	if _, err := svc.Undo(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	duplicate, _ = svc.GetTaskByID("test-bbb")
+	if duplicate.Status() != Todo || !duplicate.HasLabel("auth") || len(duplicate.Relations()) != 0 {
+		t.Errorf("expected the duplicate to be restored, got %s %v %v", duplicate.Status(), duplicate.Labels(), duplicate.Relations())
+	}
+}
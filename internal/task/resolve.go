@@ -0,0 +1,86 @@
+package task
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// IDCandidate is a task that matched an ambiguous short ID
+type IDCandidate struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+}
+
+// AmbiguousIDError is returned when a short ID matches more than one task
+type AmbiguousIDError struct {
+	Input      string        `json:"input"`
+	Candidates []IDCandidate `json:"candidates"`
+}
+
+func (e *AmbiguousIDError) Error() string {
+	ids := make([]string, len(e.Candidates))
+	for i, c := range e.Candidates {
+		ids[i] = c.ID
+	}
+	return fmt.Sprintf("ambiguous ID %q matches %d tasks: %s", e.Input, len(ids), strings.Join(ids, ", "))
+}
+
+// ErrorData lists the candidates in the JSON error response
+func (e *AmbiguousIDError) ErrorData() any {
+	return e
+}
+
+// ResolveID expands a short ID to a full task ID. It accepts the full ID,
+// the ID without the project prefix ("a1b" for "pace-a1b"), or any unique
+// prefix of either ("a1", "pace-a1").
+func (s *Service) ResolveID(input string) (string, error) {
+	if input == "" {
+		return "", ErrEmptyID
+	}
+
+	// Exact matches win even if they are also a prefix of other IDs
+	for _, id := range []string{input, s.prefix + "-" + input} {
+		exists, err := s.db.TaskExists(id)
+		if err != nil {
+			return "", err
+		}
+		if exists {
+			return id, nil
+		}
+	}
+
+	records, err := s.db.GetAllTasks()
+	if err != nil {
+		return "", err
+	}
+
+	var candidates []IDCandidate
+	for _, r := range records {
+		if strings.HasPrefix(r.ID, input) || strings.HasPrefix(s.shortID(r.ID), input) {
+			candidates = append(candidates, IDCandidate{ID: r.ID, Title: r.Title})
+		}
+	}
+
+	switch len(candidates) {
+	case 0:
+		return "", fmt.Errorf("%w: %s", ErrTaskNotFound, input)
+	case 1:
+		return candidates[0].ID, nil
+	default:
+		slices.SortFunc(candidates, func(a, b IDCandidate) int { return strings.Compare(a.ID, b.ID) })
+		return "", &AmbiguousIDError{Input: input, Candidates: candidates}
+	}
+}
+
+// shortID strips the project prefix from an ID, or for IDs created under a
+// different prefix, everything up to the last hyphen
+func (s *Service) shortID(id string) string {
+	if short, ok := strings.CutPrefix(id, s.prefix+"-"); ok {
+		return short
+	}
+	if i := strings.LastIndex(id, "-"); i >= 0 {
+		return id[i+1:]
+	}
+	return id
+}
@@ -0,0 +1,62 @@
+package task
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestResolveID(t *testing.T) {
+	svc := newTestService(t)
+	for _, id := range []string{"test-a1b", "test-a1c", "test-f00", "other-b2c"} {
+		if err := svc.CreateTask(NewTaskComplete(id, Todo, TypeTask, "task "+id, "", 3, "")); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"test-a1b", "test-a1b"},   // full ID
+		{"a1b", "test-a1b"},        // without project prefix
+		{"f", "test-f00"},          // unique hash prefix
+		{"test-f", "test-f00"},     // unique full ID prefix
+		{"b2", "other-b2c"},        // hash prefix under another project prefix
+		{"other-b2c", "other-b2c"}, // full ID under another project prefix
+	}
+	for _, tt := range tests {
+		got, err := svc.ResolveID(tt.input)
+		if err != nil {
+			t.Errorf("ResolveID(%q): unexpected error: %v", tt.input, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ResolveID(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestResolveID_Ambiguous(t *testing.T) {
+	svc := newTestService(t)
+	for _, id := range []string{"test-a1b", "test-a1c"} {
+		if err := svc.CreateTask(NewTaskComplete(id, Todo, TypeTask, "task", "", 3, "")); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	_, err := svc.ResolveID("a1")
+	var ambiguous *AmbiguousIDError
+	if !errors.As(err, &ambiguous) {
+		t.Fatalf("expected AmbiguousIDError, got %v", err)
+	}
+	if len(ambiguous.Candidates) != 2 || ambiguous.Candidates[0].ID != "test-a1b" || ambiguous.Candidates[1].ID != "test-a1c" {
+		t.Errorf("unexpected candidates: %+v", ambiguous.Candidates)
+	}
+}
+
+func TestResolveID_NotFound(t *testing.T) {
+	svc := newTestService(t)
+	if _, err := svc.ResolveID("zzz"); !errors.Is(err, ErrTaskNotFound) {
+		t.Errorf("expected ErrTaskNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,151 @@
+package task
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// Snippet highlight markers around matched terms
+const (
+	HighlightStart = "**"
+	HighlightEnd   = "**"
+)
+
+// SearchResult is a task matched by Search, with a snippet of the matching
+// text and its relevance score (higher is better)
+type SearchResult struct {
+	Task    Task
+	Snippet string
+	Score   float64
+}
+
+// SearchResultJSON is the JSON form of a search result: the task with its
+// snippet and score
+type SearchResultJSON struct {
+	TaskJSON
+	Snippet string  `json:"snippet"`
+	Score   float64 `json:"score"`
+}
+
+// ToJSON converts a search result to its JSON form
+func (r SearchResult) ToJSON() SearchResultJSON {
+	return SearchResultJSON{TaskJSON: r.Task.ToJSON(), Snippet: r.Snippet, Score: r.Score}
+}
+
+// searchTerm is a word or phrase of a search query
+type searchTerm struct {
+	text    string
+	prefix  bool
+	exclude bool
+}
+
+// match returns the term as an FTS5 phrase, quoted so that punctuation in
+// the term is never read as query syntax
+func (t searchTerm) match() string {
+	s := `"` + strings.ReplaceAll(t.text, `"`, `""`) + `"`
+	if t.prefix {
+		s += "*"
+	}
+	return s
+}
+
+// ParseSearchQuery translates a search query into an FTS5 MATCH expression.
+// Words must all match; "exact phrase" matches the words in order, prefix*
+// matches words starting with prefix, and -word or -"phrase" excludes tasks
+// that match. Case and diacritics are ignored.
+func ParseSearchQuery(query string) (string, error) {
+	var include, exclude []string
+	for _, term := range splitSearchQuery(query) {
+		if term.exclude {
+			exclude = append(exclude, term.match())
+		} else {
+			include = append(include, term.match())
+		}
+	}
+	if len(include) == 0 {
+		if len(exclude) > 0 {
+			return "", fmt.Errorf("%w: %q has only exclusions, add a term to match", ErrInvalidSearch, query)
+		}
+		return "", fmt.Errorf("%w: %q has no terms", ErrInvalidSearch, query)
+	}
+
+	match := strings.Join(include, " ")
+	if len(exclude) > 0 {
+		match = "(" + match + ") NOT " + strings.Join(exclude, " NOT ")
+	}
+	return match, nil
+}
+
+// splitSearchQuery splits a query into terms. An unclosed quote runs to the
+// end of the query, and terms with no letters or digits are dropped since
+// they cannot match anything.
+func splitSearchQuery(query string) []searchTerm {
+	var terms []searchTerm
+	s := strings.TrimSpace(query)
+	for s != "" {
+		var term searchTerm
+		if rest, ok := strings.CutPrefix(s, "-"); ok && rest != "" && !unicode.IsSpace(rune(rest[0])) {
+			term.exclude = true
+			s = rest
+		}
+
+		if rest, ok := strings.CutPrefix(s, `"`); ok {
+			var found bool
+			term.text, s, found = strings.Cut(rest, `"`)
+			if found {
+				s, term.prefix = strings.CutPrefix(s, "*")
+			}
+		} else {
+			end := strings.IndexFunc(s, unicode.IsSpace)
+			if end < 0 {
+				end = len(s)
+			}
+			term.text, s = s[:end], s[end:]
+			term.text, term.prefix = strings.CutSuffix(term.text, "*")
+		}
+		s = strings.TrimSpace(s)
+
+		if strings.IndexFunc(term.text, func(r rune) bool { return unicode.IsLetter(r) || unicode.IsDigit(r) }) >= 0 {
+			terms = append(terms, term)
+		}
+	}
+	return terms
+}
+
+// Search returns the tasks matching a query (see ParseSearchQuery) over
+// titles, descriptions, labels and comments, best match first
+func (s *Service) Search(query string, includeArchived bool) ([]SearchResult, error) {
+	match, err := ParseSearchQuery(query)
+	if err != nil {
+		return nil, err
+	}
+	hits, err := s.db.SearchTasks(match, HighlightStart, HighlightEnd, includeArchived)
+	if err != nil || len(hits) == 0 {
+		return nil, err
+	}
+
+	ids := make([]string, len(hits))
+	for i, h := range hits {
+		ids[i] = h.TaskID
+	}
+	tasks, err := s.loadTasksByIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	// The tasks come back in hit order, less any task deleted since the
+	// search ran
+	results := make([]SearchResult, 0, len(tasks))
+	for _, h := range hits {
+		if len(tasks) > 0 && tasks[0].ID() == h.TaskID {
+			results = append(results, SearchResult{
+				Task:    tasks[0],
+				Snippet: h.Snippet,
+				Score:   -h.Rank,
+			})
+			tasks = tasks[1:]
+		}
+	}
+	return results, nil
+}
@@ -0,0 +1,158 @@
+package task
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestParseSearchQuery(t *testing.T) {
+	tests := map[string]string{
+		"login timeout":       `"login" "timeout"`,
+		`"exact phrase"`:      `"exact phrase"`,
+		"auth*":               `"auth"*`,
+		`"sign on"* x`:        `"sign on"* "x"`,
+		"auth -sso":           `("auth") NOT "sso"`,
+		`auth -"single sign"`: `("auth") NOT "single sign"`,
+		`say "hi`:             `"say" "hi"`,
+		`don"t - stop`:        `"don""t" "stop"`,
+		"foo-bar":             `"foo-bar"`,
+		"  spaced   out  ":    `"spaced" "out"`,
+	}
+	for query, want := range tests {
+		got, err := ParseSearchQuery(query)
+		if err != nil {
+			t.Errorf("ParseSearchQuery(%q) unexpected error: %v", query, err)
+			continue
+		}
+		if got != want {
+			t.Errorf("ParseSearchQuery(%q) = %s, want %s", query, got, want)
+		}
+	}
+
+	for _, query := range []string{"", "   ", "-sso", `""`, "* -"} {
+		if _, err := ParseSearchQuery(query); !errors.Is(err, ErrInvalidSearch) {
+			t.Errorf("ParseSearchQuery(%q) expected ErrInvalidSearch, got %v", query, err)
+		}
+	}
+}
+
+func TestSearch(t *testing.T) {
+	svc := newTestService(t)
+
+	for _, task := range []Task{
+		NewTaskComplete("test-aaa", Todo, TypeTask, "Login timeout", "Sessions expire after a minute", 3, ""),
+		NewTaskComplete("test-bbb", Todo, TypeBug, "Fix cache", "The login page shows a stale timeout banner", 3, ""),
+		NewTaskComplete("test-ccc", Todo, TypeTask, "Authentication rework", "Replace single sign-on", 3, ""),
+		NewTaskComplete("test-ddd", Todo, TypeTask, "Café menu", "", 3, ""),
+	} {
+		if err := svc.CreateTask(task); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if err := svc.AddLabel("test-ddd", "frontend"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := svc.AddComment("test-ccc", "Blocked on the identity provider"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	search := func(query string) []string {
+		t.Helper()
+		results, err := svc.Search(query, false)
+		if err != nil {
+			t.Fatalf("Search(%q) unexpected error: %v", query, err)
+		}
+		var ids []string
+		for _, r := range results {
+			ids = append(ids, r.Task.ID())
+		}
+		return ids
+	}
+
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		// Title matches rank above description matches
+		{"login timeout", []string{"test-aaa", "test-bbb"}},
+		{`"login timeout"`, []string{"test-aaa"}},
+		{"auth*", []string{"test-ccc"}},
+		{"login -cache", []string{"test-aaa"}},
+		{"frontend", []string{"test-ddd"}},
+		{"identity", []string{"test-ccc"}},
+		{"cafe", []string{"test-ddd"}},
+		{"payments", nil},
+	}
+	for _, tt := range tests {
+		if got := search(tt.query); !slices.Equal(got, tt.want) {
+			t.Errorf("Search(%q) = %v, want %v", tt.query, got, tt.want)
+		}
+	}
+
+	// Matches carry their labels, dependencies and subtasks
+	if err := svc.AddDependency("test-aaa", "test-ddd"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := svc.SetParent("test-ccc", "test-ddd"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	results, err := svc.Search("cafe", false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(results) != 1 {
+		t.Fatalf("expected 1 match, got %d", len(results))
+	}
+	if got := results[0].Task; !slices.Equal(got.Labels(), []string{"frontend"}) ||
+		!slices.Equal(got.BlockedBy(), []string{"test-aaa"}) ||
+		!slices.Equal(got.Children(), []string{"test-ccc"}) || got.Progress() == nil || got.Progress().Total != 1 {
+		t.Errorf("unexpected task: labels %v, blocked by %v, children %v, progress %v",
+			got.Labels(), got.BlockedBy(), got.Children(), got.Progress())
+	}
+
+	results, err = svc.Search("stale", false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(results) != 1 || results[0].Snippet != "The login page shows a **stale** timeout banner" || results[0].Score <= 0 {
+		t.Errorf("unexpected result: %+v", results)
+	}
+
+	// The index follows updates, label and comment removal, archiving and undo
+	if err := svc.UpdateTask(NewTaskComplete("test-bbb", Todo, TypeBug, "Fix cache", "Evict on deploy", 3, "")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := search("stale"); got != nil {
+		t.Errorf("expected no match for an old description, got %v", got)
+	}
+	if err := svc.RemoveLabel("test-ddd", "frontend"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := search("frontend"); got != nil {
+		t.Errorf("expected no match for a removed label, got %v", got)
+	}
+	if err := svc.ArchiveTask("test-aaa"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := search("login"); got != nil {
+		t.Errorf("expected archived tasks to be hidden, got %v", got)
+	}
+	if results, _ := svc.Search("login", true); len(results) != 1 {
+		t.Errorf("expected 1 match including archived, got %d", len(results))
+	}
+
+	svc.BeginOperation("delete")
+	if err := svc.DeleteTask("test-ccc"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := search("identity"); got != nil {
+		t.Errorf("expected no match for a deleted task, got %v", got)
+	}
+	if _, err := svc.Undo(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := search("identity"); !slices.Equal(got, []string{"test-ccc"}) {
+		t.Errorf("expected the restored task to match, got %v", got)
+	}
+}
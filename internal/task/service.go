@@ -1,6 +1,12 @@
 package task
 
 import (
+	"database/sql"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
 	"github.com/lucas-tremaroli/pace/internal/storage"
 )
 
@@ -8,6 +14,11 @@ import (
 type Service struct {
 	db     *storage.DB
 	prefix string
+
+	// Current undoable operation, see BeginOperation
+	opCommand string
+	opID      int64
+	opTracked map[string]bool
 }
 
 // NewService creates a new task service
@@ -27,14 +38,91 @@ func NewService() (*Service, error) {
 	return &Service{db: db, prefix: prefix}, nil
 }
 
+// NewServiceWithDB creates a service backed by an already opened database (for testing)
+func NewServiceWithDB(db *storage.DB, prefix string) *Service {
+	return &Service{db: db, prefix: prefix}
+}
+
+// Workflow returns the statuses configured for the store
+func (s *Service) Workflow() (Workflow, error) {
+	return LoadWorkflow(s.db)
+}
+
+// ValidateFilter checks that the statuses a filter compares against are in
+// the store's workflow and its types are defined, including those nested in
+// query expressions
+func (s *Service) ValidateFilter(f *TaskFilter) error {
+	var statuses []Status
+	var types []TaskType
+	walkFilters(f, func(leaf *TaskFilter) {
+		if leaf.Status != nil {
+			statuses = append(statuses, *leaf.Status)
+		}
+		if leaf.Type != nil {
+			types = append(types, *leaf.Type)
+		}
+	})
+
+	if len(statuses) > 0 {
+		w, err := s.Workflow()
+		if err != nil {
+			return err
+		}
+		for _, status := range statuses {
+			if err := w.Check(status); err != nil {
+				return err
+			}
+		}
+	}
+	if len(types) > 0 {
+		registry, err := s.Types()
+		if err != nil {
+			return err
+		}
+		for _, t := range types {
+			if err := registry.Check(t); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 // Prefix returns the current ID prefix
 func (s *Service) Prefix() string {
 	return s.prefix
 }
 
-// GenerateTaskID creates a new unique task ID with the configured prefix
-func (s *Service) GenerateTaskID() string {
-	return GenerateID(s.prefix)
+// GenerateTaskID returns a new unused task ID with the configured prefix
+// and ID scheme. Nothing holds the ID until a task is created with it; use
+// CreateNewTask to create a task under a new ID.
+func (s *Service) GenerateTaskID() (string, error) {
+	var id string
+	err := s.Atomic(func() error {
+		var err error
+		id, err = NextID(s.db, s.prefix)
+		return err
+	})
+	return id, err
+}
+
+// Atomic runs fn in a single transaction: every change fn makes through
+// the service is committed together, or rolled back if fn returns an error.
+// Nested calls join the outer transaction.
+func (s *Service) Atomic(fn func() error) error {
+	original := s.db
+	opID, opTracked := s.opID, maps.Clone(s.opTracked)
+
+	err := original.WithTx(func(tx *storage.DB) error {
+		s.db = tx
+		defer func() { s.db = original }()
+		return fn()
+	})
+	if err != nil && !original.InTx() {
+		// The journal entry was rolled back with everything else
+		s.opID, s.opTracked = opID, opTracked
+	}
+	return err
 }
 
 // Close closes the database connection
@@ -45,26 +133,292 @@ func (s *Service) Close() error {
 	return nil
 }
 
-// CreateTask creates a new task and saves it to the database
+// CreateTask creates a new task and saves it to the database.
+// Created and updated times are set to now, as is the completed time
+// if the task is created as done.
 func (s *Service) CreateTask(task Task) error {
+	return s.Atomic(func() error { return s.createTask(task) })
+}
+
+// CreateNewTask creates a task under a new ID and returns it with that ID.
+// The ID is picked in the same transaction that creates the task, so
+// another writer cannot take it in between.
+func (s *Service) CreateNewTask(task Task) (Task, error) {
+	err := s.Atomic(func() error {
+		id, err := NextID(s.db, s.prefix)
+		if err != nil {
+			return err
+		}
+		task.id = id
+		return s.createTask(task)
+	})
+	if err != nil {
+		return Task{}, err
+	}
+	return task, nil
+}
+
+func (s *Service) createTask(task Task) error {
 	if err := task.Validate(); err != nil {
 		return err
 	}
+	w, err := s.Workflow()
+	if err != nil {
+		return err
+	}
+	if err := w.Check(task.Status()); err != nil {
+		return err
+	}
+	types, err := s.Types()
+	if err != nil {
+		return err
+	}
+	if err := types.Check(task.Type()); err != nil {
+		return err
+	}
+
+	if task.ID() == "" {
+		return ErrEmptyID
+	}
+	exists, err := s.db.TaskExists(task.ID())
+	if err != nil {
+		return err
+	}
+	if exists {
+		return fmt.Errorf("%w: %s", ErrDuplicateID, task.ID())
+	}
+	// Fields without a value have nothing to set on a new task
+	var fields []Field
+	for _, f := range task.fields {
+		if f.Value == "" {
+			continue
+		}
+		f, err := newField(f.Key, f.Type, f.Value)
+		if err != nil {
+			return err
+		}
+		fields = append(fields, f)
+	}
+	if task.parentID != "" {
+		if err := s.checkParent(task.ID(), task.parentID); err != nil {
+			return err
+		}
+	}
+
+	ts := now()
+	task.createdAt = ts
+	task.updatedAt = ts
+	task.completedAt = time.Time{}
+	if w.IsTerminal(task.Status()) {
+		task.completedAt = ts
+	}
+
+	record, err := toRecord(task, types)
+	if err != nil {
+		return err
+	}
 
-	return s.db.CreateTask(task.ID(), task.Title(), task.Description(), int(task.Status()), int(task.Type()), task.Priority(), task.Link())
+	done, err := s.track(task.ID())
+	if err != nil {
+		return err
+	}
+	if err := s.db.CreateTask(record); err != nil {
+		return err
+	}
+	for _, f := range fields {
+		if err := s.db.SetTaskField(task.ID(), toFieldRecord(f)); err != nil {
+			return err
+		}
+	}
+	if err := s.recordEvent(task.ID(), EventCreated, "", "", task.Title()); err != nil {
+		return err
+	}
+	return done()
 }
 
-// UpdateTask updates an existing task in the database
+// UpdateTask updates an existing task in the database.
+// The created time is preserved, the updated time is set to now, and the
+// completed time is set when the task moves into a terminal status and
+// cleared when it moves out of one. The parent, due date, estimate and
+// assignee are kept; use SetParent, SetDue, SetEstimate and SetAssignee to
+// change them.
 func (s *Service) UpdateTask(task Task) error {
+	return s.Atomic(func() error { return s.updateTask(task) })
+}
+
+func (s *Service) updateTask(task Task) error {
 	if err := task.Validate(); err != nil {
 		return err
 	}
+	existing, err := s.db.GetTaskByID(task.ID())
+	if err != nil {
+		return err
+	}
 
-	return s.db.UpdateTask(task.ID(), task.Title(), task.Description(), int(task.Status()), int(task.Type()), task.Priority(), task.Link())
+	// A task keeps a status that was since removed from the workflow until
+	// it is moved
+	w, err := s.Workflow()
+	if err != nil {
+		return err
+	}
+	if task.Status() != Status(existing.Status) {
+		if err := w.Check(task.Status()); err != nil {
+			return err
+		}
+	}
+
+	ts := now()
+	task.createdAt = parseTimestamp(existing.CreatedAt)
+	task.updatedAt = ts
+	task.archivedAt = parseTimestamp(existing.ArchivedAt)
+	task.parentID = existing.ParentID
+	task.due = parseDate(existing.DueDate)
+	task.estimate = existing.Estimate
+	task.assignee = existing.Assignee
+	task.completedAt = time.Time{}
+	if w.IsTerminal(task.Status()) {
+		task.completedAt = parseTimestamp(existing.CompletedAt)
+		if !w.IsTerminal(Status(existing.Status)) || task.completedAt.IsZero() {
+			task.completedAt = ts
+		}
+	}
+
+	types, err := s.Types()
+	if err != nil {
+		return err
+	}
+	record, err := toRecord(task, types)
+	if err != nil {
+		return err
+	}
+
+	done, err := s.track(task.ID())
+	if err != nil {
+		return err
+	}
+	if err := s.db.UpdateTask(record); err != nil {
+		return err
+	}
+	if err := s.recordFieldChanges(*existing, record); err != nil {
+		return err
+	}
+	return done()
+}
+
+// toRecord converts a Task to its storage representation, failing if its
+// type is not defined
+func toRecord(t Task, types TypeRegistry) (storage.TaskRecord, error) {
+	code, err := types.code(t.taskType)
+	if err != nil {
+		return storage.TaskRecord{}, err
+	}
+	return storage.TaskRecord{
+		ID:          t.id,
+		Title:       t.title,
+		Description: t.description,
+		Status:      string(t.status),
+		TaskType:    code,
+		Priority:    t.priority,
+		Link:        t.link,
+		CreatedAt:   formatTimestamp(t.createdAt),
+		UpdatedAt:   formatTimestamp(t.updatedAt),
+		CompletedAt: formatTimestamp(t.completedAt),
+		ArchivedAt:  formatTimestamp(t.archivedAt),
+		ParentID:    t.parentID,
+		DueDate:     FormatDate(t.due),
+		Estimate:    t.estimate,
+		Assignee:    t.assignee,
+	}, nil
+}
+
+// fromRecord converts a storage record to a Task without dependencies or labels
+func fromRecord(record storage.TaskRecord, types TypeRegistry) Task {
+	task := NewTaskComplete(record.ID, Status(record.Status), types.byCode(record.TaskType), record.Title, record.Description, record.Priority, record.Link)
+	task.createdAt = parseTimestamp(record.CreatedAt)
+	task.updatedAt = parseTimestamp(record.UpdatedAt)
+	task.completedAt = parseTimestamp(record.CompletedAt)
+	task.archivedAt = parseTimestamp(record.ArchivedAt)
+	task.parentID = record.ParentID
+	task.due = parseDate(record.DueDate)
+	task.estimate = record.Estimate
+	task.assignee = record.Assignee
+	return task
+}
+
+// updateRecord applies change to a stored task, bumps its updated time and
+// records the changed fields. Nothing is written if no field changes.
+func (s *Service) updateRecord(taskID string, change func(*storage.TaskRecord)) error {
+	record, err := s.db.GetTaskByID(taskID)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("%w: %s", ErrTaskNotFound, taskID)
+	}
+	if err != nil {
+		return err
+	}
+	before := *record
+	change(record)
+	if *record == before {
+		return nil
+	}
+
+	done, err := s.track(taskID)
+	if err != nil {
+		return err
+	}
+	record.UpdatedAt = formatTimestamp(now())
+	if err := s.db.UpdateTask(*record); err != nil {
+		return err
+	}
+	if err := s.recordFieldChanges(before, *record); err != nil {
+		return err
+	}
+	return done()
 }
 
 // DeleteTask removes a task from the database and cleans up dependencies and labels
 func (s *Service) DeleteTask(taskID string) error {
+	return s.Atomic(func() error { return s.deleteTask(taskID) })
+}
+
+func (s *Service) deleteTask(taskID string) error {
+	existing, err := s.db.GetTaskByID(taskID)
+	if err != nil && err != sql.ErrNoRows {
+		return err
+	}
+
+	// Track neighbours too, since deleting the task removes their edges
+	blockedBy, err := s.db.GetBlockers(taskID)
+	if err != nil {
+		return err
+	}
+	blocks, err := s.db.GetBlocking(taskID)
+	if err != nil {
+		return err
+	}
+	// Subtasks become top-level tasks
+	children, err := s.db.GetChildTasks(taskID)
+	if err != nil {
+		return err
+	}
+	tracked := append(append([]string{taskID}, blockedBy...), blocks...)
+	for _, child := range children {
+		tracked = append(tracked, child.ID)
+	}
+	done, err := s.track(tracked...)
+	if err != nil {
+		return err
+	}
+	for _, child := range children {
+		child.ParentID = ""
+		child.UpdatedAt = formatTimestamp(now())
+		if err := s.db.UpdateTask(child); err != nil {
+			return err
+		}
+		if err := s.recordEvent(child.ID, EventUpdated, "parent", taskID, ""); err != nil {
+			return err
+		}
+	}
+
 	// Remove all dependencies involving this task first
 	if err := s.db.RemoveAllDependencies(taskID); err != nil {
 		return err
@@ -73,15 +427,55 @@ func (s *Service) DeleteTask(taskID string) error {
 	if err := s.db.RemoveAllLabels(taskID); err != nil {
 		return err
 	}
-	return s.db.DeleteTask(taskID)
+	if err := s.db.RemoveAllComments(taskID); err != nil {
+		return err
+	}
+	if err := s.db.RemoveAllTaskFields(taskID); err != nil {
+		return err
+	}
+	if err := s.db.RemoveAllLinks(taskID); err != nil {
+		return err
+	}
+	if err := s.db.RemoveAllRelations(taskID); err != nil {
+		return err
+	}
+	if err := s.db.DeleteTask(taskID); err != nil {
+		return err
+	}
+	if existing == nil {
+		return done()
+	}
+	if err := s.recordEvent(taskID, EventDeleted, "", existing.Title, ""); err != nil {
+		return err
+	}
+	return done()
+}
+
+// LoadTasks retrieves tasks with dependencies and labels, leaving out
+// archived tasks unless includeArchived is set
+func (s *Service) LoadTasks(includeArchived bool) ([]Task, error) {
+	tasks, err := s.LoadAllTasks()
+	if err != nil || includeArchived {
+		return tasks, err
+	}
+	return slices.DeleteFunc(tasks, Task.IsArchived), nil
 }
 
-// LoadAllTasks retrieves all tasks from the database with dependencies and labels
+// LoadAllTasks retrieves all tasks, including archived ones, from the
+// database with dependencies and labels
 func (s *Service) LoadAllTasks() ([]Task, error) {
 	taskRecords, err := s.db.GetAllTasks()
 	if err != nil {
 		return nil, err
 	}
+	w, err := s.Workflow()
+	if err != nil {
+		return nil, err
+	}
+	types, err := s.Types()
+	if err != nil {
+		return nil, err
+	}
 
 	// Load all dependencies at once for efficiency
 	blockedByMap, blocksMap, err := s.db.GetAllDependencies()
@@ -94,19 +488,93 @@ func (s *Service) LoadAllTasks() ([]Task, error) {
 	if err != nil {
 		return nil, err
 	}
+	fieldsMap, err := s.db.GetAllTaskFields()
+	if err != nil {
+		return nil, err
+	}
+	linksMap, err := s.db.GetAllLinks()
+	if err != nil {
+		return nil, err
+	}
 
 	var tasks []Task
 	for _, record := range taskRecords {
-		task := NewTaskComplete(record.ID, Status(record.Status), TaskType(record.TaskType), record.Title, record.Description, record.Priority, record.Link)
+		task := fromRecord(record, types)
 		task.SetBlockedBy(blockedByMap[record.ID])
 		task.SetBlocks(blocksMap[record.ID])
 		task.SetLabels(labelsMap[record.ID])
+		task.SetFields(fromFieldRecords(fieldsMap[record.ID]))
+		task.SetLinks(linksMap[record.ID])
 		tasks = append(tasks, task)
 	}
+	linkHierarchy(tasks, w)
 
 	return tasks, nil
 }
 
+// loadTasksByIDs retrieves the tasks with the given IDs, in that order,
+// with dependencies, labels and subtask progress. IDs with no task are
+// skipped.
+func (s *Service) loadTasksByIDs(ids []string) ([]Task, error) {
+	taskRecords, err := s.db.GetTasksByIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+	w, err := s.Workflow()
+	if err != nil {
+		return nil, err
+	}
+	types, err := s.Types()
+	if err != nil {
+		return nil, err
+	}
+
+	blockedByMap, blocksMap, err := s.db.GetDependenciesOf(ids)
+	if err != nil {
+		return nil, err
+	}
+	labelsMap, err := s.db.GetLabelsOf(ids)
+	if err != nil {
+		return nil, err
+	}
+	fieldsMap, err := s.db.GetTaskFieldsOf(ids)
+	if err != nil {
+		return nil, err
+	}
+	linksMap, err := s.db.GetLinksOf(ids)
+	if err != nil {
+		return nil, err
+	}
+	children, err := s.db.GetChildTasksOf(ids)
+	if err != nil {
+		return nil, err
+	}
+	childrenMap := make(map[string][]storage.TaskRecord)
+	for _, child := range children {
+		childrenMap[child.ParentID] = append(childrenMap[child.ParentID], child)
+	}
+
+	byID := make(map[string]Task, len(taskRecords))
+	for _, record := range taskRecords {
+		task := fromRecord(record, types)
+		task.SetBlockedBy(blockedByMap[record.ID])
+		task.SetBlocks(blocksMap[record.ID])
+		task.SetLabels(labelsMap[record.ID])
+		task.SetFields(fromFieldRecords(fieldsMap[record.ID]))
+		task.SetLinks(linksMap[record.ID])
+		task.addSubtasks(childrenMap[record.ID], w)
+		byID[record.ID] = task
+	}
+
+	tasks := make([]Task, 0, len(byID))
+	for _, id := range ids {
+		if task, ok := byID[id]; ok {
+			tasks = append(tasks, task)
+		}
+	}
+	return tasks, nil
+}
+
 // GetTaskByID retrieves a single task by its ID with dependencies and labels
 func (s *Service) GetTaskByID(taskID string) (*Task, error) {
 	record, err := s.db.GetTaskByID(taskID)
@@ -114,7 +582,11 @@ func (s *Service) GetTaskByID(taskID string) (*Task, error) {
 		return nil, err
 	}
 
-	task := NewTaskComplete(record.ID, Status(record.Status), TaskType(record.TaskType), record.Title, record.Description, record.Priority, record.Link)
+	types, err := s.Types()
+	if err != nil {
+		return nil, err
+	}
+	task := fromRecord(*record, types)
 
 	// Load dependencies for this task
 	blockedBy, err := s.db.GetBlockers(taskID)
@@ -135,11 +607,64 @@ func (s *Service) GetTaskByID(taskID string) (*Task, error) {
 	}
 	task.SetLabels(labels)
 
+	fields, err := s.db.GetTaskFields(taskID)
+	if err != nil {
+		return nil, err
+	}
+	task.SetFields(fromFieldRecords(fields))
+
+	links, err := s.db.GetLinks(taskID)
+	if err != nil {
+		return nil, err
+	}
+	task.SetLinks(links)
+
+	relations, err := s.ListRelations(taskID)
+	if err != nil {
+		return nil, err
+	}
+	task.SetRelations(relations)
+
+	comments, err := s.db.GetComments(taskID)
+	if err != nil {
+		return nil, err
+	}
+	task.SetComments(comments)
+
+	// Load subtasks for the progress roll-up
+	children, err := s.db.GetChildTasks(taskID)
+	if err != nil {
+		return nil, err
+	}
+	w, err := s.Workflow()
+	if err != nil {
+		return nil, err
+	}
+	task.addSubtasks(children, w)
+
 	return &task, nil
 }
 
-// AddDependency creates a blocking relationship where blocker blocks blocked
+// addSubtasks records children as the task's subtasks and rolls their
+// statuses up into its progress
+func (t *Task) addSubtasks(children []storage.TaskRecord, w Workflow) {
+	for _, child := range children {
+		t.children = append(t.children, child.ID)
+		if t.progress == nil {
+			t.progress = &Progress{}
+		}
+		t.progress.addChild(w.IsTerminal(Status(child.Status)), child.ArchivedAt != "")
+	}
+}
+
+// AddDependency creates a blocking relationship where blocker blocks blocked.
+// It returns a *CycleError if blocked already blocks blocker, directly or
+// through other tasks.
 func (s *Service) AddDependency(blockerID, blockedID string) error {
+	return s.Atomic(func() error { return s.addDependency(blockerID, blockedID) })
+}
+
+func (s *Service) addDependency(blockerID, blockedID string) error {
 	// Verify both tasks exist
 	if _, err := s.db.GetTaskByID(blockerID); err != nil {
 		return err
@@ -147,34 +672,133 @@ func (s *Service) AddDependency(blockerID, blockedID string) error {
 	if _, err := s.db.GetTaskByID(blockedID); err != nil {
 		return err
 	}
-	return s.db.AddDependency(blockerID, blockedID)
+	blockers, err := s.db.GetBlockers(blockedID)
+	if err != nil {
+		return err
+	}
+	if slices.Contains(blockers, blockerID) {
+		return nil
+	}
+	_, blocks, err := s.db.GetAllDependencies()
+	if err != nil {
+		return err
+	}
+	if cycle := DependencyCycle(blocks, blockerID, blockedID); cycle != nil {
+		return &CycleError{Cycle: cycle}
+	}
+	done, err := s.track(blockedID, blockerID)
+	if err != nil {
+		return err
+	}
+	if err := s.db.AddDependency(blockerID, blockedID); err != nil {
+		return err
+	}
+	if err := s.recordEvent(blockedID, EventDependencyAdded, "blocked_by", "", blockerID); err != nil {
+		return err
+	}
+	if err := s.recordEvent(blockerID, EventDependencyAdded, "blocks", "", blockedID); err != nil {
+		return err
+	}
+	return done()
 }
 
 // RemoveDependency removes a blocking relationship
 func (s *Service) RemoveDependency(blockerID, blockedID string) error {
-	return s.db.RemoveDependency(blockerID, blockedID)
+	return s.Atomic(func() error { return s.removeDependency(blockerID, blockedID) })
+}
+
+func (s *Service) removeDependency(blockerID, blockedID string) error {
+	blockers, err := s.db.GetBlockers(blockedID)
+	if err != nil {
+		return err
+	}
+	if !slices.Contains(blockers, blockerID) {
+		return nil
+	}
+	done, err := s.track(blockedID, blockerID)
+	if err != nil {
+		return err
+	}
+	if err := s.db.RemoveDependency(blockerID, blockedID); err != nil {
+		return err
+	}
+	if err := s.recordEvent(blockedID, EventDependencyRemoved, "blocked_by", blockerID, ""); err != nil {
+		return err
+	}
+	if err := s.recordEvent(blockerID, EventDependencyRemoved, "blocks", blockedID, ""); err != nil {
+		return err
+	}
+	return done()
 }
 
 // AddLabel adds a label to a task
 func (s *Service) AddLabel(taskID, label string) error {
+	return s.Atomic(func() error { return s.addLabel(taskID, label) })
+}
+
+func (s *Service) addLabel(taskID, label string) error {
 	// Verify task exists
 	if _, err := s.db.GetTaskByID(taskID); err != nil {
 		return err
 	}
-	return s.db.AddLabel(taskID, label)
+	labels, err := s.db.GetLabels(taskID)
+	if err != nil {
+		return err
+	}
+	if slices.Contains(labels, label) {
+		return nil
+	}
+	done, err := s.track(taskID)
+	if err != nil {
+		return err
+	}
+	if err := s.db.AddLabel(taskID, label); err != nil {
+		return err
+	}
+	if err := s.recordEvent(taskID, EventLabelAdded, "label", "", label); err != nil {
+		return err
+	}
+	return done()
 }
 
 // RemoveLabel removes a label from a task
 func (s *Service) RemoveLabel(taskID, label string) error {
-	return s.db.RemoveLabel(taskID, label)
+	return s.Atomic(func() error { return s.removeLabel(taskID, label) })
 }
 
-// GetReadyTasks returns tasks that have no blockers or all blockers are done
-func (s *Service) GetReadyTasks() ([]Task, error) {
+func (s *Service) removeLabel(taskID, label string) error {
+	labels, err := s.db.GetLabels(taskID)
+	if err != nil {
+		return err
+	}
+	if !slices.Contains(labels, label) {
+		return nil
+	}
+	done, err := s.track(taskID)
+	if err != nil {
+		return err
+	}
+	if err := s.db.RemoveLabel(taskID, label); err != nil {
+		return err
+	}
+	if err := s.recordEvent(taskID, EventLabelRemoved, "label", label, ""); err != nil {
+		return err
+	}
+	return done()
+}
+
+// GetReadyTasks returns tasks that have no blockers or all blockers are done.
+// Any terminal status counts as done. Archived tasks are left out unless
+// includeArchived is set.
+func (s *Service) GetReadyTasks(includeArchived bool) ([]Task, error) {
 	tasks, err := s.LoadAllTasks()
 	if err != nil {
 		return nil, err
 	}
+	w, err := s.Workflow()
+	if err != nil {
+		return nil, err
+	}
 
 	// Build a map of task status by ID
 	statusMap := make(map[string]Status)
@@ -185,14 +809,17 @@ func (s *Service) GetReadyTasks() ([]Task, error) {
 	var ready []Task
 	for _, t := range tasks {
 		// Skip completed tasks
-		if t.Status() == Done {
+		if w.IsTerminal(t.Status()) {
+			continue
+		}
+		if t.IsArchived() && !includeArchived {
 			continue
 		}
 
 		// Check if all blockers are done
 		isReady := true
 		for _, blockerID := range t.BlockedBy() {
-			if status, exists := statusMap[blockerID]; exists && status != Done {
+			if status, exists := statusMap[blockerID]; exists && !w.IsTerminal(status) {
 				isReady = false
 				break
 			}
@@ -0,0 +1,155 @@
+package task
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/lucas-tremaroli/pace/internal/storage"
+)
+
+// newTestService creates a service backed by a fresh database in a temp dir
+func newTestService(t *testing.T) *Service {
+	t.Helper()
+	db, err := storage.NewDBWithPath(filepath.Join(t.TempDir(), "tasks.db"))
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	svc := NewServiceWithDB(db, "test")
+	t.Cleanup(func() { svc.Close() })
+	return svc
+}
+
+// newTestID generates an unused task ID or fails the test
+func newTestID(t *testing.T, svc *Service) string {
+	t.Helper()
+	id, err := svc.GenerateTaskID()
+	if err != nil {
+		t.Fatalf("failed to generate ID: %v", err)
+	}
+	return id
+}
+
+func TestCreateTask_SetsTimestamps(t *testing.T) {
+	svc := newTestService(t)
+
+	task := NewTaskComplete(newTestID(t, svc), Todo, TypeTask, "write tests", "", 3, "")
+	if err := svc.CreateTask(task); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got, err := svc.GetTaskByID(task.ID())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.CreatedAt().IsZero() {
+		t.Error("expected created_at to be set")
+	}
+	if !got.UpdatedAt().Equal(got.CreatedAt()) {
+		t.Errorf("expected updated_at %v to equal created_at %v", got.UpdatedAt(), got.CreatedAt())
+	}
+	if !got.CompletedAt().IsZero() {
+		t.Errorf("expected completed_at to be empty for todo task, got %v", got.CompletedAt())
+	}
+}
+
+func TestCreateTask_DoneSetsCompletedAt(t *testing.T) {
+	svc := newTestService(t)
+
+	task := NewTaskComplete(newTestID(t, svc), Done, TypeTask, "already done", "", 3, "")
+	if err := svc.CreateTask(task); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got, err := svc.GetTaskByID(task.ID())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.CompletedAt().IsZero() {
+		t.Error("expected completed_at to be set for done task")
+	}
+}
+
+func TestUpdateTask_StatusTransitions(t *testing.T) {
+	svc := newTestService(t)
+
+	task := NewTaskComplete(newTestID(t, svc), Todo, TypeTask, "transition", "", 3, "")
+	if err := svc.CreateTask(task); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	created, _ := svc.GetTaskByID(task.ID())
+
+	// Into done
+	task = NewTaskComplete(task.ID(), Done, TypeTask, "transition", "", 3, "")
+	if err := svc.UpdateTask(task); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	done, _ := svc.GetTaskByID(task.ID())
+	if done.CompletedAt().IsZero() {
+		t.Error("expected completed_at to be set after moving to done")
+	}
+	if !done.CreatedAt().Equal(created.CreatedAt()) {
+		t.Errorf("expected created_at to be preserved, got %v want %v", done.CreatedAt(), created.CreatedAt())
+	}
+
+	// Out of done
+	task = NewTaskComplete(task.ID(), InProgress, TypeTask, "transition", "", 3, "")
+	if err := svc.UpdateTask(task); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	reopened, _ := svc.GetTaskByID(task.ID())
+	if !reopened.CompletedAt().IsZero() {
+		t.Errorf("expected completed_at to be cleared after reopening, got %v", reopened.CompletedAt())
+	}
+}
+
+func TestUpdateTask_NotFound(t *testing.T) {
+	svc := newTestService(t)
+
+	task := NewTaskComplete("test-missing", Todo, TypeTask, "ghost", "", 3, "")
+	if err := svc.UpdateTask(task); err == nil {
+		t.Error("expected error updating a task that does not exist")
+	}
+}
+
+func TestAtomic_RollsBackOnError(t *testing.T) {
+	svc := newTestService(t)
+	svc.BeginOperation("test")
+
+	existing := NewTaskComplete(newTestID(t, svc), Todo, TypeTask, "existing", "", 3, "")
+	if err := svc.CreateTask(existing); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	created := NewTaskComplete(newTestID(t, svc), Todo, TypeTask, "rolled back", "", 3, "")
+	err := svc.Atomic(func() error {
+		if err := svc.CreateTask(created); err != nil {
+			return err
+		}
+		if err := svc.AddLabel(existing.ID(), "sprint-1"); err != nil {
+			return err
+		}
+		return svc.AddDependency(existing.ID(), "missing-task")
+	})
+	if err == nil {
+		t.Fatal("expected error from missing dependency")
+	}
+
+	if _, err := svc.GetTaskByID(created.ID()); err == nil {
+		t.Error("expected task created in the failed transaction to be rolled back")
+	}
+	got, err := svc.GetTaskByID(existing.ID())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got.Labels()) != 0 {
+		t.Errorf("expected label to be rolled back, got %v", got.Labels())
+	}
+
+	// The operation still undoes cleanly with only the committed change
+	if _, err := svc.Undo(); err != nil {
+		t.Fatalf("unexpected error on undo: %v", err)
+	}
+	if _, err := svc.GetTaskByID(existing.ID()); err == nil {
+		t.Error("expected undo to remove the committed task")
+	}
+}
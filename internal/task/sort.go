@@ -0,0 +1,114 @@
+package task
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// sortField orders tasks by one attribute. Tasks for which missing is true
+// have no value and always sort last, whichever the direction.
+type sortField struct {
+	compare func(a, b Task) int
+	missing func(t Task) bool
+}
+
+var sortFields = map[string]sortField{
+	"priority": {compare: func(a, b Task) int { return a.Priority() - b.Priority() }},
+	"created":  {compare: func(a, b Task) int { return b.CreatedAt().Compare(a.CreatedAt()) }},
+	"updated":  {compare: func(a, b Task) int { return b.UpdatedAt().Compare(a.UpdatedAt()) }},
+	"due": {
+		compare: func(a, b Task) int { return a.Due().Compare(b.Due()) },
+		missing: func(t Task) bool { return t.Due().IsZero() },
+	},
+	"estimate": {
+		compare: func(a, b Task) int { return cmp.Compare(a.Estimate(), b.Estimate()) },
+		missing: func(t Task) bool { return t.Estimate() == 0 },
+	},
+	"title": {compare: func(a, b Task) int {
+		return cmp.Compare(strings.ToLower(a.Title()), strings.ToLower(b.Title()))
+	}},
+	"id": {compare: func(a, b Task) int { return cmp.Compare(a.ID(), b.ID()) }},
+}
+
+// SortFields lists the fields accepted by SortTasks
+var SortFields = []string{"priority", "created", "updated", "due", "estimate", "title", "id"}
+
+// SortTasks sorts tasks in place by a sort spec: one or more comma separated
+// fields, each deciding only between tasks the previous ones tie on. A
+// leading - reverses a field's order. By default:
+//   - priority: P1 first, P4 last (the default field)
+//   - created: newest first
+//   - updated: most recently updated first
+//   - due: soonest due first, tasks without a due date last
+//   - estimate: smallest first, tasks without an estimate last
+//   - title: alphabetical, ignoring case
+//   - id: alphabetical
+func SortTasks(tasks []Task, spec string) error {
+	compare, err := ParseSort(spec)
+	if err != nil {
+		return err
+	}
+	slices.SortStableFunc(tasks, compare)
+	return nil
+}
+
+// ParseSort parses a sort spec (see SortTasks) into a comparison function
+func ParseSort(spec string) (func(a, b Task) int, error) {
+	if strings.TrimSpace(spec) == "" {
+		spec = "priority"
+	}
+
+	var compares []func(a, b Task) int
+	for _, name := range strings.Split(spec, ",") {
+		name = strings.TrimSpace(name)
+		field, reverse := strings.CutPrefix(name, "-")
+		f, ok := sortFields[field]
+		if !ok {
+			return nil, fmt.Errorf("invalid sort field: %s (valid: %s, each optionally prefixed with -)", name, strings.Join(SortFields, ", "))
+		}
+		compares = append(compares, func(a, b Task) int {
+			if f.missing != nil {
+				if ma, mb := f.missing(a), f.missing(b); ma || mb {
+					return cmpBool(ma, mb)
+				}
+			}
+			if reverse {
+				return f.compare(b, a)
+			}
+			return f.compare(a, b)
+		})
+	}
+
+	return func(a, b Task) int {
+		for _, compare := range compares {
+			if c := compare(a, b); c != 0 {
+				return c
+			}
+		}
+		return 0
+	}, nil
+}
+
+// cmpBool orders false before true
+func cmpBool(a, b bool) int {
+	switch {
+	case a == b:
+		return 0
+	case a:
+		return 1
+	default:
+		return -1
+	}
+}
+
+// Page returns at most limit items starting at offset, or every item from
+// offset on if limit is 0
+func Page[T any](items []T, offset, limit int) []T {
+	items = items[min(max(offset, 0), len(items)):]
+	if limit > 0 && limit < len(items) {
+		items = items[:limit]
+	}
+	return items
+}
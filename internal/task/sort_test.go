@@ -0,0 +1,70 @@
+package task
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestSortTasks(t *testing.T) {
+	a := NewTaskComplete("t-a", Todo, TypeTask, "beta", "", 2, "")
+	a.SetDue(parseDate("2026-11-01"))
+	b := NewTaskComplete("t-b", Todo, TypeTask, "Alpha", "", 1, "")
+	c := NewTaskComplete("t-c", Todo, TypeTask, "gamma", "", 2, "")
+	c.SetDue(parseDate("2026-10-01"))
+	c.SetEstimate(3)
+	d := NewTaskComplete("t-d", Todo, TypeTask, "delta", "", 1, "")
+	d.SetEstimate(1)
+
+	tests := []struct {
+		spec string
+		want []string
+	}{
+		{"", []string{"t-b", "t-d", "t-a", "t-c"}},
+		{"title", []string{"t-b", "t-a", "t-d", "t-c"}},
+		{"-title", []string{"t-c", "t-d", "t-a", "t-b"}},
+		{"priority,-id", []string{"t-d", "t-b", "t-c", "t-a"}},
+		{"due", []string{"t-c", "t-a", "t-b", "t-d"}},
+		// Tasks without a value stay last when reversed
+		{"-due", []string{"t-a", "t-c", "t-b", "t-d"}},
+		{"estimate, title", []string{"t-d", "t-c", "t-b", "t-a"}},
+	}
+	for _, tt := range tests {
+		tasks := []Task{a, b, c, d}
+		if err := SortTasks(tasks, tt.spec); err != nil {
+			t.Errorf("SortTasks(%q) unexpected error: %v", tt.spec, err)
+			continue
+		}
+		var got []string
+		for _, task := range tasks {
+			got = append(got, task.ID())
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("SortTasks(%q) = %v, want %v", tt.spec, got, tt.want)
+		}
+	}
+
+	for _, spec := range []string{"colour", "priority,", "--due"} {
+		if err := SortTasks(nil, spec); err == nil {
+			t.Errorf("SortTasks(%q) expected error, got nil", spec)
+		}
+	}
+}
+
+func TestPage(t *testing.T) {
+	items := []int{1, 2, 3, 4, 5}
+	tests := []struct {
+		offset, limit int
+		want          []int
+	}{
+		{0, 0, []int{1, 2, 3, 4, 5}},
+		{0, 2, []int{1, 2}},
+		{2, 2, []int{3, 4}},
+		{4, 2, []int{5}},
+		{9, 2, []int{}},
+	}
+	for _, tt := range tests {
+		if got := Page(items, tt.offset, tt.limit); !slices.Equal(got, tt.want) {
+			t.Errorf("Page(%d, %d) = %v, want %v", tt.offset, tt.limit, got, tt.want)
+		}
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"net/url"
 	"slices"
 	"strings"
+	"time"
 )
 
 type Task struct {
@@ -18,20 +19,49 @@ type Task struct {
 	blocks      []string
 	labels      []string
 	link        string
+	createdAt   time.Time
+	updatedAt   time.Time
+	completedAt time.Time
+	archivedAt  time.Time
+	comments    []Comment
+	parentID    string
+	children    []string
+	progress    *Progress
+	due         time.Time
+	estimate    float64
+	assignee    string
+	fields      []Field
+	links       []Link
+	relations   []Relation
 }
 
 // TaskJSON is the JSON-serializable representation of a Task
 type TaskJSON struct {
-	ID          string   `json:"id"`
-	Title       string   `json:"title"`
-	Description string   `json:"description"`
-	Status      string   `json:"status"`
-	Type        string   `json:"type"`
-	Priority    int      `json:"priority"`
-	BlockedBy   []string `json:"blocked_by,omitempty"`
-	Blocks      []string `json:"blocks,omitempty"`
-	Labels      []string `json:"labels,omitempty"`
-	Link        string   `json:"link,omitempty"`
+	ID           string         `json:"id"`
+	Title        string         `json:"title"`
+	Description  string         `json:"description"`
+	Status       string         `json:"status"`
+	Type         string         `json:"type"`
+	Priority     int            `json:"priority"`
+	BlockedBy    []string       `json:"blocked_by,omitempty"`
+	Blocks       []string       `json:"blocks,omitempty"`
+	Labels       []string       `json:"labels,omitempty"`
+	Link         string         `json:"link,omitempty"`
+	CreatedAt    string         `json:"created_at,omitempty"`
+	UpdatedAt    string         `json:"updated_at,omitempty"`
+	CompletedAt  string         `json:"completed_at,omitempty"`
+	ArchivedAt   string         `json:"archived_at,omitempty"`
+	Comments     []Comment      `json:"comments,omitempty"`
+	ParentID     string         `json:"parent_id,omitempty"`
+	Children     []string       `json:"children,omitempty"`
+	Progress     *Progress      `json:"progress,omitempty"`
+	Due          string         `json:"due,omitempty"`
+	Estimate     float64        `json:"estimate,omitempty"`
+	Assignee     string         `json:"assignee,omitempty"`
+	AssigneeKind string         `json:"assignee_kind,omitempty"`
+	Fields       map[string]any `json:"fields,omitempty"`
+	Links        []Link         `json:"links,omitempty"`
+	Relations    []Relation     `json:"relations,omitempty"`
 }
 
 // TaskInput is used for parsing bulk task creation input
@@ -43,6 +73,12 @@ type TaskInput struct {
 	Priority    int      `json:"priority"`
 	Labels      []string `json:"labels"`
 	Link        string   `json:"link"`
+	Parent      string   `json:"parent"`
+	Due         string   `json:"due"`
+	Estimate    float64  `json:"estimate"`
+	Assignee    string   `json:"assignee"`
+	// Fields maps custom field keys to strings, numbers or bools
+	Fields map[string]any `json:"fields"`
 }
 
 // NewTask creates a new task with the given ID
@@ -107,6 +143,93 @@ func (t Task) Link() string {
 	return t.link
 }
 
+// CreatedAt returns when the task was created (zero if unknown)
+func (t Task) CreatedAt() time.Time {
+	return t.createdAt
+}
+
+// UpdatedAt returns when the task was last modified (zero if unknown)
+func (t Task) UpdatedAt() time.Time {
+	return t.updatedAt
+}
+
+// CompletedAt returns when the task was moved to done (zero if not done)
+func (t Task) CompletedAt() time.Time {
+	return t.completedAt
+}
+
+// ArchivedAt returns when the task was archived (zero if not archived)
+func (t Task) ArchivedAt() time.Time {
+	return t.archivedAt
+}
+
+// Comments returns the task's comments, oldest first. Only tasks loaded
+// individually have their comments loaded.
+func (t Task) Comments() []Comment {
+	return t.comments
+}
+
+// SetComments sets the task's comments
+func (t *Task) SetComments(comments []Comment) {
+	t.comments = comments
+}
+
+// ParentID returns the ID of the parent task, or "" for a top-level task
+func (t Task) ParentID() string {
+	return t.parentID
+}
+
+// SetParentID sets the parent task used when the task is created
+func (t *Task) SetParentID(id string) {
+	t.parentID = id
+}
+
+// Due returns the task's due date at midnight local time (zero if none)
+func (t Task) Due() time.Time {
+	return t.due
+}
+
+// SetDue sets the due date used when the task is created
+func (t *Task) SetDue(due time.Time) {
+	t.due = due
+}
+
+// Estimate returns the task's estimate, or 0 if it is not estimated
+func (t Task) Estimate() float64 {
+	return t.estimate
+}
+
+// SetEstimate sets the estimate used when the task is created
+func (t *Task) SetEstimate(estimate float64) {
+	t.estimate = estimate
+}
+
+// Assignee returns who the task is assigned to, or "" if unassigned
+func (t Task) Assignee() string {
+	return t.assignee
+}
+
+// SetAssignee sets the assignee used when the task is created
+func (t *Task) SetAssignee(assignee string) {
+	t.assignee = assignee
+}
+
+// Children returns the IDs of the task's direct subtasks
+func (t Task) Children() []string {
+	return t.children
+}
+
+// Progress returns how many of the task's subtasks are done, or nil if it
+// has none
+func (t Task) Progress() *Progress {
+	return t.progress
+}
+
+// IsArchived returns true if the task has been archived
+func (t Task) IsArchived() bool {
+	return !t.archivedAt.IsZero()
+}
+
 // BlockedBy returns the IDs of tasks that block this task
 func (t Task) BlockedBy() []string {
 	return t.blockedBy
@@ -211,23 +334,39 @@ func (t Task) IsBlocked() bool {
 // ToJSON converts a Task to its JSON-serializable form
 func (t Task) ToJSON() TaskJSON {
 	return TaskJSON{
-		ID:          t.id,
-		Title:       t.title,
-		Description: t.description,
-		Status:      t.status.String(),
-		Type:        t.taskType.String(),
-		Priority:    t.priority,
-		BlockedBy:   t.blockedBy,
-		Blocks:      t.blocks,
-		Labels:      t.labels,
-		Link:        t.link,
+		ID:           t.id,
+		Title:        t.title,
+		Description:  t.description,
+		Status:       t.status.String(),
+		Type:         t.taskType.String(),
+		Priority:     t.priority,
+		BlockedBy:    t.blockedBy,
+		Blocks:       t.blocks,
+		Labels:       t.labels,
+		Link:         t.link,
+		CreatedAt:    formatTimestamp(t.createdAt),
+		UpdatedAt:    formatTimestamp(t.updatedAt),
+		CompletedAt:  formatTimestamp(t.completedAt),
+		ArchivedAt:   formatTimestamp(t.archivedAt),
+		Comments:     t.comments,
+		ParentID:     t.parentID,
+		Children:     t.children,
+		Progress:     t.progress,
+		Due:          FormatDate(t.due),
+		Estimate:     t.estimate,
+		Assignee:     t.assignee,
+		AssigneeKind: AssigneeKind(t.assignee),
+		Fields:       fieldsJSON(t.fields),
+		Links:        t.links,
+		Relations:    t.relations,
 	}
 }
 
-// SetStatus updates the task status with validation
+// SetStatus updates the task status with validation. Whether the status
+// is part of the store's workflow is checked when the task is saved.
 func (t *Task) SetStatus(s Status) error {
-	if s < Todo || s > Done {
-		return ErrInvalidStatus
+	if _, err := ParseStatus(string(s)); err != nil {
+		return err
 	}
 	t.status = s
 	return nil
@@ -268,124 +407,55 @@ func (t Task) Validate() error {
 	if t.title == "" {
 		return ErrEmptyTitle
 	}
-	if t.status < Todo || t.status > Done {
-		return ErrInvalidStatus
+	if _, err := ParseStatus(string(t.status)); err != nil {
+		return err
 	}
 	if err := ValidateLink(t.link); err != nil {
 		return err
 	}
-	return nil
-}
-
-type Status int
-
-func (s Status) getNext() Status {
-	if s == Done {
-		return Todo
+	if err := ValidateEstimate(t.estimate); err != nil {
+		return err
 	}
-	return s + 1
-}
-
-func (s Status) getPrev() Status {
-	if s == Todo {
-		return Done
+	if _, err := ParseAssignee(t.assignee); err != nil {
+		return err
 	}
-	return s - 1
+	return nil
 }
 
+// Status is the name of a workflow status, see Workflow
+type Status string
+
+// Statuses of the default workflow
 const (
-	Todo Status = iota
-	InProgress
-	Done
+	Todo       Status = "todo"
+	InProgress Status = "in-progress"
+	Done       Status = "done"
 )
 
 // String returns the string representation of a status
 func (s Status) String() string {
-	switch s {
-	case Todo:
-		return "todo"
-	case InProgress:
-		return "in-progress"
-	case Done:
-		return "done"
-	default:
-		return "unknown"
-	}
+	return string(s)
 }
 
-// ParseStatus parses a string into a status value
+// ParseStatus parses a status name: lowercase letters, digits, hyphens and
+// underscores. Use Workflow.Parse to also check that the store defines it.
 func ParseStatus(s string) (Status, error) {
-	switch s {
-	case "todo":
-		return Todo, nil
-	case "in-progress":
-		return InProgress, nil
-	case "done":
-		return Done, nil
-	default:
-		return 0, fmt.Errorf("invalid status: %s (valid: todo, in-progress, done)", s)
+	if s == "" {
+		return "", fmt.Errorf("%w: status cannot be empty", ErrInvalidStatus)
 	}
-}
-
-// TaskType represents the type of task
-type TaskType int
-
-const (
-	TypeTask TaskType = iota
-	TypeBug
-	TypeFeature
-	TypeChore
-	TypeDocs
-)
-
-// String returns the string representation of a task type
-func (t TaskType) String() string {
-	switch t {
-	case TypeTask:
-		return "task"
-	case TypeBug:
-		return "bug"
-	case TypeFeature:
-		return "feature"
-	case TypeChore:
-		return "chore"
-	case TypeDocs:
-		return "docs"
-	default:
-		return "task"
+	if !isName(s) {
+		return "", fmt.Errorf("%w: %s (use lowercase letters, digits, - and _)", ErrInvalidStatus, s)
 	}
+	return Status(s), nil
 }
 
-// Symbol returns a short symbol for the task type (for TUI display)
-func (t TaskType) Symbol() string {
-	switch t {
-	case TypeBug:
-		return "B"
-	case TypeFeature:
-		return "F"
-	case TypeChore:
-		return "C"
-	case TypeDocs:
-		return "D"
-	default:
-		return "T"
-	}
-}
-
-// ParseTaskType parses a string into a task type value
-func ParseTaskType(s string) (TaskType, error) {
-	switch s {
-	case "task", "":
-		return TypeTask, nil
-	case "bug":
-		return TypeBug, nil
-	case "feature":
-		return TypeFeature, nil
-	case "chore":
-		return TypeChore, nil
-	case "docs":
-		return TypeDocs, nil
-	default:
-		return TypeTask, fmt.Errorf("invalid type: %s (valid: task, bug, feature, chore, docs)", s)
+// isName reports whether s is a valid status or type name: lowercase
+// letters, digits, hyphens and underscores
+func isName(s string) bool {
+	for _, r := range s {
+		if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '-' && r != '_' {
+			return false
+		}
 	}
+	return s != ""
 }
@@ -1,6 +1,7 @@
 package task
 
 import (
+	"errors"
 	"testing"
 )
 
@@ -15,19 +16,19 @@ func TestValidate_EmptyTitle(t *testing.T) {
 func TestValidate_InvalidStatus(t *testing.T) {
 	task := Task{
 		id:          "test-id",
-		status:      Status(-1),
+		status:      Status(""),
 		title:       "valid title",
 		description: "description",
 	}
 	err := task.Validate()
-	if err != ErrInvalidStatus {
+	if !errors.Is(err, ErrInvalidStatus) {
 		t.Errorf("expected ErrInvalidStatus, got %v", err)
 	}
 
-	task.status = Status(99)
+	task.status = Status("In Progress")
 	err = task.Validate()
-	if err != ErrInvalidStatus {
-		t.Errorf("expected ErrInvalidStatus for status 99, got %v", err)
+	if !errors.Is(err, ErrInvalidStatus) {
+		t.Errorf("expected ErrInvalidStatus for a malformed status, got %v", err)
 	}
 }
 
@@ -51,7 +52,7 @@ func TestValidate_Success(t *testing.T) {
 	}
 }
 
-func TestStatusGetNext(t *testing.T) {
+func TestWorkflowNext(t *testing.T) {
 	tests := []struct {
 		current  Status
 		expected Status
@@ -59,30 +60,13 @@ func TestStatusGetNext(t *testing.T) {
 		{Todo, InProgress},
 		{InProgress, Done},
 		{Done, Todo},
+		{"unknown", Todo},
 	}
 
 	for _, tt := range tests {
-		result := tt.current.getNext()
+		result := DefaultWorkflow.Next(tt.current)
 		if result != tt.expected {
-			t.Errorf("getNext(%d) = %d, expected %d", tt.current, result, tt.expected)
-		}
-	}
-}
-
-func TestStatusGetPrev(t *testing.T) {
-	tests := []struct {
-		current  Status
-		expected Status
-	}{
-		{Todo, Done},
-		{InProgress, Todo},
-		{Done, InProgress},
-	}
-
-	for _, tt := range tests {
-		result := tt.current.getPrev()
-		if result != tt.expected {
-			t.Errorf("getPrev(%d) = %d, expected %d", tt.current, result, tt.expected)
+			t.Errorf("Next(%s) = %s, expected %s", tt.current, result, tt.expected)
 		}
 	}
 }
@@ -98,13 +82,13 @@ func TestSetStatus(t *testing.T) {
 		t.Errorf("expected status InProgress, got %v", task.Status())
 	}
 
-	err = task.SetStatus(Status(-1))
-	if err != ErrInvalidStatus {
+	err = task.SetStatus(Status(""))
+	if !errors.Is(err, ErrInvalidStatus) {
 		t.Errorf("expected ErrInvalidStatus, got %v", err)
 	}
 
-	err = task.SetStatus(Status(99))
-	if err != ErrInvalidStatus {
-		t.Errorf("expected ErrInvalidStatus for status 99, got %v", err)
+	err = task.SetStatus(Status("Done!"))
+	if !errors.Is(err, ErrInvalidStatus) {
+		t.Errorf("expected ErrInvalidStatus for a malformed status, got %v", err)
 	}
 }
@@ -0,0 +1,240 @@
+package task
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/lucas-tremaroli/pace/internal/storage"
+)
+
+// TaskType is the name of a task type, see TypeRegistry
+type TaskType string
+
+// Built-in task types
+const (
+	TypeTask    TaskType = "task"
+	TypeBug     TaskType = "bug"
+	TypeFeature TaskType = "feature"
+	TypeChore   TaskType = "chore"
+	TypeDocs    TaskType = "docs"
+)
+
+// maxSymbolLength is the longest symbol a type may have, in characters
+const maxSymbolLength = 3
+
+// String returns the string representation of a task type
+func (t TaskType) String() string {
+	return string(t)
+}
+
+// ParseTaskType parses a type name; an empty name is a plain task. Use
+// TypeRegistry.Parse to also check that the store defines it.
+func ParseTaskType(s string) (TaskType, error) {
+	if s == "" {
+		return TypeTask, nil
+	}
+	if !isName(s) {
+		return "", fmt.Errorf("%w: %s (use lowercase letters, digits, - and _)", ErrInvalidType, s)
+	}
+	return TaskType(s), nil
+}
+
+// TypeDef describes a task type
+type TypeDef struct {
+	Name    TaskType `json:"name"`
+	Symbol  string   `json:"symbol"`
+	Color   string   `json:"color,omitempty"`
+	Builtin bool     `json:"builtin"`
+
+	// code is how tasks of this type are stored
+	code int
+}
+
+// TypeRegistry lists the task types a store knows about: the built-in
+// types followed by user-defined ones
+type TypeRegistry []TypeDef
+
+// BuiltinTypes are available in every store. Their codes are the ones
+// stores have always recorded, so existing tasks keep their type.
+var BuiltinTypes = TypeRegistry{
+	{Name: TypeTask, Symbol: "T", Builtin: true, code: 0},
+	{Name: TypeBug, Symbol: "B", Builtin: true, code: 1},
+	{Name: TypeFeature, Symbol: "F", Builtin: true, code: 2},
+	{Name: TypeChore, Symbol: "C", Builtin: true, code: 3},
+	{Name: TypeDocs, Symbol: "D", Builtin: true, code: 4},
+}
+
+// LoadTypes returns the built-in types and those defined for a store
+func LoadTypes(db *storage.DB) (TypeRegistry, error) {
+	records, err := db.GetTaskTypes()
+	if err != nil {
+		return nil, err
+	}
+	r := slices.Clone(BuiltinTypes)
+	for _, rec := range records {
+		r = append(r, TypeDef{Name: TaskType(rec.Name), Symbol: rec.Symbol, Color: rec.Color, code: rec.Code})
+	}
+	return r, nil
+}
+
+// String returns the type names, comma-separated
+func (r TypeRegistry) String() string {
+	names := make([]string, len(r))
+	for i, def := range r {
+		names[i] = string(def.Name)
+	}
+	return strings.Join(names, ", ")
+}
+
+// Types returns the type names in order
+func (r TypeRegistry) Types() []TaskType {
+	types := make([]TaskType, len(r))
+	for i, def := range r {
+		types[i] = def.Name
+	}
+	return types
+}
+
+// Get returns the definition of a type
+func (r TypeRegistry) Get(t TaskType) (TypeDef, bool) {
+	i := slices.IndexFunc(r, func(def TypeDef) bool { return def.Name == t })
+	if i < 0 {
+		return TypeDef{}, false
+	}
+	return r[i], true
+}
+
+// Parse parses a type name and checks that it is defined
+func (r TypeRegistry) Parse(s string) (TaskType, error) {
+	t, err := ParseTaskType(s)
+	if err != nil {
+		return "", err
+	}
+	if err := r.Check(t); err != nil {
+		return "", err
+	}
+	return t, nil
+}
+
+// Check returns ErrInvalidType if t is not defined
+func (r TypeRegistry) Check(t TaskType) error {
+	if _, ok := r.Get(t); !ok {
+		return fmt.Errorf("%w: %s (valid: %s)", ErrInvalidType, t, r)
+	}
+	return nil
+}
+
+// Symbol returns the short symbol shown for a type, or "?" if it is not
+// defined
+func (r TypeRegistry) Symbol(t TaskType) string {
+	if def, ok := r.Get(t); ok {
+		return def.Symbol
+	}
+	return "?"
+}
+
+// Color returns the color of a type, or "" for the default
+func (r TypeRegistry) Color(t TaskType) string {
+	def, _ := r.Get(t)
+	return def.Color
+}
+
+// code returns the stored code of a type
+func (r TypeRegistry) code(t TaskType) (int, error) {
+	def, ok := r.Get(t)
+	if !ok {
+		return 0, r.Check(t)
+	}
+	return def.code, nil
+}
+
+// byCode returns the type stored as code. Unknown codes are plain tasks,
+// as they always have been.
+func (r TypeRegistry) byCode(code int) TaskType {
+	i := slices.IndexFunc(r, func(def TypeDef) bool { return def.code == code })
+	if i < 0 {
+		return TypeTask
+	}
+	return r[i].Name
+}
+
+// validColor reports whether s is an ANSI color number (0-255) or a hex
+// color like #f80 or #ff8800
+func validColor(s string) bool {
+	if hex, ok := strings.CutPrefix(s, "#"); ok {
+		if len(hex) != 3 && len(hex) != 6 {
+			return false
+		}
+		_, err := strconv.ParseUint(hex, 16, 32)
+		return err == nil
+	}
+	n, err := strconv.Atoi(s)
+	return err == nil && n >= 0 && n <= 255
+}
+
+// Types returns the task types defined for the store
+func (s *Service) Types() (TypeRegistry, error) {
+	return LoadTypes(s.db)
+}
+
+// AddType defines a new task type. The symbol is shown in listings and the
+// TUI; color is optional.
+func (s *Service) AddType(name, symbol, color string) (*TypeDef, error) {
+	var def *TypeDef
+	err := s.Atomic(func() error {
+		if !isName(name) {
+			return fmt.Errorf("%w: %q (use lowercase letters, digits, - and _)", ErrInvalidType, name)
+		}
+		t := TaskType(name)
+		if n := utf8.RuneCountInString(symbol); n == 0 || n > maxSymbolLength || strings.ContainsFunc(symbol, unicode.IsSpace) {
+			return fmt.Errorf("invalid symbol %q: use 1 to %d characters without spaces", symbol, maxSymbolLength)
+		}
+		if color != "" && !validColor(color) {
+			return fmt.Errorf("invalid color %q: use an ANSI color number (0-255) or a hex color like #ff8800", color)
+		}
+
+		types, err := s.Types()
+		if err != nil {
+			return err
+		}
+		if _, exists := types.Get(t); exists {
+			return fmt.Errorf("%w: %s", ErrDuplicateType, t)
+		}
+		code, err := s.db.AddTaskType(storage.TypeRecord{Name: name, Symbol: symbol, Color: color})
+		if err != nil {
+			return err
+		}
+		def = &TypeDef{Name: t, Symbol: symbol, Color: color, code: code}
+		return nil
+	})
+	return def, err
+}
+
+// RemoveType deletes a user-defined task type that no task uses
+func (s *Service) RemoveType(name string) error {
+	return s.Atomic(func() error {
+		types, err := s.Types()
+		if err != nil {
+			return err
+		}
+		def, ok := types.Get(TaskType(name))
+		if !ok {
+			return fmt.Errorf("%w: %s", ErrInvalidType, name)
+		}
+		if def.Builtin {
+			return fmt.Errorf("%w: %s", ErrBuiltinType, name)
+		}
+		count, err := s.db.CountTasksWithType(def.code)
+		if err != nil {
+			return err
+		}
+		if count > 0 {
+			return fmt.Errorf("%w: %s is used by %d task(s)", ErrTypeInUse, name, count)
+		}
+		return s.db.DeleteTaskType(def.code)
+	})
+}
@@ -0,0 +1,146 @@
+package task
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestAddType(t *testing.T) {
+	svc := newTestService(t)
+
+	def, err := svc.AddType("spike", "S", "#ff8800")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if def.Name != "spike" || def.Builtin {
+		t.Errorf("unexpected type definition: %+v", def)
+	}
+
+	for _, tt := range []struct {
+		name, symbol, color string
+		want                error
+	}{
+		{"spike", "X", "", ErrDuplicateType},
+		{"bug", "X", "", ErrDuplicateType},
+		{"Big Spike", "X", "", ErrInvalidType},
+	} {
+		if _, err := svc.AddType(tt.name, tt.symbol, tt.color); !errors.Is(err, tt.want) {
+			t.Errorf("AddType(%q) expected %v, got %v", tt.name, tt.want, err)
+		}
+	}
+	for _, symbol := range []string{"", "LONG", "a b"} {
+		if _, err := svc.AddType("research", symbol, ""); err == nil {
+			t.Errorf("AddType with symbol %q expected error, got nil", symbol)
+		}
+	}
+	if _, err := svc.AddType("research", "R", "orange"); err == nil {
+		t.Error("AddType with an invalid color expected error, got nil")
+	}
+
+	types, err := svc.Types()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(types) != len(BuiltinTypes)+1 || types.Symbol("spike") != "S" || types.Color("spike") != "#ff8800" {
+		t.Errorf("expected the built-in types and spike, got %+v", types)
+	}
+}
+
+func TestCustomTypeTasks(t *testing.T) {
+	svc := newTestService(t)
+	if _, err := svc.AddType("spike", "S", ""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	spike := NewTaskComplete("test-aaa", Todo, "spike", "investigate", "", 3, "")
+	bug := NewTaskComplete("test-bbb", Todo, TypeBug, "fix", "", 3, "")
+	for _, task := range []Task{spike, bug} {
+		if err := svc.CreateTask(task); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	err := svc.CreateTask(NewTaskComplete("test-ccc", Todo, "research", "unknown", "", 3, ""))
+	if !errors.Is(err, ErrInvalidType) {
+		t.Errorf("expected ErrInvalidType for an undefined type, got %v", err)
+	}
+
+	got, err := svc.GetTaskByID(spike.ID())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Type() != "spike" {
+		t.Errorf("expected type spike, got %s", got.Type())
+	}
+
+	// Built-in types keep the codes stores have always used
+	record, err := svc.db.GetTaskByID(bug.ID())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if record.TaskType != 1 {
+		t.Errorf("expected bug to be stored as code 1, got %d", record.TaskType)
+	}
+
+	// Types in use, and built-in types, cannot be removed
+	if err := svc.RemoveType("spike"); !errors.Is(err, ErrTypeInUse) {
+		t.Errorf("expected ErrTypeInUse, got %v", err)
+	}
+	if err := svc.RemoveType("bug"); !errors.Is(err, ErrBuiltinType) {
+		t.Errorf("expected ErrBuiltinType, got %v", err)
+	}
+	if err := svc.DeleteTask(spike.ID()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := svc.RemoveType("spike"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestValidColor(t *testing.T) {
+	for s, want := range map[string]bool{
+		"0": true, "255": true, "#f80": true, "#ff8800": true,
+		"256": false, "-1": false, "#ff88": false, "#gggggg": false, "red": false,
+	} {
+		if got := validColor(s); got != want {
+			t.Errorf("validColor(%q) = %v, want %v", s, got, want)
+		}
+	}
+}
+
+func TestRemoveType_CodeNotReused(t *testing.T) {
+	svc := newTestService(t)
+	if _, err := svc.AddType("spike", "S", ""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	task := NewTaskComplete("test-aaa", Todo, "spike", "investigate", "", 3, "")
+	if err := svc.CreateTask(task); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// The journal keeps the spike's code once it becomes a bug
+	svc.BeginOperation("retype")
+	if err := svc.UpdateTask(NewTaskComplete(task.ID(), Todo, TypeBug, "investigate", "", 3, "")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := svc.RemoveType("spike"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := svc.AddType("epic", "E", ""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Undoing brings the task back as a plain task, not as an epic
+	if _, err := svc.Undo(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got, err := svc.GetTaskByID(task.ID())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Type() != TypeTask {
+		t.Errorf("expected type task after undo, got %s", got.Type())
+	}
+	if count, err := svc.db.CountTasksWithType(0); err != nil || count != 1 {
+		t.Errorf("expected the task stored as a plain task, got %d (%v)", count, err)
+	}
+}
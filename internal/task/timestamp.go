@@ -0,0 +1,87 @@
+package task
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// timestampLayout is the format used to store task timestamps
+const timestampLayout = time.RFC3339
+
+// now returns the current time at the precision timestamps are stored with
+func now() time.Time {
+	return time.Now().UTC().Truncate(time.Second)
+}
+
+// formatTimestamp formats a timestamp for storage and JSON output.
+// Zero times format as an empty string.
+func formatTimestamp(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(timestampLayout)
+}
+
+// parseTimestamp parses a stored timestamp. Empty or malformed values
+// yield the zero time.
+func parseTimestamp(s string) time.Time {
+	if s == "" {
+		return time.Time{}
+	}
+	t, err := time.Parse(timestampLayout, s)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
+// ParseTimeBound parses a point in time for --since/--until style flags.
+// Accepts a date (2006-01-02), an RFC3339 timestamp, or a duration in the
+// past such as 90m, 24h, 7d or 2w.
+func ParseTimeBound(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if t, err := time.ParseInLocation("2006-01-02", s, time.Local); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	if d, err := parseAgo(s); err == nil {
+		return now().Add(-d), nil
+	}
+	return time.Time{}, fmt.Errorf("invalid time: %s (use YYYY-MM-DD, RFC3339, or a duration like 24h, 7d, 2w)", s)
+}
+
+// ParseTimeUntil parses an upper bound like ParseTimeBound, except that a
+// date means the end of that day, so "until 2026-01-15" includes the 15th
+func ParseTimeUntil(s string) (time.Time, error) {
+	if t, err := time.ParseInLocation("2006-01-02", strings.TrimSpace(s), time.Local); err == nil {
+		return t.AddDate(0, 0, 1).Add(-time.Nanosecond), nil
+	}
+	return ParseTimeBound(s)
+}
+
+// parseAgo parses a duration, additionally accepting day (d) and week (w) units
+func parseAgo(s string) (time.Duration, error) {
+	if n, ok := strings.CutSuffix(s, "d"); ok {
+		days, err := strconv.Atoi(n)
+		if err != nil || days < 0 {
+			return 0, fmt.Errorf("invalid duration: %s", s)
+		}
+		return time.Duration(days) * 24 * time.Hour, nil
+	}
+	if n, ok := strings.CutSuffix(s, "w"); ok {
+		weeks, err := strconv.Atoi(n)
+		if err != nil || weeks < 0 {
+			return 0, fmt.Errorf("invalid duration: %s", s)
+		}
+		return time.Duration(weeks) * 7 * 24 * time.Hour, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("invalid duration: %s", s)
+	}
+	return d, nil
+}
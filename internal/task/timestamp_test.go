@@ -0,0 +1,95 @@
+package task
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseTimeBound(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr bool
+	}{
+		{"date", "2026-01-15", false},
+		{"rfc3339", "2026-01-15T10:30:00Z", false},
+		{"hours", "24h", false},
+		{"days", "7d", false},
+		{"weeks", "2w", false},
+		{"garbage", "yesterday-ish", true},
+		{"negative days", "-3d", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseTimeBound(tt.input)
+			if tt.wantErr && err == nil {
+				t.Errorf("ParseTimeBound(%q) expected error, got nil", tt.input)
+			}
+			if !tt.wantErr && err != nil {
+				t.Errorf("ParseTimeBound(%q) unexpected error: %v", tt.input, err)
+			}
+		})
+	}
+}
+
+func TestParseTimeBound_Relative(t *testing.T) {
+	got, err := ParseTimeBound("7d")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := time.Now().Add(-7 * 24 * time.Hour)
+	if diff := got.Sub(want); diff > time.Minute || diff < -time.Minute {
+		t.Errorf("ParseTimeBound(7d) = %v, want about %v", got, want)
+	}
+}
+
+func TestParseTimeUntil(t *testing.T) {
+	got, err := ParseTimeUntil("2026-01-15")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	lastSecond := time.Date(2026, 1, 15, 23, 59, 59, 0, time.Local)
+	if got.Before(lastSecond) || !got.Before(lastSecond.Add(time.Second)) {
+		t.Errorf("ParseTimeUntil(2026-01-15) = %v, want the end of the day", got)
+	}
+
+	// Other forms are points in time already
+	got, err = ParseTimeUntil("2026-01-15T10:30:00Z")
+	if err != nil || !got.Equal(time.Date(2026, 1, 15, 10, 30, 0, 0, time.UTC)) {
+		t.Errorf("ParseTimeUntil(rfc3339) = %v, %v", got, err)
+	}
+
+	// Tasks updated on the day itself are at or before it
+	task := NewTaskComplete("test-aaa", Todo, TypeTask, "task", "", 3, "")
+	task.updatedAt = time.Date(2026, 1, 15, 12, 0, 0, 0, time.Local)
+	for query, want := range map[string]bool{
+		"updated<=2026-01-15": true,
+		"updated>2026-01-15":  false,
+		"updated<2026-01-15":  false,
+		"updated>=2026-01-15": true,
+		"updated<=2026-01-14": false,
+		"updated>2026-01-14":  true,
+	} {
+		filter, err := ParseFilter(query)
+		if err != nil {
+			t.Fatalf("ParseFilter(%q) unexpected error: %v", query, err)
+		}
+		if got := filter.Matches(task); got != want {
+			t.Errorf("%s matches = %v, want %v", query, got, want)
+		}
+	}
+}
+
+func TestTimestampRoundTrip(t *testing.T) {
+	ts := time.Date(2026, 3, 4, 5, 6, 7, 0, time.UTC)
+	if got := parseTimestamp(formatTimestamp(ts)); !got.Equal(ts) {
+		t.Errorf("round trip = %v, want %v", got, ts)
+	}
+	if formatTimestamp(time.Time{}) != "" {
+		t.Error("expected zero time to format as empty string")
+	}
+	if !parseTimestamp("").IsZero() {
+		t.Error("expected empty string to parse as zero time")
+	}
+}
@@ -1,5 +1,7 @@
 package task
 
+import "strings"
+
 const (
 	// UI layout constants
 	Margin = 4
@@ -12,3 +14,21 @@ const (
 	ColumnTitleInProgress = "In Progress"
 	ColumnTitleDone       = "Done"
 )
+
+// columnTitle returns the board column title for a status, such as
+// "In Review" for "in-review"
+func columnTitle(s Status) string {
+	switch s {
+	case Todo:
+		return ColumnTitleTodo
+	case InProgress:
+		return ColumnTitleInProgress
+	case Done:
+		return ColumnTitleDone
+	}
+	words := strings.FieldsFunc(string(s), func(r rune) bool { return r == '-' || r == '_' })
+	for i, word := range words {
+		words[i] = strings.ToUpper(word[:1]) + word[1:]
+	}
+	return strings.Join(words, " ")
+}
@@ -0,0 +1,83 @@
+package task
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/lucas-tremaroli/pace/internal/storage"
+)
+
+// View is a saved slice of the task list: filter queries that must all
+// match, and a sort field
+type View = storage.ViewRecord
+
+// SaveView checks a view's filters and sort and stores it under name,
+// replacing any view with that name
+func (s *Service) SaveView(name string, filters []string, sort string) (*View, error) {
+	if !isName(name) {
+		return nil, fmt.Errorf("%w: name %q (use lowercase letters, digits, - and _)", ErrInvalidView, name)
+	}
+	if len(filters) == 0 && sort == "" {
+		return nil, fmt.Errorf("%w: %s needs a filter or a sort", ErrInvalidView, name)
+	}
+	filter, err := ParseFilters(filters)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.ValidateFilter(filter); err != nil {
+		return nil, err
+	}
+	if err := SortTasks(nil, sort); err != nil {
+		return nil, err
+	}
+
+	at := formatTimestamp(now())
+	view := View{Name: name, Filters: filters, Sort: sort, CreatedAt: at, UpdatedAt: at}
+	if view.Filters == nil {
+		view.Filters = []string{}
+	}
+	if err := s.db.SaveView(view); err != nil {
+		return nil, err
+	}
+	return s.GetView(name)
+}
+
+// GetView returns a saved view by name
+func (s *Service) GetView(name string) (*View, error) {
+	view, err := s.db.GetView(name)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("%w: %s", ErrViewNotFound, name)
+	}
+	return view, err
+}
+
+// ListViews returns the saved views, by name
+func (s *Service) ListViews() ([]View, error) {
+	return s.db.GetViews()
+}
+
+// RemoveView deletes a saved view
+func (s *Service) RemoveView(name string) error {
+	found, err := s.db.DeleteView(name)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return fmt.Errorf("%w: %s", ErrViewNotFound, name)
+	}
+	return nil
+}
+
+// ViewFilter returns the filter of a saved view, checked against the
+// store's current workflow and types, which may have changed since the
+// view was saved
+func (s *Service) ViewFilter(view *View) (*TaskFilter, error) {
+	filter, err := ParseFilters(view.Filters)
+	if err != nil {
+		return nil, fmt.Errorf("view %s: %w", view.Name, err)
+	}
+	if err := s.ValidateFilter(filter); err != nil {
+		return nil, fmt.Errorf("view %s: %w", view.Name, err)
+	}
+	return filter, nil
+}
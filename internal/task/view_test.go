@@ -0,0 +1,77 @@
+package task
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSaveView(t *testing.T) {
+	svc := newTestService(t)
+
+	view, err := svc.SaveView("urgent", []string{"priority<=2", "status!=done"}, "due")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if view.Sort != "due" || len(view.Filters) != 2 || view.CreatedAt == "" {
+		t.Errorf("unexpected view: %+v", view)
+	}
+
+	// Saving again replaces the filters but keeps the creation time
+	updated, err := svc.SaveView("urgent", []string{"priority=1"}, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if updated.CreatedAt != view.CreatedAt || len(updated.Filters) != 1 || updated.Sort != "" {
+		t.Errorf("unexpected view after update: %+v", updated)
+	}
+
+	filter, err := svc.ViewFilter(updated)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !filter.Matches(NewTaskComplete("test-aaa", Todo, TypeTask, "task", "", 1, "")) ||
+		filter.Matches(NewTaskComplete("test-bbb", Todo, TypeTask, "task", "", 2, "")) {
+		t.Error("view filter does not match by priority")
+	}
+
+	tests := []struct {
+		name    string
+		filters []string
+		sort    string
+		err     error
+	}{
+		{"Urgent", []string{"priority=1"}, "", ErrInvalidView},
+		{"empty", nil, "", ErrInvalidView},
+		{"bad-filter", []string{"priority<3 OR"}, "", ErrInvalidFilter},
+		{"bad-status", []string{"status=review"}, "", ErrInvalidStatus},
+	}
+	for _, tt := range tests {
+		if _, err := svc.SaveView(tt.name, tt.filters, tt.sort); !errors.Is(err, tt.err) {
+			t.Errorf("SaveView(%q) expected %v, got %v", tt.name, tt.err, err)
+		}
+	}
+	if _, err := svc.SaveView("bad-sort", nil, "colour"); err == nil {
+		t.Error("expected an error for an invalid sort")
+	}
+
+	if _, err := svc.SaveView("backlog", []string{"status=todo"}, ""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	views, err := svc.ListViews()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(views) != 2 || views[0].Name != "backlog" || views[1].Name != "urgent" {
+		t.Errorf("expected backlog and urgent by name, got %+v", views)
+	}
+
+	if err := svc.RemoveView("urgent"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := svc.GetView("urgent"); !errors.Is(err, ErrViewNotFound) {
+		t.Errorf("expected ErrViewNotFound, got %v", err)
+	}
+	if err := svc.RemoveView("urgent"); !errors.Is(err, ErrViewNotFound) {
+		t.Errorf("expected ErrViewNotFound, got %v", err)
+	}
+}
@@ -1,6 +1,8 @@
 package task
 
 import (
+	"fmt"
+
 	"github.com/charmbracelet/bubbles/help"
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
@@ -27,18 +29,30 @@ var viewerKeys = viewerKeyMap{
 }
 
 type Viewer struct {
-	help   help.Model
-	task   Task
-	board  *Board
-	width  int
-	height int
+	help      help.Model
+	task      Task
+	comments  []Comment
+	relations []Relation
+	board     *Board
+	width     int
+	height    int
 }
 
 func NewViewer(task Task, board *Board) Viewer {
+	// Board tasks are loaded without comments and relations, so fetch
+	// them here
+	var comments []Comment
+	var relations []Relation
+	if board != nil && board.service != nil {
+		comments, _ = board.service.ListComments(task.ID())
+		relations, _ = board.service.ListRelations(task.ID())
+	}
 	return Viewer{
-		help:  help.New(),
-		task:  task,
-		board: board,
+		help:      help.New(),
+		task:      task,
+		comments:  comments,
+		relations: relations,
+		board:     board,
 	}
 }
 
@@ -76,6 +90,9 @@ func (v Viewer) View() string {
 	valueStyle := lipgloss.NewStyle().
 		Foreground(lipgloss.Color("252"))
 
+	metaStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("243"))
+
 	boxStyle := lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
 		BorderForeground(lipgloss.Color("62")).
@@ -105,13 +122,45 @@ func (v Viewer) View() string {
 		valueStyle.Render(desc),
 	)
 
-	content := lipgloss.JoinVertical(
-		lipgloss.Left,
+	sections := []string{
 		headerStyle.Render("Task Details"),
 		titleSection,
 		"",
 		descSection,
-	)
+	}
+
+	if len(v.relations) > 0 {
+		sections = append(sections, "", labelStyle.Render(fmt.Sprintf("Relations (%d):", len(v.relations))))
+		for _, r := range v.relations {
+			sections = append(sections, valueStyle.Render(r.Kind+" ")+metaStyle.Render(r.ID))
+		}
+	}
+
+	if links := v.task.Links(); len(links) > 0 {
+		sections = append(sections, "", labelStyle.Render(fmt.Sprintf("Links (%d):", len(links))))
+		for _, l := range links {
+			line := fmt.Sprintf("#%d [%s] ", l.ID, l.Kind)
+			if l.Title != "" {
+				line += l.Title + " "
+			}
+			sections = append(sections, valueStyle.Render(line)+metaStyle.Render(l.Target))
+		}
+	}
+
+	if len(v.comments) > 0 {
+		sections = append(sections, "", labelStyle.Render(fmt.Sprintf("Comments (%d):", len(v.comments))))
+		for i, c := range v.comments {
+			if i > 0 {
+				sections = append(sections, "")
+			}
+			sections = append(sections,
+				metaStyle.Render(fmt.Sprintf("#%d %s · %s", c.ID, c.Author, c.CreatedAt)),
+				valueStyle.Render(c.Body),
+			)
+		}
+	}
+
+	content := lipgloss.JoinVertical(lipgloss.Left, sections...)
 
 	return lipgloss.JoinVertical(
 		lipgloss.Left,
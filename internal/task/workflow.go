@@ -0,0 +1,171 @@
+package task
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/lucas-tremaroli/pace/internal/storage"
+)
+
+const (
+	// ConfigKeyStatuses is the config key for the comma-separated list of
+	// statuses, in board order
+	ConfigKeyStatuses = "statuses"
+	// ConfigKeyTerminalStatuses is the config key for the comma-separated
+	// statuses that count as done
+	ConfigKeyTerminalStatuses = "terminal_statuses"
+)
+
+// StatusDef is one status of a workflow
+type StatusDef struct {
+	Name     Status `json:"name"`
+	Terminal bool   `json:"terminal"`
+}
+
+// Workflow is the ordered list of statuses tasks move through. Tasks in a
+// terminal status count as done: they do not block other tasks, are never
+// ready, and have a completed time.
+type Workflow []StatusDef
+
+// DefaultWorkflow is used when no statuses are configured
+var DefaultWorkflow = Workflow{
+	{Name: Todo},
+	{Name: InProgress},
+	{Name: Done, Terminal: true},
+}
+
+// ParseWorkflow builds a workflow from comma-separated status names and the
+// comma-separated subset of them that are terminal. If terminal is empty,
+// the last status is terminal.
+func ParseWorkflow(statuses, terminal string) (Workflow, error) {
+	var w Workflow
+	for _, name := range splitList(statuses) {
+		status, err := ParseStatus(name)
+		if err != nil {
+			return nil, err
+		}
+		if w.Has(status) {
+			return nil, fmt.Errorf("duplicate status in workflow: %s", status)
+		}
+		w = append(w, StatusDef{Name: status})
+	}
+	if len(w) == 0 {
+		return nil, fmt.Errorf("workflow must have at least one status")
+	}
+
+	terminalNames := splitList(terminal)
+	if len(terminalNames) == 0 {
+		w[len(w)-1].Terminal = true
+	}
+	for _, name := range terminalNames {
+		i := w.index(Status(name))
+		if i < 0 {
+			return nil, fmt.Errorf("terminal status %s is not in the workflow (%s)", name, w)
+		}
+		w[i].Terminal = true
+	}
+	return w, nil
+}
+
+// LoadWorkflow returns the workflow configured for a store, or the default
+func LoadWorkflow(db *storage.DB) (Workflow, error) {
+	statuses, err := configValue(db, ConfigKeyStatuses, DefaultWorkflow.String())
+	if err != nil {
+		return nil, err
+	}
+	terminal, err := configValue(db, ConfigKeyTerminalStatuses, "")
+	if err != nil {
+		return nil, err
+	}
+	w, err := ParseWorkflow(statuses, terminal)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s config: %w", ConfigKeyStatuses, err)
+	}
+	return w, nil
+}
+
+// splitList splits a comma-separated list, dropping empty entries
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// String returns the status names, comma-separated
+func (w Workflow) String() string {
+	names := make([]string, len(w))
+	for i, def := range w {
+		names[i] = string(def.Name)
+	}
+	return strings.Join(names, ", ")
+}
+
+// Statuses returns the status names in order
+func (w Workflow) Statuses() []Status {
+	statuses := make([]Status, len(w))
+	for i, def := range w {
+		statuses[i] = def.Name
+	}
+	return statuses
+}
+
+func (w Workflow) index(s Status) int {
+	return slices.IndexFunc(w, func(def StatusDef) bool { return def.Name == s })
+}
+
+// Initial returns the status new tasks start in
+func (w Workflow) Initial() Status {
+	return w[0].Name
+}
+
+// Closed returns the first terminal status, where closed tasks go
+func (w Workflow) Closed() Status {
+	for _, def := range w {
+		if def.Terminal {
+			return def.Name
+		}
+	}
+	return w[len(w)-1].Name
+}
+
+// Has reports whether s is one of the workflow's statuses
+func (w Workflow) Has(s Status) bool {
+	return w.index(s) >= 0
+}
+
+// IsTerminal reports whether s is a terminal status
+func (w Workflow) IsTerminal(s Status) bool {
+	i := w.index(s)
+	return i >= 0 && w[i].Terminal
+}
+
+// Parse parses a status name and checks that it is in the workflow
+func (w Workflow) Parse(s string) (Status, error) {
+	status, err := ParseStatus(s)
+	if err != nil {
+		return "", err
+	}
+	if err := w.Check(status); err != nil {
+		return "", err
+	}
+	return status, nil
+}
+
+// Check returns ErrInvalidStatus if s is not in the workflow
+func (w Workflow) Check(s Status) error {
+	if !w.Has(s) {
+		return fmt.Errorf("%w: %s (valid: %s)", ErrInvalidStatus, s, w)
+	}
+	return nil
+}
+
+// Next returns the status after s, wrapping around to the first. Statuses
+// outside the workflow move to the first status.
+func (w Workflow) Next(s Status) Status {
+	return w[(w.index(s)+1)%len(w)].Name
+}
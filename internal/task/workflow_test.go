@@ -0,0 +1,95 @@
+package task
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestParseWorkflow(t *testing.T) {
+	w, err := ParseWorkflow("backlog, todo,in-progress,review,done,wontfix", "done,wontfix")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []Status{"backlog", Todo, InProgress, "review", Done, "wontfix"}
+	if !slices.Equal(w.Statuses(), want) {
+		t.Errorf("expected statuses %v, got %v", want, w.Statuses())
+	}
+	for _, s := range want {
+		terminal := s == Done || s == "wontfix"
+		if w.IsTerminal(s) != terminal {
+			t.Errorf("IsTerminal(%s) = %v, expected %v", s, w.IsTerminal(s), terminal)
+		}
+	}
+
+	// Without terminal statuses, the last one is terminal
+	w, err = ParseWorkflow("open,closed", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if w.IsTerminal("open") || !w.IsTerminal("closed") {
+		t.Errorf("expected only the last status to be terminal, got %+v", w)
+	}
+
+	for _, tt := range []struct{ statuses, terminal string }{
+		{"", ""},
+		{"todo,todo", ""},
+		{"todo,In Review", ""},
+		{"todo,done", "closed"},
+	} {
+		if _, err := ParseWorkflow(tt.statuses, tt.terminal); err == nil {
+			t.Errorf("ParseWorkflow(%q, %q) expected error, got nil", tt.statuses, tt.terminal)
+		}
+	}
+}
+
+func TestCustomWorkflow(t *testing.T) {
+	svc := newTestService(t)
+	if err := svc.db.SetConfig(ConfigKeyStatuses, "backlog,todo,review,shipped"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	blocker := NewTaskComplete("test-aaa", "review", TypeTask, "blocker", "", 3, "")
+	blocked := NewTaskComplete("test-bbb", "backlog", TypeTask, "blocked", "", 3, "")
+	for _, task := range []Task{blocker, blocked} {
+		if err := svc.CreateTask(task); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if err := svc.AddDependency(blocker.ID(), blocked.ID()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Statuses outside the workflow are rejected
+	err := svc.CreateTask(NewTaskComplete("test-ccc", InProgress, TypeTask, "invalid", "", 3, ""))
+	if !errors.Is(err, ErrInvalidStatus) {
+		t.Errorf("expected ErrInvalidStatus, got %v", err)
+	}
+
+	ready, err := svc.GetReadyTasks(false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(ready) != 1 || ready[0].ID() != blocker.ID() {
+		t.Errorf("expected only the blocker to be ready, got %d tasks", len(ready))
+	}
+
+	// Moving the blocker to the terminal status completes it and unblocks
+	if err := svc.UpdateTask(NewTaskComplete(blocker.ID(), "shipped", TypeTask, "blocker", "", 3, "")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got, err := svc.GetTaskByID(blocker.ID())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.CompletedAt().IsZero() {
+		t.Error("expected completed time to be set for a terminal status")
+	}
+	ready, err = svc.GetReadyTasks(false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(ready) != 1 || ready[0].ID() != blocked.ID() {
+		t.Errorf("expected only the blocked task to be ready, got %d tasks", len(ready))
+	}
+}